@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sc-internacional/internal/admin"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/clients/mongodb"
+	"sc-internacional/internal/standings"
+)
+
+const usage = `usage: admin <command> [flags]
+
+commands:
+  seed                                  load Inter's reference teams, championships and matches
+  import -collection <name> -file <f>   import a JSON array or NDJSON file
+  export -collection <name> -file <f>   export a collection as JSON, or NDJSON for .ndjson/.jsonl files
+  recompute-standings [-championship <id>]
+                                        rebuild standings from the recorded matches
+  create-indexes                        create the indexes used by the API
+  validate                              check references between teams, championships and matches
+
+collections: teams, championships, matches
+configuration is read from MONGO_URI and DB_NAME`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "seed", "import", "export", "recompute-standings", "create-indexes", "validate":
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	collection := flags.String("collection", "", "collection to import or export")
+	file := flags.String("file", "", "file to import from or export to")
+	championship := flags.String("championship", "", "championship id, all championships when empty")
+	flags.Parse(args)
+
+	config, err := mongodb.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	mongodbClient, err := mongodb.NewMongoClient(config)
+	if err != nil {
+		return err
+	}
+	defer mongodbClient.MongoClient.Disconnect(context.Background())
+	db := mongodbClient.Database()
+
+	// Only a shared cache outlives this process, so recomputing standings can
+	// drop the tables the API serves from it.
+	cacheConfig, err := cache.NewConfig()
+	if err != nil {
+		return err
+	}
+	c, err := cache.New(cacheConfig)
+	if err != nil {
+		return err
+	}
+
+	standingService := standings.NewService(standings.NewRepository(db.Collection("standings"), db.Collection("matches")), c)
+	a := admin.New(db, standingService, os.Stdout)
+
+	switch command {
+	case "seed":
+		return a.Seed(ctx)
+	case "import":
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return a.Import(ctx, *collection, f)
+	case "export":
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return a.Export(ctx, *collection, f, *file)
+	case "recompute-standings":
+		return a.RecomputeStandings(ctx, *championship)
+	case "create-indexes":
+		return a.CreateIndexes(ctx)
+	case "validate":
+		issues, err := a.Validate(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		if len(issues) > 0 {
+			return fmt.Errorf("found %d integrity issues", len(issues))
+		}
+
+		fmt.Println("no integrity issues found")
+	}
+
+	return nil
+}
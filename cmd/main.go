@@ -5,36 +5,350 @@ import (
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/championships"
 	"sc-internacional/internal/clients/mongodb"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/graphql"
+	"sc-internacional/internal/health"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/live"
+	"sc-internacional/internal/logging"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/media"
+	"sc-internacional/internal/metrics"
+	"sc-internacional/internal/openapi"
+	"sc-internacional/internal/ratelimit"
+	"sc-internacional/internal/rpc"
+	"sc-internacional/internal/search"
+	"sc-internacional/internal/server"
+	"sc-internacional/internal/standings"
 	"sc-internacional/internal/teams"
+	"sc-internacional/internal/tracing"
+	"sc-internacional/internal/webhooks"
+	"syscall"
 )
 
 func main() {
-	r := gin.Default()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	//TODO: refactor this mongo block
-	config, err := mongodb.NewConfig()
+	loggingConfig, err := logging.NewConfig()
 	if err != nil {
 		log.Fatal(err)
 		return
 	}
+	logger := logging.New(loggingConfig, os.Stdout)
 
-	mongodbClient, _ := mongodb.NewMongoClient(config)
-	defer mongodbClient.MongoClient.Disconnect(context.Background())
-	db := mongodbClient.Database()
+	tracingConfig, err := tracing.NewConfig()
+	if err != nil {
+		logger.Error("invalid tracing configuration", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, tracingConfig)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	r := gin.New()
+
+	serverConfig, err := server.NewConfig()
+	if err != nil {
+		logger.Error("invalid server configuration", "error", err)
+		os.Exit(1)
+	}
+	if err = r.SetTrustedProxies(serverConfig.TrustedProxies); err != nil {
+		logger.Error("invalid trusted proxies", "error", err)
+		os.Exit(1)
+	}
+
+	i18nConfig, err := i18n.NewConfig()
+	if err != nil {
+		logger.Error("invalid i18n configuration", "error", err)
+		os.Exit(1)
+	}
+
+	r.Use(tracing.Middleware(tracingConfig), logging.RequestIDMiddleware(), logging.AccessLogMiddleware(logger), logging.RecoveryMiddleware(logger), metrics.Middleware(), server.MaxBodyBytes(serverConfig.MaxBodyBytes), i18n.Middleware(i18nConfig))
+
+	authConfig, err := auth.NewConfig()
+	if err != nil {
+		logger.Error("invalid auth configuration", "error", err)
+		os.Exit(1)
+	}
+
+	authenticator, err := auth.New(authConfig)
+	if err != nil {
+		logger.Error("invalid auth configuration", "error", err)
+		os.Exit(1)
+	}
+
+	rateLimitConfig, err := ratelimit.NewConfig()
+	if err != nil {
+		logger.Error("invalid rate limit configuration", "error", err)
+		os.Exit(1)
+	}
+	limiters := ratelimit.NewLimiters(rateLimitConfig)
+
+	versionConfig, err := apiversion.NewConfig()
+	if err != nil {
+		logger.Error("invalid api version configuration", "error", err)
+		os.Exit(1)
+	}
+
+	mongoConfig, err := mongodb.NewConfig()
+	if err != nil {
+		logger.Error("invalid mongo configuration", "error", err)
+		os.Exit(1)
+	}
+
+	mongodbClient, err := mongodb.NewMongoClient(mongoConfig)
+	if err != nil {
+		logger.Error("failed to connect to mongo", "error", err)
+		os.Exit(1)
+	}
+
+	cacheConfig, err := cache.NewConfig()
+	if err != nil {
+		logger.Error("invalid cache configuration", "error", err)
+		os.Exit(1)
+	}
+
+	responseCache, err := cache.New(cacheConfig)
+	if err != nil {
+		logger.Error("failed to set up cache", "error", err)
+		os.Exit(1)
+	}
+
+	checks := map[string]health.Checker{"mongodb": mongodbClient}
+	redisCache, sharedCache := responseCache.(*cache.Redis)
+	if sharedCache {
+		checks["redis"] = redisCache
+	}
+
+	eventsConfig, err := events.NewConfig()
+	if err != nil {
+		logger.Error("invalid events configuration", "error", err)
+		os.Exit(1)
+	}
+
+	bus := events.NewBus()
+	var publisher events.Publisher = bus
+	var outbox *events.Outbox
+	if eventsConfig.Outbox {
+		outbox = events.NewOutbox(events.NewRepository(mongodbClient.Collection("outbox")), mongodbClient, bus)
+		publisher = outbox
+	}
 
-	teamRepository := teams.NewRepository(db)
-	teamService := teams.NewService(teamRepository)
+	auditRepository := audit.NewRepository(mongodbClient.Collection("audit"))
+	auditService := audit.NewService(auditRepository)
+	auditController := audit.NewController(auditService)
+
+	teamRepository := teams.NewRepository(mongodbClient.Collection("teams"), mongodbClient.Collection("matches"), mongodbClient.Collection("championships"))
+	teamService := teams.NewService(teamRepository, auditService, responseCache, publisher)
 	teamController := teams.NewController(teamService)
 
-	routers(r, teamController)
+	championshipRepository := championships.NewRepository(mongodbClient.Collection("championships"), mongodbClient.Collection("matches"))
+	championshipService := championships.NewService(championshipRepository, auditService, responseCache, publisher)
+	championshipController := championships.NewController(championshipService)
+
+	matchRepository := matches.NewRepository(mongodbClient.Collection("matches"))
+	matchService := matches.NewService(matchRepository, auditService, responseCache, publisher)
+	matchController := matches.NewController(matchService)
+
+	standingRepository := standings.NewRepository(mongodbClient.Collection("standings"), mongodbClient.Collection("matches"))
+	standingService := standings.NewService(standingRepository, responseCache)
+	standingController := standings.NewController(standingService)
+	bus.Subscribe(standingService.HandleMatchEvent, events.MatchTypes...)
 
-	r.Run()
+	liveConfig, err := live.NewConfig()
+	if err != nil {
+		logger.Error("invalid live configuration", "error", err)
+		os.Exit(1)
+	}
+
+	liveRepository := live.NewRepository(mongodbClient.Collection("match_updates"), mongodbClient.Collection("matches"))
+	liveService := live.NewService(liveRepository, liveConfig)
+	liveController := live.NewController(liveService, liveConfig)
+	bus.Subscribe(liveService.HandleEvent, events.MatchTypes...)
+
+	webhookConfig, err := webhooks.NewConfig()
+	if err != nil {
+		logger.Error("invalid webhook configuration", "error", err)
+		os.Exit(1)
+	}
+
+	webhookRepository := webhooks.NewRepository(mongodbClient.Collection("webhooks"), mongodbClient.Collection("webhook_deliveries"))
+	webhookService := webhooks.NewService(webhookRepository, webhookConfig)
+	webhookController := webhooks.NewController(webhookService)
+	bus.Subscribe(webhookService.HandleEvent)
+	go webhookService.Run(ctx)
+
+	searchConfig, err := search.NewConfig()
+	if err != nil {
+		logger.Error("invalid search configuration", "error", err)
+		os.Exit(1)
+	}
+
+	searchRepository := search.NewRepository(mongodbClient.Collection("teams"), mongodbClient.Collection("championships"), mongodbClient.Collection("matches"))
+	searchService := search.NewService(searchRepository, search.NewIndex(), searchConfig)
+	searchController := search.NewController(searchService)
+	bus.Subscribe(searchService.HandleEvent, events.Types...)
+	go searchService.Run(ctx)
+
+	mediaConfig, err := media.NewConfig()
+	if err != nil {
+		logger.Error("invalid media configuration", "error", err)
+		os.Exit(1)
+	}
+
+	mediaService := media.NewService(media.NewDisk(mediaConfig.Dir), teamService, mediaConfig)
+	mediaController := media.NewController(mediaService, mediaConfig)
+	bus.Subscribe(mediaService.HandleEvent, events.TeamPurged)
+
+	// Relay only once every subscriber is in place, or the events it picks up
+	// would be marked dispatched without reaching them.
+	relayStopped := make(chan struct{})
+	if outbox != nil {
+		go func() {
+			defer close(relayStopped)
+			outbox.Relay(ctx, eventsConfig.RelayInterval, eventsConfig.RelayBatch)
+		}()
+	} else {
+		close(relayStopped)
+	}
+
+	graphqlConfig, err := graphql.NewConfig()
+	if err != nil {
+		logger.Error("invalid graphql configuration", "error", err)
+		os.Exit(1)
+	}
+
+	graphqlController, err := graphql.NewController(teamService, championshipService, matchService, standingService, graphqlConfig)
+	if err != nil {
+		logger.Error("failed to build graphql schema", "error", err)
+		os.Exit(1)
+	}
+
+	healthController := health.NewController(checks)
+
+	routers(r, authenticator, limiters, versionConfig, teamController, championshipController, matchController, liveController, standingController, graphqlController, auditController, webhookController, searchController, mediaController, healthController)
+
+	srv := server.New(serverConfig, r)
+	srv.OnShutdown(healthController.Shutdown)
+	srv.OnShutdown(liveService.Shutdown)
+
+	grpcConfig, err := rpc.NewConfig()
+	if err != nil {
+		logger.Error("invalid grpc configuration", "error", err)
+		os.Exit(1)
+	}
+
+	grpcServer, err := rpc.New(grpcConfig, logger, authenticator, limiters, teams.NewGRPCServer(teamService), championships.NewGRPCServer(championshipService), matches.NewGRPCServer(matchService))
+	if err != nil {
+		logger.Error("failed to set up grpc server", "error", err)
+		os.Exit(1)
+	}
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		defer close(grpcStopped)
+		logger.Info("starting grpc server", "port", grpcConfig.Port)
+		if err := grpcServer.Run(ctx); err != nil {
+			logger.Error("grpc server stopped with error", "error", err)
+			stop()
+		}
+	}()
+
+	logger.Info("starting server", "port", serverConfig.Port)
+	if err = srv.Run(ctx); err != nil {
+		logger.Error("server stopped with error", "error", err)
+		stop()
+	}
+	<-grpcStopped
+	<-relayStopped
+	bus.Wait()
+
+	disconnectCtx, cancel := context.WithTimeout(context.Background(), serverConfig.ShutdownTimeout)
+	defer cancel()
+
+	if err = mongodbClient.MongoClient.Disconnect(disconnectCtx); err != nil {
+		logger.Error("failed to disconnect from mongo", "error", err)
+	}
+
+	if sharedCache {
+		if err = redisCache.Close(); err != nil {
+			logger.Error("failed to close redis connections", "error", err)
+		}
+	}
+
+	if err = shutdownTracing(disconnectCtx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
+	}
+	logger.Info("server stopped")
 }
 
-func routers(r *gin.Engine, controllerTeam *teams.Controller) {
-	r.POST("/teams", controllerTeam.PostTeam)
-	r.GET("/teams/:id", controllerTeam.GetTeam)
-	r.GET("/teams", controllerTeam.GetAllTeams)
+func routers(r *gin.Engine, authenticator *auth.Authenticator, limiters ratelimit.Limiters, versionConfig *apiversion.Config, controllerTeam *teams.Controller, controllerChampionship *championships.Controller, controllerMatch *matches.Controller, controllerLive *live.Controller, controllerStanding *standings.Controller, controllerGraphql *graphql.Controller, controllerAudit *audit.Controller, controllerWebhook *webhooks.Controller, controllerSearch *search.Controller, controllerMedia *media.Controller, controllerHealth *health.Controller) {
+	r.GET("/metrics", metrics.Handler())
+	r.GET("/healthz", controllerHealth.Healthz)
+	r.GET("/readyz", controllerHealth.Readyz)
 	r.GET("/", func(ctx *gin.Context) { ctx.JSON(http.StatusOK, gin.H{"message": "VAMO COLORADO!!"}) })
+	r.GET("/openapi.json", document().Handler())
+	r.GET("/docs/*filepath", openapi.UI("/openapi.json"))
+
+	// The limiters are shared, so that a client spends the same budget on
+	// every version, on the aliases and over gRPC.
+	register := func(api *gin.RouterGroup) {
+		reads := api.Group("/", ratelimit.Middleware(limiters.Reads), authenticator.Reads())
+		reads.GET("/teams/:id", controllerTeam.GetTeam)
+		reads.GET("/teams", controllerTeam.GetAllTeams)
+		reads.GET("/teams/:id/crest", controllerMedia.GetCrest)
+		reads.GET("/players/:name/photo", controllerMedia.GetPhoto)
+		reads.GET("/championships/:id", controllerChampionship.GetChampionship)
+		reads.GET("/matches/:id", controllerMatch.GetMatch)
+		reads.GET("/matches/:id/live", controllerLive.GetLiveMatch)
+		reads.GET("/export/teams", controllerTeam.ExportTeams)
+		reads.GET("/export/matches", controllerMatch.ExportMatches)
+		reads.GET("/export/standings", controllerStanding.ExportStandings)
+		reads.GET("/search", controllerSearch.Search)
+		reads.GET("/graphql", controllerGraphql.Query)
+		reads.POST("/graphql", controllerGraphql.Query)
+
+		writes := api.Group("/", ratelimit.Middleware(limiters.Writes), authenticator.Require(auth.Editor))
+		writes.POST("/teams", controllerTeam.PostTeam)
+		writes.PUT("/teams/:id", controllerTeam.PutTeam)
+		writes.DELETE("/teams/:id", controllerTeam.DeleteTeam)
+		writes.POST("/teams/:id/restore", controllerTeam.RestoreTeam)
+		writes.PUT("/teams/:id/crest", controllerMedia.PutCrest)
+		writes.DELETE("/teams/:id/crest", controllerMedia.DeleteCrest)
+		writes.PUT("/players/:name/photo", controllerMedia.PutPhoto)
+		writes.DELETE("/players/:name/photo", controllerMedia.DeletePhoto)
+		writes.POST("/championships", controllerChampionship.PostChampionship)
+		writes.PUT("/championships/:id", controllerChampionship.PutChampionship)
+		writes.DELETE("/championships/:id", controllerChampionship.DeleteChampionship)
+		writes.POST("/championships/:id/restore", controllerChampionship.RestoreChampionship)
+		writes.POST("/matches", controllerMatch.PostMatch)
+		writes.PUT("/matches/:id", controllerMatch.PutMatch)
+		writes.DELETE("/matches/:id", controllerMatch.DeleteMatch)
+		writes.POST("/matches/:id/restore", controllerMatch.RestoreMatch)
+
+		admin := api.Group("/", ratelimit.Middleware(limiters.Reads), authenticator.Require(auth.Admin))
+		admin.GET("/audit", controllerAudit.GetAudit)
+		admin.POST("/webhooks", controllerWebhook.PostWebhook)
+		admin.GET("/webhooks", controllerWebhook.GetWebhooks)
+		admin.DELETE("/webhooks/:id", controllerWebhook.DeleteWebhook)
+		admin.GET("/webhooks/:id/deliveries", controllerWebhook.GetWebhookDeliveries)
+		admin.GET("/webhooks/dead-letters", controllerWebhook.GetDeadLetters)
+		admin.POST("/webhooks/deliveries/:id/retry", controllerWebhook.RetryWebhookDelivery)
+	}
+
+	register(apiversion.Group(r, apiversion.V1, authenticator.Authenticate()))
+	register(apiversion.Group(r, apiversion.V2, authenticator.Authenticate()))
+	register(apiversion.Alias(r, apiversion.V1, versionConfig, authenticator.Authenticate()))
 }
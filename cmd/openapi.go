@@ -0,0 +1,310 @@
+package main
+
+import (
+	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/export"
+	"sc-internacional/internal/live"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/media"
+	"sc-internacional/internal/openapi"
+	"sc-internacional/internal/search"
+	"sc-internacional/internal/standings"
+	"sc-internacional/internal/teams"
+	"sc-internacional/internal/webhooks"
+)
+
+// document describes every route routers registers, those of each version in
+// full and the unversioned aliases as deprecated copies of /v1. TestDocument
+// fails when the two drift apart.
+func document() *openapi.Document {
+	d := openapi.New("S.C. Internacional API", "1.0.0")
+	v1 := apiversion.Prefix(apiversion.V1)
+
+	d.Route(http.MethodGet, "/metrics").Describe("operations", "Prometheus metrics").
+		RespondAs(http.StatusOK, "Metrics in the Prometheus text format", map[string]*openapi.Schema{"text/plain": openapi.String()})
+	d.Route(http.MethodGet, "/healthz").Describe("operations", "Liveness probe").
+		Respond(http.StatusOK, "The process is alive", openapi.Object(map[string]*openapi.Schema{"status": openapi.Enum("ok")}, "status"))
+	d.Route(http.MethodGet, "/readyz").Describe("operations", "Readiness probe").
+		Respond(http.StatusOK, "Every dependency is up", readiness()).
+		Respond(http.StatusServiceUnavailable, "A dependency is down or the process is shutting down", readiness())
+	d.Route(http.MethodGet, "/").Describe("operations", "Greeting").
+		Respond(http.StatusOK, "VAMO COLORADO!!", openapi.Object(map[string]*openapi.Schema{"message": openapi.String()}, "message"))
+	d.Route(http.MethodGet, "/openapi.json").Describe("operations", "This document").
+		Respond(http.StatusOK, "The OpenAPI document", &openapi.Schema{Type: "object"})
+	d.Route(http.MethodGet, "/docs/*filepath").Describe("operations", "Swagger UI").
+		Note("Browse this document at /docs/.").
+		RespondAs(http.StatusOK, "A page or asset of Swagger UI", map[string]*openapi.Schema{"text/html": openapi.String()}).
+		Respond(http.StatusNotFound, "No such asset", nil)
+
+	shared := shapes{
+		team:         d.Schema(teams.Team{}),
+		championship: d.Schema(championships.Championship{}),
+		standing:     d.Schema(standings.Standing{}),
+		entry:        d.Schema(audit.Entry{}),
+		subscription: d.Schema(webhooks.Subscription{}),
+		delivery:     d.Schema(webhooks.Delivery{}),
+		results:      d.Schema(search.Results{}),
+		image:        d.Schema(media.Image{}),
+	}
+
+	v1Shapes := shared
+	v1Shapes.match = d.Schema(matches.V1Match{})
+	v1Shapes.update = d.Schema(live.V1Update{})
+	v1Shapes.wrap = func(schema *openapi.Schema) *openapi.Schema { return schema }
+	routes(d, v1, v1Shapes)
+
+	meta, links := d.Schema(apiversion.Meta{}), d.Schema(apiversion.Links{})
+	v2Shapes := shared
+	v2Shapes.match = d.Schema(matches.Match{})
+	v2Shapes.update = d.Schema(live.Update{})
+	v2Shapes.wrap = func(schema *openapi.Schema) *openapi.Schema {
+		return openapi.Object(map[string]*openapi.Schema{"data": schema, "meta": meta, "links": links}, "data", "meta", "links")
+	}
+	routes(d, apiversion.Prefix(apiversion.V2), v2Shapes)
+
+	d.Alias(v1, "Deprecated: use the same route under "+v1+", which this one answers for until its sunset.", map[string]openapi.Header{
+		"Deprecation": {Description: "When the route was deprecated, as @ and a Unix time", Schema: openapi.String()},
+		"Sunset":      {Description: "When the route stops being served", Schema: openapi.String()},
+		"Link":        {Description: "The successor-version of the route", Schema: openapi.String()},
+	})
+
+	return d
+}
+
+// shapes are the schemas of the bodies of one version. wrap turns the
+// schema of a resource into that of a response carrying it.
+type shapes struct {
+	team, championship, match, standing, entry, subscription, delivery, update, results, image *openapi.Schema
+
+	wrap func(*openapi.Schema) *openapi.Schema
+}
+
+// routes describes the API routes of the version served under prefix.
+func routes(d *openapi.Document, prefix string, s shapes) {
+	read(d.Route(http.MethodGet, prefix+"/teams/:id")).Describe("teams", "Get a team").
+		Query("includeDeleted", "Also find a deleted team. Admins only.", openapi.Boolean()).
+		Header("If-None-Match", "ETag of the copy the caller holds", false).
+		Respond(http.StatusOK, "The team", s.wrap(s.team)).
+		Respond(http.StatusNotModified, "The team did not change", nil).
+		ResponseHeader("ETag", "Version of the team", http.StatusOK, http.StatusNotModified).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+	read(d.Route(http.MethodGet, prefix+"/teams")).Describe("teams", "List teams").
+		Query("includeDeleted", "Also list deleted teams. Admins only.", openapi.Boolean()).
+		Respond(http.StatusOK, "Every team", s.wrap(openapi.ArrayOf(s.team))).
+		Errors(http.StatusInternalServerError)
+	write(d.Route(http.MethodPost, prefix+"/teams")).Describe("teams", "Create a team").
+		Body(s.team).
+		Respond(http.StatusCreated, "The created team", s.wrap(s.team)).
+		ResponseHeader("ETag", "Version of the team", http.StatusCreated).
+		Errors(http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusInternalServerError)
+	replace(d.Route(http.MethodPut, prefix+"/teams/:id"), "team", s.team, s.wrap(s.team))
+	remove(d.Route(http.MethodDelete, prefix+"/teams/:id"), "team")
+	restore(d.Route(http.MethodPost, prefix+"/teams/:id/restore"), "team", s.wrap(s.team))
+
+	images := map[string]*openapi.Schema{media.PNG: openapi.Binary(), media.JPEG: openapi.Binary(), media.SVG: openapi.Binary()}
+	read(d.Route(http.MethodGet, prefix+"/teams/:id/crest")).Describe("teams", "Get the crest of a team").
+		Note("Served with Cache-Control, ETag and Last-Modified, and answers conditional and range requests.").
+		Query("size", "Get the thumbnail instead, scaled down to fit a square", openapi.Enum("thumbnail")).
+		Header("If-None-Match", "ETag of the copy the caller holds", false).
+		RespondAs(http.StatusOK, "The image, in the format it was uploaded in", images).
+		Respond(http.StatusNotModified, "The crest did not change", nil).
+		ResponseHeader("ETag", "Digest of the image", http.StatusOK, http.StatusNotModified).
+		ResponseHeader("Cache-Control", "How long the image can be cached", http.StatusOK, http.StatusNotModified).
+		Errors(http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	write(d.Route(http.MethodPut, prefix+"/teams/:id/crest")).Describe("teams", "Upload the crest of a team").
+		Note("The body is the image itself. Its format is told by its bytes, whatever the Content-Type, and a thumbnail is made from it.").
+		BodyAs(images).
+		Respond(http.StatusOK, "Where the crest and its thumbnail are served", s.wrap(s.image)).
+		Errors(http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusInternalServerError)
+	write(d.Route(http.MethodDelete, prefix+"/teams/:id/crest")).Describe("teams", "Delete the crest of a team").
+		Respond(http.StatusNoContent, "The crest and its thumbnail were deleted", nil).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+
+	read(d.Route(http.MethodGet, prefix+"/players/:name/photo")).Describe("players", "Get the photo of a player").
+		Note("Players are named as in match incidents. Served as crests are.").
+		Query("size", "Get the thumbnail instead, scaled down to fit a square", openapi.Enum("thumbnail")).
+		Header("If-None-Match", "ETag of the copy the caller holds", false).
+		RespondAs(http.StatusOK, "The image, in the format it was uploaded in", images).
+		Respond(http.StatusNotModified, "The photo did not change", nil).
+		ResponseHeader("ETag", "Digest of the image", http.StatusOK, http.StatusNotModified).
+		ResponseHeader("Cache-Control", "How long the image can be cached", http.StatusOK, http.StatusNotModified).
+		Errors(http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	write(d.Route(http.MethodPut, prefix+"/players/:name/photo")).Describe("players", "Upload the photo of a player").
+		Note("The body is the image itself, as for crests.").
+		BodyAs(images).
+		Respond(http.StatusOK, "Where the photo and its thumbnail are served", s.wrap(s.image)).
+		Errors(http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusInternalServerError)
+	write(d.Route(http.MethodDelete, prefix+"/players/:name/photo")).Describe("players", "Delete the photo of a player").
+		Respond(http.StatusNoContent, "The photo and its thumbnail were deleted", nil).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+
+	read(d.Route(http.MethodGet, prefix+"/championships/:id")).Describe("championships", "Get a championship").
+		Query("includeDeleted", "Also find a deleted championship. Admins only.", openapi.Boolean()).
+		Header("If-None-Match", "ETag of the copy the caller holds", false).
+		Respond(http.StatusOK, "The championship", s.wrap(s.championship)).
+		Respond(http.StatusNotModified, "The championship did not change", nil).
+		ResponseHeader("ETag", "Version of the championship", http.StatusOK, http.StatusNotModified).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+	write(d.Route(http.MethodPost, prefix+"/championships")).Describe("championships", "Create a championship").
+		Body(s.championship).
+		Respond(http.StatusCreated, "The created championship", s.wrap(s.championship)).
+		ResponseHeader("ETag", "Version of the championship", http.StatusCreated).
+		Errors(http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusInternalServerError)
+	replace(d.Route(http.MethodPut, prefix+"/championships/:id"), "championship", s.championship, s.wrap(s.championship))
+	remove(d.Route(http.MethodDelete, prefix+"/championships/:id"), "championship")
+	restore(d.Route(http.MethodPost, prefix+"/championships/:id/restore"), "championship", s.wrap(s.championship))
+
+	read(d.Route(http.MethodGet, prefix+"/matches/:id")).Describe("matches", "Get a match").
+		Query("includeDeleted", "Also find a deleted match. Admins only.", openapi.Boolean()).
+		Header("If-None-Match", "ETag of the copy the caller holds", false).
+		Respond(http.StatusOK, "The match", s.wrap(s.match)).
+		Respond(http.StatusNotModified, "The match did not change", nil).
+		ResponseHeader("ETag", "Version of the match", http.StatusOK, http.StatusNotModified).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+	read(d.Route(http.MethodGet, prefix+"/matches/:id/live")).Describe("matches", "Follow a match live").
+		Note("Streams the updates of the match as Server-Sent Events, or over a WebSocket when the request asks for an upgrade. "+
+			"A stream without a cursor, or with one older than the updates still kept, opens with a match.snapshot.").
+		Header("Last-Event-ID", "Version of the last update received, to resume from", false).
+		Query("lastEventId", "Same as the Last-Event-ID header, for clients that cannot set it", openapi.Integer()).
+		RespondAs(http.StatusOK, "A stream of updates", map[string]*openapi.Schema{"text/event-stream": s.update}).
+		Respond(http.StatusSwitchingProtocols, "A WebSocket carrying one update per message", nil).
+		Errors(http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	write(d.Route(http.MethodPost, prefix+"/matches")).Describe("matches", "Create a match").
+		Body(s.match).
+		Respond(http.StatusCreated, "The created match", s.wrap(s.match)).
+		ResponseHeader("ETag", "Version of the match", http.StatusCreated).
+		Errors(http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusInternalServerError)
+	replace(d.Route(http.MethodPut, prefix+"/matches/:id"), "match", s.match, s.wrap(s.match))
+	remove(d.Route(http.MethodDelete, prefix+"/matches/:id"), "match")
+	restore(d.Route(http.MethodPost, prefix+"/matches/:id/restore"), "match", s.wrap(s.match))
+
+	exports(read(d.Route(http.MethodGet, prefix+"/export/teams")).Describe("exports", "Export teams"), s.team)
+	exports(read(d.Route(http.MethodGet, prefix+"/export/matches")).Describe("exports", "Export matches"), s.match)
+	exports(read(d.Route(http.MethodGet, prefix+"/export/standings")).Describe("exports", "Export standings"), s.standing).
+		Query("championshipId", "Only export the table of this championship", openapi.String())
+
+	read(d.Route(http.MethodGet, prefix+"/search")).Describe("search", "Search teams, championships and players").
+		Note("Accents and case do not matter, and names are found by their start or despite a typo or two.").
+		Query("q", "The words to look for, up to 100 characters. Required.", openapi.String()).
+		Query("limit", "Number of hits of each kind, 1 to 50", openapi.Integer()).
+		Respond(http.StatusOK, "The hits grouped by kind, best first", s.wrap(s.results)).
+		Errors(http.StatusBadRequest, http.StatusInternalServerError)
+
+	graphqlResult := openapi.Object(map[string]*openapi.Schema{"data": {Type: "object", Nullable: true}, "errors": openapi.ArrayOf(&openapi.Schema{Type: "object"})})
+	read(d.Route(http.MethodGet, prefix+"/graphql")).Describe("graphql", "Run a GraphQL query").
+		Query("query", "The query", openapi.String()).
+		Query("operationName", "The operation to run when the query holds several", openapi.String()).
+		Query("variables", "The variables of the query, as a JSON object", openapi.String()).
+		Respond(http.StatusOK, "The result", graphqlResult).
+		Respond(http.StatusBadRequest, "The query is invalid or too deep or complex", graphqlResult)
+	read(d.Route(http.MethodPost, prefix+"/graphql")).Describe("graphql", "Run a GraphQL query").
+		Body(openapi.Object(map[string]*openapi.Schema{"query": openapi.String(), "operationName": openapi.String(), "variables": {Type: "object"}}, "query")).
+		Respond(http.StatusOK, "The result", graphqlResult).
+		Respond(http.StatusBadRequest, "The query is invalid or too deep or complex", graphqlResult).
+		Respond(http.StatusRequestEntityTooLarge, "The body is over the size limit", graphqlResult)
+
+	admin(d.Route(http.MethodGet, prefix+"/audit")).Describe("audit", "Read the audit trail").
+		Query("entity", "Only entries about this kind of entity", openapi.Enum("team", "championship", "match")).
+		Query("id", "Only entries about the entity with this id. Needs entity.", openapi.String()).
+		Query("limit", "Number of entries, 1 to 1000", openapi.Integer()).
+		Respond(http.StatusOK, "The entries, newest first", s.wrap(openapi.ArrayOf(s.entry))).
+		Errors(http.StatusBadRequest, http.StatusInternalServerError)
+	admin(d.Route(http.MethodPost, prefix+"/webhooks")).Describe("webhooks", "Subscribe to events").
+		Body(s.subscription).
+		Respond(http.StatusCreated, "The subscription, with the secret that signs its deliveries", s.wrap(s.subscription)).
+		Errors(http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusInternalServerError)
+	admin(d.Route(http.MethodGet, prefix+"/webhooks")).Describe("webhooks", "List subscriptions").
+		Respond(http.StatusOK, "Every subscription, without secrets", s.wrap(openapi.ArrayOf(s.subscription))).
+		Errors(http.StatusInternalServerError)
+	admin(d.Route(http.MethodDelete, prefix+"/webhooks/:id")).Describe("webhooks", "Unsubscribe").
+		Respond(http.StatusNoContent, "The subscription was removed", nil).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+	admin(d.Route(http.MethodGet, prefix+"/webhooks/:id/deliveries")).Describe("webhooks", "List the deliveries of a subscription").
+		Query("status", "Only deliveries with this status", openapi.Enum("pending", "delivered", "dead")).
+		Query("limit", "Number of deliveries, 1 to 1000", openapi.Integer()).
+		Respond(http.StatusOK, "The deliveries, newest first", s.wrap(openapi.ArrayOf(s.delivery))).
+		Errors(http.StatusBadRequest, http.StatusInternalServerError)
+	admin(d.Route(http.MethodGet, prefix+"/webhooks/dead-letters")).Describe("webhooks", "List dead deliveries").
+		Query("limit", "Number of deliveries, 1 to 1000", openapi.Integer()).
+		Respond(http.StatusOK, "The deliveries that ran out of attempts, newest first", s.wrap(openapi.ArrayOf(s.delivery))).
+		Errors(http.StatusBadRequest, http.StatusInternalServerError)
+	admin(d.Route(http.MethodPost, prefix+"/webhooks/deliveries/:id/retry")).Describe("webhooks", "Retry a delivery").
+		Respond(http.StatusAccepted, "The delivery, queued again", s.wrap(s.delivery)).
+		Errors(http.StatusNotFound, http.StatusInternalServerError)
+}
+
+const acceptLanguage = "Language of the error messages and display names: pt-BR, en or es"
+
+// read, write and admin describe the authentication, rate limiting and
+// language negotiation of the route groups of the same name in routers.
+func read(o *openapi.Operation) *openapi.Operation {
+	return o.Secure(true).
+		Note("Needs the reader role unless reads are public.").
+		Header("Accept-Language", acceptLanguage, false).
+		Errors(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests)
+}
+
+func write(o *openapi.Operation) *openapi.Operation {
+	return o.Secure(false).
+		Note("Needs the editor role.").
+		Header("Accept-Language", acceptLanguage, false).
+		Errors(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests)
+}
+
+func admin(o *openapi.Operation) *openapi.Operation {
+	return o.Secure(false).
+		Note("Needs the admin role.").
+		Header("Accept-Language", acceptLanguage, false).
+		Errors(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests)
+}
+
+func replace(o *openapi.Operation, entity string, body, result *openapi.Schema) *openapi.Operation {
+	return write(o).Describe(entity+"s", "Replace a "+entity).
+		Header("If-Match", "ETag of the version last read, or * for any", true).
+		Body(body).
+		Respond(http.StatusOK, "The replaced "+entity, result).
+		ResponseHeader("ETag", "Version of the "+entity, http.StatusOK).
+		Errors(http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge, http.StatusPreconditionFailed, http.StatusPreconditionRequired, http.StatusInternalServerError)
+}
+
+func remove(o *openapi.Operation, entity string) *openapi.Operation {
+	return write(o).Describe(entity+"s", "Delete a "+entity).
+		Note("Marks the "+entity+" deleted. Admins can remove it for good instead, which conflicts while anything refers to it.").
+		Query("hard", "Remove the "+entity+" for good. Admins only.", openapi.Boolean()).
+		Respond(http.StatusNoContent, "The "+entity+" was deleted", nil).
+		Errors(http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusInternalServerError)
+}
+
+func restore(o *openapi.Operation, entity string, result *openapi.Schema) *openapi.Operation {
+	return write(o).Describe(entity+"s", "Restore a deleted "+entity).
+		Respond(http.StatusOK, "The restored "+entity, result).
+		ResponseHeader("ETag", "Version of the "+entity, http.StatusOK).
+		Errors(http.StatusNotFound, http.StatusPreconditionFailed, http.StatusInternalServerError)
+}
+
+func exports(o *openapi.Operation, schema *openapi.Schema) *openapi.Operation {
+	return o.Query("format", "Export format, instead of negotiating it with the Accept header", openapi.Enum(string(export.JSON), string(export.NDJSON), string(export.CSV), string(export.Excel))).
+		RespondAs(http.StatusOK, "Every record, streamed", map[string]*openapi.Schema{
+			export.MIMEJSON:   openapi.ArrayOf(schema),
+			export.MIMENDJSON: schema,
+			export.MIMECSV:    openapi.String(),
+			export.MIMEExcel:  openapi.String(),
+		}).
+		Errors(http.StatusNotAcceptable, http.StatusInternalServerError)
+}
+
+func readiness() *openapi.Schema {
+	dependency := openapi.Object(map[string]*openapi.Schema{
+		"status":    openapi.Enum("up", "down"),
+		"latencyMs": {Type: "number"},
+		"error":     openapi.String(),
+	}, "status", "latencyMs")
+
+	return openapi.Object(map[string]*openapi.Schema{
+		"status":       openapi.Enum("ok", "unavailable", "shutting down"),
+		"dependencies": openapi.MapOf(dependency),
+	}, "status")
+}
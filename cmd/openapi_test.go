@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/graphql"
+	"sc-internacional/internal/health"
+	"sc-internacional/internal/live"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/media"
+	"sc-internacional/internal/openapi"
+	"sc-internacional/internal/ratelimit"
+	"sc-internacional/internal/search"
+	"sc-internacional/internal/standings"
+	"sc-internacional/internal/teams"
+	"sc-internacional/internal/webhooks"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDocument fails when a route is added to routers without being
+// documented, or documented without being routed.
+func TestDocument(t *testing.T) {
+	r := newRouter(t)
+
+	var routes []string
+	for _, route := range r.Routes() {
+		routes = append(routes, route.Method+" "+openapi.Path(route.Path))
+	}
+
+	assert.ElementsMatch(t, routes, document().Operations())
+}
+
+// TestDocument_References fails when the document refers to a schema it
+// does not define, or a path parameter it does not declare.
+func TestDocument_References(t *testing.T) {
+	d := document()
+	body, err := json.Marshal(d)
+	assert.NoError(t, err)
+
+	for _, ref := range regexp.MustCompile(`"\$ref":"#/components/schemas/(\w+)"`).FindAllStringSubmatch(string(body), -1) {
+		assert.Contains(t, d.Components.Schemas, ref[1])
+	}
+
+	for path, item := range d.Paths {
+		for method, operation := range item {
+			for _, name := range regexp.MustCompile(`\{(\w+)\}`).FindAllStringSubmatch(path, -1) {
+				declared := false
+				for _, parameter := range operation.Parameters {
+					declared = declared || (parameter.In == "path" && parameter.Name == name[1])
+				}
+				assert.True(t, declared, "%s %s does not declare %s", strings.ToUpper(method), path, name[1])
+			}
+			assert.NotEmpty(t, operation.Responses, "%s %s has no responses", strings.ToUpper(method), path)
+		}
+	}
+}
+
+// TestDocument_Aliases fails when a /v1 operation has no deprecated alias,
+// or an alias is served without announcing its sunset.
+func TestDocument_Aliases(t *testing.T) {
+	d := document()
+	for path, item := range d.Paths {
+		if !strings.HasPrefix(path, "/v1/") {
+			continue
+		}
+		for method, operation := range item {
+			alias := d.Paths[strings.TrimPrefix(path, "/v1")][method]
+			if assert.NotNil(t, alias, "%s %s has no alias", strings.ToUpper(method), path) {
+				assert.False(t, operation.Deprecated)
+				assert.True(t, alias.Deprecated)
+				assert.Contains(t, alias.Responses["401"].Headers, "Sunset")
+			}
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	r := newRouter(t)
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/teams", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get("Deprecation"))
+	assert.NotEmpty(t, recorder.Header().Get("Sunset"))
+	assert.Equal(t, `</v1/teams>; rel="successor-version"`, recorder.Header().Get("Link"))
+}
+
+func TestDocument_Served(t *testing.T) {
+	r := newRouter(t)
+
+	tests := []struct {
+		name                string
+		path                string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{name: "when getting the document", path: "/openapi.json", expectedStatusCode: http.StatusOK, expectedContentType: "application/json; charset=utf-8", expectedBody: `"openapi":"3.0.3"`},
+		{name: "when getting the docs page", path: "/docs/", expectedStatusCode: http.StatusOK, expectedContentType: "text/html; charset=utf-8", expectedBody: `<div id="swagger-ui"></div>`},
+		{name: "when getting the docs initializer", path: "/docs/swagger-initializer.js", expectedStatusCode: http.StatusOK, expectedContentType: "text/javascript; charset=utf-8", expectedBody: `url: "/openapi.json"`},
+		{name: "when getting a missing asset", path: "/docs/gremio.js", expectedStatusCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, recorder.Header().Get("Content-Type"))
+			}
+			assert.Contains(t, recorder.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func newRouter(t *testing.T) *gin.Engine {
+	authenticator, err := auth.New(&auth.Config{PublicReads: true})
+	assert.NoError(t, err)
+
+	graphqlController, err := graphql.NewController(nil, nil, nil, nil, &graphql.Config{MaxDepth: 7, MaxComplexity: 1000})
+	assert.NoError(t, err)
+
+	r := gin.New()
+	routers(r, authenticator, ratelimit.NewLimiters(&ratelimit.Config{ReadsPerSecond: 20, ReadsBurst: 40, WritesPerSecond: 2, WritesBurst: 5, IdleTTL: time.Minute}), &apiversion.Config{},
+		teams.NewController(nil), championships.NewController(nil), matches.NewController(nil), live.NewController(nil, &live.Config{}),
+		standings.NewController(nil), graphqlController, audit.NewController(nil), webhooks.NewController(nil), search.NewController(nil), media.NewController(nil, &media.Config{}), health.NewController(nil))
+
+	return r
+}
@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"io"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+)
+
+const (
+	teamsCollection         = "teams"
+	championshipsCollection = "championships"
+	matchesCollection       = "matches"
+	standingsCollection     = "standings"
+	auditCollection         = "audit"
+	outboxCollection        = "outbox"
+	webhooksCollection      = "webhooks"
+	deliveriesCollection    = "webhook_deliveries"
+	liveUpdatesCollection   = "match_updates"
+)
+
+// entities maps every collection that can be imported or exported to the
+// type its documents are decoded into.
+var entities = map[string]func() interface{}{
+	teamsCollection:         func() interface{} { return &teams.Team{} },
+	championshipsCollection: func() interface{} { return &championships.Championship{} },
+	matchesCollection:       func() interface{} { return &matches.Match{} },
+}
+
+type standings interface {
+	Recompute(ctx context.Context, championshipId string) error
+}
+
+// Admin holds the maintenance operations exposed by the admin command line.
+type Admin struct {
+	db        *mongo.Database
+	standings standings
+	out       io.Writer
+}
+
+func New(db *mongo.Database, standings standings, out io.Writer) *Admin {
+	return &Admin{db: db, standings: standings, out: out}
+}
+
+func (a *Admin) RecomputeStandings(ctx context.Context, championshipId string) error {
+	return a.standings.Recompute(ctx, championshipId)
+}
+
+// toDocument converts an entity to the document stored in Mongo. Ids travel
+// as hex strings in the API, but are stored as ObjectIDs.
+func toDocument(entity interface{}) (bson.D, error) {
+	raw, err := bson.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.D
+	if err = bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for i, elem := range doc {
+		if elem.Key != "_id" {
+			continue
+		}
+
+		if hex, ok := elem.Value.(string); ok {
+			id, err := primitive.ObjectIDFromHex(hex)
+			if err != nil {
+				return nil, err
+			}
+			doc[i].Value = id
+		}
+	}
+
+	return doc, nil
+}
+
+// each decodes every document of a collection into its entity type and hands
+// it to fn, stopping at the first error.
+func (a *Admin) each(ctx context.Context, collection string, fn func(interface{}) error) error {
+	newEntity, ok := entities[collection]
+	if !ok {
+		return fmt.Errorf("unknown collection %q", collection)
+	}
+
+	cursor, err := a.db.Collection(collection).Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		entity := newEntity()
+		if err = cursor.Decode(entity); err != nil {
+			return err
+		}
+
+		if err = fn(entity); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func documentId(doc bson.D) interface{} {
+	for _, elem := range doc {
+		if elem.Key == "_id" {
+			return elem.Value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sc-internacional/internal/teams"
+	"testing"
+	"time"
+)
+
+func TestToDocument(t *testing.T) {
+	objectId, _ := primitive.ObjectIDFromHex("670000000000000000000001")
+	tests := []struct {
+		name    string
+		entity  interface{}
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name:   "when entity has no id",
+			entity: teams.Team{Name: "Internacional", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			want: bson.D{
+				{Key: "name", Value: "Internacional"},
+				{Key: "fullname", Value: ""},
+				{Key: "website", Value: ""},
+				{Key: "foundationdate", Value: primitive.NewDateTimeFromTime(time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC))},
+				{Key: "version", Value: int64(0)},
+			},
+		},
+		{
+			name:   "when entity has a hex id",
+			entity: teams.Team{Id: "670000000000000000000001", Name: "Internacional"},
+			want: bson.D{
+				{Key: "_id", Value: objectId},
+				{Key: "name", Value: "Internacional"},
+				{Key: "fullname", Value: ""},
+				{Key: "website", Value: ""},
+				{Key: "foundationdate", Value: primitive.NewDateTimeFromTime(time.Time{})},
+				{Key: "version", Value: int64(0)},
+			},
+		},
+		{
+			name:    "when entity has an invalid id",
+			entity:  teams.Team{Id: "xpto"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toDocument(tt.entity)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSeedData(t *testing.T) {
+	var s seed
+	assert.NoError(t, json.Unmarshal(seedData, &s))
+
+	for _, team := range s.Teams {
+		assert.NoError(t, binding.Validator.ValidateStruct(team))
+	}
+	for _, match := range s.Matches {
+		assert.NoError(t, binding.Validator.ValidateStruct(match))
+	}
+}
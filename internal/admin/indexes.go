@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// liveUpdatesTTL is how long, in seconds, live updates are kept for streams
+// to resume from.
+const liveUpdatesTTL = 7 * 24 * 60 * 60
+
+// indexes lists the indexes backing the queries issued by the repositories.
+// The text indexes back search: their language is none so that names are
+// neither stemmed nor stripped of stop words such as "do" in "Clube do Povo".
+var indexes = map[string][]mongo.IndexModel{
+	teamsCollection: {
+		{Keys: bson.D{{Key: "name", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "name", Value: "text"}, {Key: "nicknames", Value: "text"}, {Key: "fullname", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none").SetWeights(bson.D{{Key: "name", Value: 10}, {Key: "nicknames", Value: 9}, {Key: "fullname", Value: 7}}),
+		},
+	},
+	championshipsCollection: {
+		{Keys: bson.D{{Key: "season", Value: 1}, {Key: "name", Value: 1}}},
+		{Keys: bson.D{{Key: "name", Value: "text"}}, Options: options.Index().SetDefaultLanguage("none")},
+	},
+	matchesCollection: {
+		{Keys: bson.D{{Key: "incidents.player", Value: "text"}}, Options: options.Index().SetDefaultLanguage("none")},
+		{Keys: bson.D{{Key: "matchdate", Value: 1}}},
+		{Keys: bson.D{{Key: "championshipid", Value: 1}, {Key: "matchdate", Value: 1}}},
+		{Keys: bson.D{{Key: "teamhomeid", Value: 1}}},
+		{Keys: bson.D{{Key: "teamawayid", Value: 1}}},
+	},
+	standingsCollection: {
+		{Keys: bson.D{{Key: "championshipid", Value: 1}, {Key: "points", Value: -1}, {Key: "goaldifference", Value: -1}, {Key: "goalsfor", Value: -1}}},
+	},
+	auditCollection: {
+		{Keys: bson.D{{Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "entity", Value: 1}, {Key: "entityid", Value: 1}, {Key: "timestamp", Value: -1}}},
+	},
+	outboxCollection: {
+		{Keys: bson.D{{Key: "dispatchedat", Value: 1}, {Key: "occurredat", Value: 1}}},
+	},
+	webhooksCollection: {
+		{Keys: bson.D{{Key: "events", Value: 1}}},
+	},
+	deliveriesCollection: {
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextattemptat", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdat", Value: -1}}},
+		{Keys: bson.D{{Key: "subscriptionid", Value: 1}, {Key: "createdat", Value: -1}}},
+		// One delivery per subscription and event, however often the event
+		// is handled.
+		{Keys: bson.D{{Key: "subscriptionid", Value: 1}, {Key: "event._id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	liveUpdatesCollection: {
+		{Keys: bson.D{{Key: "matchid", Value: 1}, {Key: "version", Value: 1}}},
+		// Streams resuming from an expired update start over from a snapshot.
+		{Keys: bson.D{{Key: "occurredat", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(liveUpdatesTTL)},
+	},
+}
+
+// CreateIndexes creates the missing indexes. Creating an index that already
+// exists is a no-op in Mongo, so it is safe to run on every deploy.
+func (a *Admin) CreateIndexes(ctx context.Context) error {
+	for _, collection := range []string{teamsCollection, championshipsCollection, matchesCollection, standingsCollection, auditCollection, outboxCollection, webhooksCollection, deliveriesCollection, liveUpdatesCollection} {
+		names, err := a.db.Collection(collection).Indexes().CreateMany(ctx, indexes[collection])
+		if err != nil {
+			return fmt.Errorf("creating indexes on %s: %w", collection, err)
+		}
+		fmt.Fprintf(a.out, "%s: %v\n", collection, names)
+	}
+
+	return nil
+}
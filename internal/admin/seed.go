@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+)
+
+//go:embed seed.json
+var seedData []byte
+
+type seed struct {
+	Teams         []teams.Team `json:"teams"`
+	Championships []struct {
+		Id     string            `json:"id"`
+		Name   string            `json:"name"`
+		Names  map[string]string `json:"names"`
+		Season string            `json:"season"`
+		Teams  []string          `json:"teams"`
+	} `json:"championships"`
+	Matches []matches.Match `json:"matches"`
+}
+
+// Seed loads Inter's reference teams, championships and matches. Documents
+// have fixed ids, so seeding twice replaces them instead of duplicating them.
+func (a *Admin) Seed(ctx context.Context) error {
+	var s seed
+	if err := json.Unmarshal(seedData, &s); err != nil {
+		return err
+	}
+
+	teamsById := make(map[string]teams.Team, len(s.Teams))
+	for _, team := range s.Teams {
+		teamsById[team.Id] = team
+	}
+
+	entities := map[string][]interface{}{}
+	for _, team := range s.Teams {
+		entities[teamsCollection] = append(entities[teamsCollection], team)
+	}
+	for _, c := range s.Championships {
+		championship := championships.Championship{Id: c.Id, Name: c.Name, Names: c.Names, Season: c.Season}
+		for _, id := range c.Teams {
+			championship.Teams = append(championship.Teams, teamsById[id])
+		}
+		entities[championshipsCollection] = append(entities[championshipsCollection], championship)
+	}
+	for _, match := range s.Matches {
+		entities[matchesCollection] = append(entities[matchesCollection], match)
+	}
+
+	for _, collection := range []string{teamsCollection, championshipsCollection, matchesCollection} {
+		n, err := a.upsert(ctx, collection, entities[collection])
+		if err != nil {
+			return fmt.Errorf("seeding %s: %w", collection, err)
+		}
+		fmt.Fprintf(a.out, "seeded %d %s\n", n, collection)
+	}
+
+	return a.standings.Recompute(ctx, "")
+}
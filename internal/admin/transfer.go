@@ -0,0 +1,150 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"io"
+	"path/filepath"
+)
+
+// batchSize is how many documents are sent to Mongo in a single bulk write.
+const batchSize = 500
+
+// Import reads a JSON array or NDJSON stream of entities into a collection.
+// Every entity is validated with the same binding rules used by the API, and
+// entities carrying an id replace the stored document with that id.
+func (a *Admin) Import(ctx context.Context, collection string, r io.Reader) error {
+	newEntity, ok := entities[collection]
+	if !ok {
+		return fmt.Errorf("unknown collection %q", collection)
+	}
+
+	reader := bufio.NewReader(r)
+	decoder := json.NewDecoder(reader)
+	if isArray(reader) {
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+
+	var batch []interface{}
+	total := 0
+	for line := 1; decoder.More(); line++ {
+		entity := newEntity()
+		if err := decoder.Decode(entity); err != nil {
+			return fmt.Errorf("record %d: %w", line, err)
+		}
+
+		if err := binding.Validator.ValidateStruct(entity); err != nil {
+			return fmt.Errorf("record %d: %w", line, err)
+		}
+
+		batch = append(batch, entity)
+		if len(batch) == batchSize {
+			n, err := a.upsert(ctx, collection, batch)
+			if err != nil {
+				return err
+			}
+			total += n
+			batch = batch[:0]
+		}
+	}
+
+	n, err := a.upsert(ctx, collection, batch)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "imported %d %s\n", total+n, collection)
+
+	return nil
+}
+
+// Export writes every document of a collection as a JSON array, or as NDJSON
+// when name ends in .ndjson or .jsonl.
+func (a *Admin) Export(ctx context.Context, collection string, w io.Writer, name string) error {
+	ext := filepath.Ext(name)
+	ndjson := ext == ".ndjson" || ext == ".jsonl"
+
+	encoder := json.NewEncoder(w)
+	if !ndjson {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+	}
+
+	total := 0
+	err := a.each(ctx, collection, func(entity interface{}) error {
+		if !ndjson && total > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		total++
+
+		return encoder.Encode(entity)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ndjson {
+		if _, err = io.WriteString(w, "]\n"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(a.out, "exported %d %s\n", total, collection)
+
+	return nil
+}
+
+func (a *Admin) upsert(ctx context.Context, collection string, entities []interface{}) (int, error) {
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(entities))
+	for _, entity := range entities {
+		doc, err := toDocument(entity)
+		if err != nil {
+			return 0, err
+		}
+
+		if id := documentId(doc); id != nil {
+			models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": id}).SetReplacement(doc).SetUpsert(true))
+		} else {
+			models = append(models, mongo.NewInsertOneModel().SetDocument(doc))
+		}
+	}
+
+	_, err := a.db.Collection(collection).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(models), nil
+}
+
+// isArray reports whether the next non-blank byte of r opens a JSON array.
+func isArray(r *bufio.Reader) bool {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return false
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			r.ReadByte()
+		default:
+			return b[0] == '['
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+)
+
+// Issue is a broken reference found by Validate.
+type Issue struct {
+	Collection string
+	Id         string
+	Message    string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Collection, i.Id, i.Message)
+}
+
+// Validate checks that championships only reference existing teams and that
+// matches reference existing teams and championships, with both teams taking
+// part in the championship.
+func (a *Admin) Validate(ctx context.Context) ([]Issue, error) {
+	teamIds := map[string]bool{}
+	err := a.each(ctx, teamsCollection, func(v interface{}) error {
+		teamIds[v.(*teams.Team).Id] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	championshipTeams := map[string]map[string]bool{}
+	err = a.each(ctx, championshipsCollection, func(v interface{}) error {
+		championship := v.(*championships.Championship)
+		issues = append(issues, checkChampionship(*championship, teamIds)...)
+
+		championshipTeams[championship.Id] = map[string]bool{}
+		for _, team := range championship.Teams {
+			championshipTeams[championship.Id][team.Id] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.each(ctx, matchesCollection, func(v interface{}) error {
+		issues = append(issues, checkMatch(*v.(*matches.Match), teamIds, championshipTeams)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func checkChampionship(championship championships.Championship, teamIds map[string]bool) []Issue {
+	var issues []Issue
+	for _, team := range championship.Teams {
+		if !teamIds[team.Id] {
+			issues = append(issues, Issue{championshipsCollection, championship.Id, fmt.Sprintf("team %s does not exist", team.Id)})
+		}
+	}
+
+	return issues
+}
+
+func checkMatch(match matches.Match, teamIds map[string]bool, championshipTeams map[string]map[string]bool) []Issue {
+	var issues []Issue
+	participants, championshipExists := championshipTeams[match.ChampionshipId]
+	if !championshipExists {
+		issues = append(issues, Issue{matchesCollection, match.Id, fmt.Sprintf("championship %s does not exist", match.ChampionshipId)})
+	}
+
+	for _, teamId := range []string{match.TeamHomeId, match.TeamAwayId} {
+		if !teamIds[teamId] {
+			issues = append(issues, Issue{matchesCollection, match.Id, fmt.Sprintf("team %s does not exist", teamId)})
+			continue
+		}
+
+		if championshipExists && !participants[teamId] {
+			issues = append(issues, Issue{matchesCollection, match.Id, fmt.Sprintf("team %s does not take part in championship %s", teamId, match.ChampionshipId)})
+		}
+	}
+
+	return issues
+}
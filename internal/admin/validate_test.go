@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+	"testing"
+)
+
+func TestCheckChampionship(t *testing.T) {
+	tests := []struct {
+		name         string
+		championship championships.Championship
+		teamIds      map[string]bool
+		want         []Issue
+	}{
+		{
+			name:         "when every team exists",
+			championship: championships.Championship{Id: "c1", Teams: []teams.Team{{Id: "t1"}, {Id: "t2"}}},
+			teamIds:      map[string]bool{"t1": true, "t2": true},
+			want:         nil,
+		},
+		{
+			name:         "when a team does not exist",
+			championship: championships.Championship{Id: "c1", Teams: []teams.Team{{Id: "t1"}, {Id: "t3"}}},
+			teamIds:      map[string]bool{"t1": true, "t2": true},
+			want:         []Issue{{Collection: "championships", Id: "c1", Message: "team t3 does not exist"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkChampionship(tt.championship, tt.teamIds)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckMatch(t *testing.T) {
+	teamIds := map[string]bool{"t1": true, "t2": true, "t3": true}
+	championshipTeams := map[string]map[string]bool{"c1": {"t1": true, "t2": true}}
+
+	tests := []struct {
+		name  string
+		match matches.Match
+		want  []Issue
+	}{
+		{
+			name:  "when every reference is valid",
+			match: matches.Match{Id: "m1", ChampionshipId: "c1", TeamHomeId: "t1", TeamAwayId: "t2"},
+			want:  nil,
+		},
+		{
+			name:  "when championship does not exist",
+			match: matches.Match{Id: "m1", ChampionshipId: "c2", TeamHomeId: "t1", TeamAwayId: "t2"},
+			want:  []Issue{{Collection: "matches", Id: "m1", Message: "championship c2 does not exist"}},
+		},
+		{
+			name:  "when a team does not exist",
+			match: matches.Match{Id: "m1", ChampionshipId: "c1", TeamHomeId: "t1", TeamAwayId: "t9"},
+			want:  []Issue{{Collection: "matches", Id: "m1", Message: "team t9 does not exist"}},
+		},
+		{
+			name:  "when a team does not take part in the championship",
+			match: matches.Match{Id: "m1", ChampionshipId: "c1", TeamHomeId: "t3", TeamAwayId: "t2"},
+			want:  []Issue{{Collection: "matches", Id: "m1", Message: "team t3 does not take part in championship c1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkMatch(tt.match, teamIds, championshipTeams)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package apiversion serves each version of the REST API under its own path
+// prefix and renders responses in the shape of the version a request was
+// routed to, so that a new version can rename fields without touching the
+// clients of an older one.
+//
+// Every type is named in camelCase and v2 wraps it in an Envelope. v1 is the
+// compatibility mode: it renders bodies bare and, through the mappers the
+// packages owning reshaped types register for it, in the shape they had
+// before the naming was made consistent.
+package apiversion
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+type Version string
+
+const (
+	V1 Version = "v1"
+	V2 Version = "v2"
+)
+
+// Envelope is the body of every v2 response: the resource, or the list of
+// them, in Data, facts about it in Meta and related URLs in Links. Errors are
+// not wrapped, they stay {"error": "..."} in every version.
+type Envelope struct {
+	Data  interface{} `json:"data"`
+	Meta  Meta        `json:"meta"`
+	Links Links       `json:"links"`
+}
+
+type Meta struct {
+	Version Version `json:"version"`
+	// Count is the number of items of a list.
+	Count *int `json:"count,omitempty"`
+}
+
+type Links struct {
+	Self string `json:"self"`
+}
+
+const versionKey = "apiversion"
+
+// mappers holds, per version, the function rendering each type that version
+// reshapes.
+var mappers = map[Version]map[reflect.Type]func(interface{}) interface{}{}
+
+// Register makes responses of version v render a T as fn returns it. Types a
+// version registers no mapper for are rendered as they are. Register is meant
+// to be called from init functions.
+func Register[T any](v Version, fn func(T) interface{}) {
+	if mappers[v] == nil {
+		mappers[v] = map[reflect.Type]func(interface{}) interface{}{}
+	}
+	mappers[v][reflect.TypeOf((*T)(nil)).Elem()] = func(value interface{}) interface{} {
+		return fn(value.(T))
+	}
+}
+
+// Prefix is the path the routes of version v are served under.
+func Prefix(v Version) string {
+	return "/" + string(v)
+}
+
+// Group serves the routes added to it under the prefix of version v.
+func Group(r gin.IRouter, v Version, handlers ...gin.HandlerFunc) *gin.RouterGroup {
+	return r.Group(Prefix(v), append([]gin.HandlerFunc{use(v)}, handlers...)...)
+}
+
+// Alias serves the routes added to it at the root as deprecated aliases of
+// the same routes of version v. Their responses are in the shape of v and
+// carry Deprecation, Sunset and Link headers pointing at the versioned path.
+func Alias(r gin.IRouter, v Version, config *Config, handlers ...gin.HandlerFunc) *gin.RouterGroup {
+	return r.Group("/", append([]gin.HandlerFunc{use(v), deprecate(v, config)}, handlers...)...)
+}
+
+// From returns the version the request was routed to, v1 when it was not
+// routed through Group or Alias.
+func From(ctx *gin.Context) Version {
+	if v, ok := ctx.Get(versionKey); ok {
+		return v.(Version)
+	}
+
+	return V1
+}
+
+// JSON writes value as the JSON body of the response, in the shape of the
+// version the request was routed to.
+func JSON(ctx *gin.Context, status int, value interface{}) {
+	v := From(ctx)
+	body := Render(v, value)
+	if v == V1 {
+		ctx.JSON(status, body)
+		return
+	}
+
+	envelope := Envelope{Data: body, Meta: Meta{Version: v}, Links: Links{Self: ctx.Request.URL.RequestURI()}}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice {
+		count := rv.Len()
+		envelope.Meta.Count = &count
+		if rv.IsNil() {
+			envelope.Data = []interface{}{}
+		}
+	}
+
+	ctx.JSON(status, envelope)
+}
+
+// Render returns value in the shape of version v. A T, or each T of a []T,
+// goes through the mapper v registered for T.
+func Render(v Version, value interface{}) interface{} {
+	byType := mappers[v]
+	if len(byType) == 0 || value == nil {
+		return value
+	}
+
+	rv := reflect.ValueOf(value)
+	if fn, ok := byType[rv.Type()]; ok {
+		return fn(value)
+	}
+
+	if rv.Kind() != reflect.Slice || rv.IsNil() {
+		return value
+	}
+	fn, ok := byType[rv.Type().Elem()]
+	if !ok {
+		return value
+	}
+
+	rendered := make([]interface{}, rv.Len())
+	for i := range rendered {
+		rendered[i] = fn(rv.Index(i).Interface())
+	}
+
+	return rendered
+}
+
+func use(v Version) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(versionKey, v)
+		ctx.Next()
+	}
+}
+
+// deprecate sets the headers of RFC 9745 and RFC 8594 before the handlers
+// run, so that even refused requests carry them.
+func deprecate(v Version, config *Config) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(config.DeprecatedAt.Unix(), 10)
+	sunset := config.Sunset.UTC().Format(http.TimeFormat)
+
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", deprecation)
+		ctx.Header("Sunset", sunset)
+		ctx.Header("Link", "<"+Prefix(v)+ctx.Request.URL.Path+`>; rel="successor-version"`)
+		ctx.Next()
+	}
+}
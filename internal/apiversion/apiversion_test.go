@@ -0,0 +1,105 @@
+package apiversion
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type player struct {
+	FullName string `json:"fullName"`
+}
+
+type v1Player struct {
+	FullName string `json:"full_name"`
+}
+
+func TestGroup(t *testing.T) {
+	Register(V1, func(p player) interface{} { return v1Player(p) })
+	t.Cleanup(func() { delete(mappers, V1) })
+
+	config := &Config{
+		DeprecatedAt: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		Sunset:       time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC),
+	}
+
+	r := gin.New()
+	for _, g := range []*gin.RouterGroup{Group(r, V1), Group(r, V2), Alias(r, V1, config)} {
+		g.GET("/players/:id", func(ctx *gin.Context) { JSON(ctx, http.StatusOK, player{FullName: "Andrés D'Alessandro"}) })
+		g.GET("/players", func(ctx *gin.Context) {
+			JSON(ctx, http.StatusOK, []player{{FullName: "Andrés D'Alessandro"}, {FullName: "Fernandão"}})
+		})
+		g.GET("/coaches", func(ctx *gin.Context) { JSON(ctx, http.StatusOK, []player(nil)) })
+	}
+
+	tests := []struct {
+		name               string
+		path               string
+		expectedBody       string
+		expectedDeprecated bool
+	}{
+		{
+			name:         "when v1 renders the type through its mapper",
+			path:         "/v1/players/10",
+			expectedBody: `{"full_name":"Andrés D'Alessandro"}`,
+		},
+		{
+			name:         "when v1 maps each element of a slice",
+			path:         "/v1/players",
+			expectedBody: `[{"full_name":"Andrés D'Alessandro"},{"full_name":"Fernandão"}]`,
+		},
+		{
+			name:         "when v2 wraps the type as it is",
+			path:         "/v2/players/10",
+			expectedBody: `{"data":{"fullName":"Andrés D'Alessandro"},"meta":{"version":"v2"},"links":{"self":"/v2/players/10"}}`,
+		},
+		{
+			name:         "when v2 counts the items of a list",
+			path:         "/v2/players?limit=2",
+			expectedBody: `{"data":[{"fullName":"Andrés D'Alessandro"},{"fullName":"Fernandão"}],"meta":{"version":"v2","count":2},"links":{"self":"/v2/players?limit=2"}}`,
+		},
+		{
+			name:         "when v2 wraps an empty list",
+			path:         "/v2/coaches",
+			expectedBody: `{"data":[],"meta":{"version":"v2","count":0},"links":{"self":"/v2/coaches"}}`,
+		},
+		{
+			name:               "when the alias renders v1 and announces its sunset",
+			path:               "/players/10",
+			expectedBody:       `{"full_name":"Andrés D'Alessandro"}`,
+			expectedDeprecated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			if tt.expectedDeprecated {
+				assert.Equal(t, "@1792368000", recorder.Header().Get("Deprecation"))
+				assert.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", recorder.Header().Get("Sunset"))
+				assert.Equal(t, `</v1/players/10>; rel="successor-version"`, recorder.Header().Get("Link"))
+			} else {
+				assert.Empty(t, recorder.Header().Get("Deprecation"))
+				assert.Empty(t, recorder.Header().Get("Sunset"))
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	Register(V1, func(p player) interface{} { return v1Player(p) })
+	t.Cleanup(func() { delete(mappers, V1) })
+
+	assert.Equal(t, v1Player{FullName: "Fernandão"}, Render(V1, player{FullName: "Fernandão"}))
+	assert.Equal(t, player{FullName: "Fernandão"}, Render(V2, player{FullName: "Fernandão"}))
+	assert.Equal(t, []player(nil), Render(V1, []player(nil)), "a nil slice stays null")
+	assert.Equal(t, gin.H{"error": "boom"}, Render(V1, gin.H{"error": "boom"}), "types without a mapper are left alone")
+	assert.Nil(t, Render(V1, nil))
+}
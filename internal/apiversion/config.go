@@ -0,0 +1,27 @@
+package apiversion
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config holds the deprecation schedule of the unversioned aliases: when
+// they were deprecated and when they stop being served.
+type Config struct {
+	DeprecatedAt time.Time `env:"UNVERSIONED_DEPRECATED_AT" envDefault:"2026-10-19T00:00:00Z"`
+	Sunset       time.Time `env:"UNVERSIONED_SUNSET" envDefault:"2027-04-19T00:00:00Z"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+
+	if !cfg.Sunset.After(cfg.DeprecatedAt) {
+		return nil, errors.New("UNVERSIONED_SUNSET must come after UNVERSIONED_DEPRECATED_AT")
+	}
+
+	return &cfg, nil
+}
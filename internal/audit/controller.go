@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+	"strconv"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type service interface {
+	findEntries(ctx context.Context, entity, entityId string, limit int64) ([]Entry, error)
+}
+
+type Controller struct {
+	service service
+}
+
+func NewController(service service) *Controller {
+	return &Controller{service: service}
+}
+
+func (c Controller) GetAudit(ctx *gin.Context) {
+	limit := int64(defaultLimit)
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 || parsed > maxLimit {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("limit must be between 1 and 1000")))
+			return
+		}
+		limit = parsed
+	}
+
+	if ctx.Query("id") != "" && ctx.Query("entity") == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("entity is required when filtering by id")))
+		return
+	}
+
+	entries, err := c.service.findEntries(ctx.Request.Context(), ctx.Query("entity"), ctx.Query("id"), limit)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, entries)
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
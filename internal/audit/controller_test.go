@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_GetAudit(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when limit is invalid",
+			setup:              func(s *serviceMock) {},
+			query:              "limit=5000",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"limit must be between 1 and 1000\"}",
+		},
+		{
+			name:               "when id is given without entity",
+			setup:              func(s *serviceMock) {},
+			query:              "id=1",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"entity is required when filtering by id\"}",
+		},
+		{
+			name: "when failed to find entries",
+			setup: func(s *serviceMock) {
+				s.On("findEntries", mock.Anything, "", "", int64(100)).Return([]Entry(nil), errors.New("failed to find"))
+			},
+			query:              "",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to find\"}",
+		},
+		{
+			name: "when successfully find entries",
+			setup: func(s *serviceMock) {
+				entries := []Entry{{Id: "a", Actor: "ci", Action: Update, Entity: "team", EntityId: "1", Timestamp: time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC), Changes: []Change{{Field: "name", Before: "Internacional", After: "Inter"}}}}
+				s.On("findEntries", mock.Anything, "team", "1", int64(10)).Return(entries, nil)
+			},
+			query:              "entity=team&id=1&limit=10",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "[{\"id\":\"a\",\"actor\":\"ci\",\"action\":\"update\",\"entity\":\"team\",\"entityId\":\"1\",\"timestamp\":\"2024-10-12T18:30:00Z\",\"changes\":[{\"field\":\"name\",\"before\":\"Internacional\",\"after\":\"Inter\"}]}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/audit?"+tt.query, nil)
+
+			c.GetAudit(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) findEntries(ctx context.Context, entity, entityId string, limit int64) ([]Entry, error) {
+	args := m.Called(ctx, entity, entityId, limit)
+
+	return args.Get(0).([]Entry), args.Error(1)
+}
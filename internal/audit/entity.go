@@ -0,0 +1,32 @@
+package audit
+
+import "time"
+
+type Action string
+
+const (
+	Create  Action = "create"
+	Update  Action = "update"
+	Delete  Action = "delete"
+	Restore Action = "restore"
+	Purge   Action = "purge"
+)
+
+type Entry struct {
+	Id        string    `json:"id,omitempty" bson:"_id,omitempty"`
+	Actor     string    `json:"actor"`
+	Action    Action    `json:"action"`
+	Entity    string    `json:"entity"`
+	EntityId  string    `json:"entityId"`
+	RequestId string    `json:"requestId,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Changes   []Change  `json:"changes"`
+}
+
+// Change is a field whose value differs between the before and after
+// versions of an entity. Field is the JSON name exposed by the API.
+type Change struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
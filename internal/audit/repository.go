@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/tracing"
+)
+
+type db interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+}
+
+type Repository struct {
+	db
+}
+
+func NewRepository(db db) *Repository {
+	return &Repository{db}
+}
+
+func (r Repository) createEntry(ctx context.Context, entry Entry) error {
+	ctx, span := tracer.Start(ctx, "audit.Repository.createEntry")
+	defer span.End()
+
+	if _, err := r.db.InsertOne(ctx, entry); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// findEntries returns the newest entries first. Empty entity or entityId
+// match every value.
+func (r Repository) findEntries(ctx context.Context, entity, entityId string, limit int64) ([]Entry, error) {
+	ctx, span := tracer.Start(ctx, "audit.Repository.findEntries")
+	defer span.End()
+
+	filter := bson.M{}
+	if entity != "" {
+		filter["entity"] = entity
+	}
+	if entityId != "" {
+		filter["entityid"] = entityId
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}).SetLimit(limit)
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []Entry{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"log/slog"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/logging"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/audit")
+
+type repository interface {
+	createEntry(ctx context.Context, entry Entry) error
+	findEntries(ctx context.Context, entity, entityId string, limit int64) ([]Entry, error)
+}
+
+type Service struct {
+	repository repository
+	now        func() time.Time
+}
+
+func NewService(repository repository) *Service {
+	return &Service{repository: repository, now: time.Now}
+}
+
+// Record writes an audit entry for a mutation that already succeeded. The
+// actor and request id come from ctx. Failures are logged rather than
+// returned, since the change they describe cannot be rolled back anymore.
+func (s Service) Record(ctx context.Context, action Action, entity, entityId string, before, after interface{}) {
+	ctx, span := tracer.Start(ctx, "audit.Service.Record")
+	defer span.End()
+
+	actor := "anonymous"
+	if principal, ok := auth.PrincipalFrom(ctx); ok {
+		actor = principal.Subject
+	}
+
+	changes, err := diff(before, after)
+	if err == nil {
+		err = s.repository.createEntry(ctx, Entry{
+			Actor:     actor,
+			Action:    action,
+			Entity:    entity,
+			EntityId:  entityId,
+			RequestId: logging.RequestID(ctx),
+			Timestamp: s.now().UTC(),
+			Changes:   changes,
+		})
+	}
+
+	if err != nil {
+		tracing.Error(span, err)
+		slog.ErrorContext(ctx, "failed to record audit entry", "action", action, "entity", entity, "entity_id", entityId, "error", err)
+	}
+}
+
+func (s Service) findEntries(ctx context.Context, entity, entityId string, limit int64) ([]Entry, error) {
+	ctx, span := tracer.Start(ctx, "audit.Service.findEntries")
+	defer span.End()
+
+	entries, err := s.repository.findEntries(ctx, entity, entityId, limit)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/logging"
+	"testing"
+	"time"
+)
+
+type team struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Website string `json:"website,omitempty"`
+}
+
+func TestService_Record(t *testing.T) {
+	now := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		action Action
+		before interface{}
+		after  interface{}
+		want   Entry
+	}{
+		{
+			name:   "when an anonymous caller creates an entity",
+			ctx:    context.Background(),
+			action: Create,
+			before: nil,
+			after:  team{Id: "1", Name: "Internacional"},
+			want: Entry{Actor: "anonymous", Action: Create, Entity: "team", EntityId: "1", Timestamp: now, Changes: []Change{
+				{Field: "id", After: "1"},
+				{Field: "name", After: "Internacional"},
+			}},
+		},
+		{
+			name:   "when an authenticated caller updates an entity",
+			ctx:    logging.WithRequestID(auth.WithPrincipal(context.Background(), auth.Principal{Subject: "ci", Role: auth.Editor}), "req-1"),
+			action: Update,
+			before: team{Id: "1", Name: "Internacional"},
+			after:  team{Id: "1", Name: "Inter", Website: "internacional.com.br"},
+			want: Entry{Actor: "ci", Action: Update, Entity: "team", EntityId: "1", RequestId: "req-1", Timestamp: now, Changes: []Change{
+				{Field: "name", Before: "Internacional", After: "Inter"},
+				{Field: "website", After: "internacional.com.br"},
+			}},
+		},
+		{
+			name:   "when an entity is deleted",
+			ctx:    context.Background(),
+			action: Delete,
+			before: team{Id: "1", Name: "Internacional"},
+			after:  nil,
+			want: Entry{Actor: "anonymous", Action: Delete, Entity: "team", EntityId: "1", Timestamp: now, Changes: []Change{
+				{Field: "id", Before: "1"},
+				{Field: "name", Before: "Internacional"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			r.On("createEntry", mock.Anything, tt.want).Return(nil)
+
+			s := NewService(r)
+			s.now = func() time.Time { return now }
+
+			s.Record(tt.ctx, tt.action, "team", "1", tt.before, tt.after)
+
+			r.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_Record_repositoryFailure(t *testing.T) {
+	r := &repositoryMock{}
+	r.On("createEntry", mock.Anything, mock.Anything).Return(errors.New("failed to insert"))
+
+	s := NewService(r)
+
+	assert.NotPanics(t, func() {
+		s.Record(context.Background(), Create, "team", "1", nil, team{Id: "1"})
+	})
+	r.AssertExpectations(t)
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) createEntry(ctx context.Context, entry Entry) error {
+	args := m.Called(ctx, entry)
+
+	return args.Error(0)
+}
+
+func (m *repositoryMock) findEntries(ctx context.Context, entity, entityId string, limit int64) ([]Entry, error) {
+	args := m.Called(ctx, entity, entityId, limit)
+
+	return args.Get(0).([]Entry), args.Error(1)
+}
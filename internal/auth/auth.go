@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"strings"
+)
+
+type Role string
+
+const (
+	Reader Role = "reader"
+	Editor Role = "editor"
+	Admin  Role = "admin"
+)
+
+// rank orders roles so that a role grants everything the lower ones do.
+var rank = map[Role]int{Reader: 1, Editor: 2, Admin: 3}
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrForbidden          = errors.New("insufficient role")
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Role    Role
+}
+
+// Has reports whether the principal's role grants role.
+func (p Principal) Has(role Role) bool {
+	return rank[p.Role] >= rank[role]
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the caller stored in ctx by the middleware.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Allows reports whether the caller stored in ctx has a role granting role.
+func Allows(ctx context.Context, role Role) bool {
+	principal, ok := PrincipalFrom(ctx)
+	return ok && principal.Has(role)
+}
+
+type apiKey struct {
+	hash      [sha256.Size]byte
+	principal Principal
+}
+
+type Authenticator struct {
+	config    Config
+	apiKeys   []apiKey
+	publicKey *rsa.PublicKey
+}
+
+func New(config *Config) (*Authenticator, error) {
+	a := &Authenticator{config: *config}
+
+	for _, entry := range config.APIKeys {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, errors.New("AUTH_API_KEYS entries must be name:role:key")
+		}
+		role, err := parseRole(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		a.apiKeys = append(a.apiKeys, apiKey{hash: sha256.Sum256([]byte(parts[2])), principal: Principal{Subject: parts[0], Role: role}})
+	}
+
+	if config.JWTPublicKey != "" {
+		pem, err := os.ReadFile(config.JWTPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if a.publicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+			return nil, fmt.Errorf("AUTH_JWT_RS256_PUBLIC_KEY_FILE: %w", err)
+		}
+	}
+
+	return a, nil
+}
+
+// authenticateAPIKey compares hashes in constant time, so the time taken does
+// not reveal how much of a key matched.
+func (a *Authenticator) authenticateAPIKey(key string) (Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	for _, k := range a.apiKeys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+			return k.principal, nil
+		}
+	}
+
+	return Principal{}, ErrInvalidCredentials
+}
+
+func (a *Authenticator) authenticateJWT(token string) (Principal, error) {
+	var methods []string
+	if a.config.JWTSecret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if a.publicKey != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if a.config.JWTIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.config.JWTIssuer))
+	}
+	if a.config.JWTAudience != "" {
+		opts = append(opts, jwt.WithAudience(a.config.JWTAudience))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method == jwt.SigningMethodRS256 {
+			return a.publicKey, nil
+		}
+		return []byte(a.config.JWTSecret), nil
+	}, opts...)
+	if err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return Principal{Subject: subject, Role: highestRole(claims[a.config.JWTRolesClaim])}, nil
+}
+
+// highestRole reads a role claim that is either a single string or a list.
+func highestRole(claim interface{}) Role {
+	var values []interface{}
+	switch v := claim.(type) {
+	case string:
+		values = []interface{}{v}
+	case []interface{}:
+		values = v
+	}
+
+	var highest Role
+	for _, value := range values {
+		s, _ := value.(string)
+		if role, err := parseRole(s); err == nil && rank[role] > rank[highest] {
+			highest = role
+		}
+	}
+
+	return highest
+}
+
+func parseRole(s string) (Role, error) {
+	role := Role(s)
+	if _, ok := rank[role]; !ok {
+		return "", fmt.Errorf("unknown role %q, use reader, editor or admin", s)
+	}
+
+	return role, nil
+}
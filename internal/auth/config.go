@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"github.com/caarlos0/env/v11"
+)
+
+type Config struct {
+	// APIKeys lists static keys as name:role:key, e.g. "discord-bot:editor:s3cr3t".
+	// The name is the actor recorded for requests made with the key.
+	APIKeys       []string `env:"AUTH_API_KEYS" envSeparator:","`
+	JWTSecret     string   `env:"AUTH_JWT_HS256_SECRET"`
+	JWTPublicKey  string   `env:"AUTH_JWT_RS256_PUBLIC_KEY_FILE"`
+	JWTIssuer     string   `env:"AUTH_JWT_ISSUER"`
+	JWTAudience   string   `env:"AUTH_JWT_AUDIENCE"`
+	JWTRolesClaim string   `env:"AUTH_JWT_ROLES_CLAIM" envDefault:"roles"`
+	PublicReads   bool     `env:"AUTH_PUBLIC_READS" envDefault:"true"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
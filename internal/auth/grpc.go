@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// UnaryInterceptor is the gRPC counterpart of Authenticate followed by
+// Require: it identifies the caller from the x-api-key or authorization
+// metadata and checks it against the role roles lists for the method. Reader
+// methods are public when AUTH_PUBLIC_READS is set, and methods missing from
+// roles are restricted to admins.
+func (a *Authenticator) UnaryInterceptor(roles map[string]Role) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod, roles)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods.
+func (a *Authenticator) StreamInterceptor(roles map[string]Role) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(stream.Context(), info.FullMethod, roles)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, principalStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func (a *Authenticator) authorize(ctx context.Context, method string, roles map[string]Role) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var principal Principal
+	var err error
+	authenticated := true
+
+	apiKey := first(md, strings.ToLower(APIKeyHeader))
+	authorization := first(md, "authorization")
+	switch {
+	case apiKey != "":
+		principal, err = a.authenticateAPIKey(apiKey)
+	case strings.HasPrefix(authorization, "Bearer "):
+		principal, err = a.authenticateJWT(strings.TrimPrefix(authorization, "Bearer "))
+	case authorization != "":
+		err = ErrInvalidCredentials
+	default:
+		authenticated = false
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if authenticated {
+		ctx = WithPrincipal(ctx, principal)
+	}
+
+	role, ok := roles[method]
+	if !ok {
+		role = Admin
+	}
+	if role == Reader && a.config.PublicReads {
+		return ctx, nil
+	}
+
+	if !authenticated {
+		return nil, status.Error(codes.Unauthenticated, ErrUnauthenticated.Error())
+	}
+	if !principal.Has(role) {
+		return nil, status.Error(codes.PermissionDenied, ErrForbidden.Error())
+	}
+
+	return ctx, nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// principalStream hands the context carrying the caller to stream handlers.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// AdminOnly is the gRPC counterpart of AdminFlag: it refuses a flag set by a
+// caller who is not an admin.
+func AdminOnly(ctx context.Context, flag bool) error {
+	if flag && !Allows(ctx, Admin) {
+		return status.Error(codes.PermissionDenied, ErrForbidden.Error())
+	}
+
+	return nil
+}
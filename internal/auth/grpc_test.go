@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestAuthenticator_UnaryInterceptor(t *testing.T) {
+	roles := map[string]Role{
+		"/scinternacional.v1.Teams/GetTeam":    Reader,
+		"/scinternacional.v1.Teams/CreateTeam": Editor,
+	}
+
+	tests := []struct {
+		name            string
+		publicReads     bool
+		method          string
+		metadata        map[string]string
+		expectedCode    codes.Code
+		expectedSubject string
+	}{
+		{
+			name:         "when reading anonymously and reads are public",
+			publicReads:  true,
+			method:       "/scinternacional.v1.Teams/GetTeam",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "when reading anonymously and reads are not public",
+			method:       "/scinternacional.v1.Teams/GetTeam",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:            "when writing with an editor api key",
+			method:          "/scinternacional.v1.Teams/CreateTeam",
+			metadata:        map[string]string{"x-api-key": "bot-key"},
+			expectedCode:    codes.OK,
+			expectedSubject: "discord-bot",
+		},
+		{
+			name:         "when writing with a reader api key",
+			method:       "/scinternacional.v1.Teams/CreateTeam",
+			metadata:     map[string]string{"x-api-key": "fan-key"},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "when writing anonymously",
+			publicReads:  true,
+			method:       "/scinternacional.v1.Teams/CreateTeam",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "when api key is unknown",
+			publicReads:  true,
+			method:       "/scinternacional.v1.Teams/GetTeam",
+			metadata:     map[string]string{"x-api-key": "gremio"},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "when authorization scheme is unknown",
+			publicReads:  true,
+			method:       "/scinternacional.v1.Teams/GetTeam",
+			metadata:     map[string]string{"authorization": "Basic Zm9vOmJhcg=="},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "when method is not listed",
+			publicReads:  true,
+			method:       "/scinternacional.v1.Teams/Unknown",
+			metadata:     map[string]string{"x-api-key": "bot-key"},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := New(&Config{
+				APIKeys:     []string{"fan-site:reader:fan-key", "discord-bot:editor:bot-key"},
+				PublicReads: tt.publicReads,
+			})
+			assert.NoError(t, err)
+
+			var subject string
+			handler := func(ctx context.Context, req any) (any, error) {
+				principal, _ := PrincipalFrom(ctx)
+				subject = principal.Subject
+				return nil, nil
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(tt.metadata))
+			_, err = a.UnaryInterceptor(roles)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedSubject, subject)
+		})
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		flag         bool
+		role         Role
+		expectedCode codes.Code
+	}{
+		{name: "when the flag is not set", flag: false, role: Reader, expectedCode: codes.OK},
+		{name: "when a non-admin sets the flag", flag: true, role: Editor, expectedCode: codes.PermissionDenied},
+		{name: "when an admin sets the flag", flag: true, role: Admin, expectedCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithPrincipal(context.Background(), Principal{Subject: "ci", Role: tt.role})
+
+			assert.Equal(t, tt.expectedCode, status.Code(AdminOnly(ctx, tt.flag)))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sc-internacional/internal/i18n"
+	"strings"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Authenticate identifies the caller from an X-API-Key header or a bearer
+// token and stores it in the request context. Requests without credentials
+// continue anonymously; invalid credentials are rejected.
+func (a *Authenticator) Authenticate() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var principal Principal
+		var err error
+
+		authorization := ctx.GetHeader("Authorization")
+		switch {
+		case ctx.GetHeader(APIKeyHeader) != "":
+			principal, err = a.authenticateAPIKey(ctx.GetHeader(APIKeyHeader))
+		case strings.HasPrefix(authorization, "Bearer "):
+			principal, err = a.authenticateJWT(strings.TrimPrefix(authorization, "Bearer "))
+		case authorization != "":
+			err = ErrInvalidCredentials
+		default:
+			ctx.Next()
+			return
+		}
+
+		if err != nil {
+			unauthorized(ctx, err)
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(WithPrincipal(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+// Require rejects callers whose role does not grant role.
+func (a *Authenticator) Require(role Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := PrincipalFrom(ctx.Request.Context())
+		if !ok {
+			unauthorized(ctx, ErrUnauthenticated)
+			return
+		}
+
+		if !principal.Has(role) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": i18n.Message(ctx.Request.Context(), ErrForbidden)})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// Reads guards read-only routes: public when AUTH_PUBLIC_READS is set,
+// otherwise restricted to readers.
+func (a *Authenticator) Reads() gin.HandlerFunc {
+	if a.config.PublicReads {
+		return func(ctx *gin.Context) { ctx.Next() }
+	}
+
+	return a.Require(Reader)
+}
+
+func unauthorized(ctx *gin.Context, err error) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="sc-internacional"`)
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Message(ctx.Request.Context(), err)})
+}
+
+// AdminFlag reports whether the boolean query parameter name is set, which
+// only admins may do.
+func AdminFlag(ctx *gin.Context, name string) (bool, error) {
+	if ctx.Query(name) != "true" {
+		return false, nil
+	}
+
+	if !Allows(ctx.Request.Context(), Admin) {
+		return false, ErrForbidden
+	}
+
+	return true, nil
+}
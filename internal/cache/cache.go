@@ -0,0 +1,136 @@
+// Package cache keeps the results of expensive reads, either in process or in
+// a Redis-compatible server shared by every replica.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"reflect"
+	"sc-internacional/internal/metrics"
+)
+
+// Cache stores encoded values by key. Implementations expire entries after
+// their configured TTL, which bounds how stale a value can get when an
+// invalidation is lost.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Fetch returns the value cached under key, or loads, caches and returns it.
+// The cache only ever speeds reads up: when it fails, the value is loaded as
+// if it were missing. Zero values, which loaders return for what does not
+// exist, are not cached, so that a record created through another replica
+// is found right away.
+func Fetch[T any](ctx context.Context, c Cache, key string, load func(context.Context) (T, error)) (T, error) {
+	span := trace.SpanFromContext(ctx)
+
+	if value, ok := lookup[T](ctx, c, key); ok {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return value, nil
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", false))
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	store(ctx, c, key, value)
+
+	return value, nil
+}
+
+// FetchMany is Fetch for several keys at once: load is called once, with
+// the keys missing from the cache, and returns the value of each. Keys it
+// leaves out are neither cached nor returned.
+func FetchMany[T any](ctx context.Context, c Cache, keys []string, load func(ctx context.Context, missing []string) (map[string]T, error)) (map[string]T, error) {
+	values := make(map[string]T, len(keys))
+	var missing []string
+	for _, key := range keys {
+		if value, ok := lookup[T](ctx, c, key); ok {
+			values[key] = value
+			continue
+		}
+		missing = append(missing, key)
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("cache.misses", len(missing)))
+
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	loaded, err := load(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range loaded {
+		values[key] = value
+		store(ctx, c, key, value)
+	}
+
+	return values, nil
+}
+
+func lookup[T any](ctx context.Context, c Cache, key string) (T, bool) {
+	var value T
+
+	raw, ok, err := c.Get(ctx, key)
+	if err == nil && ok {
+		if err = json.Unmarshal(raw, &value); err == nil {
+			metrics.ObserveCache("get", "hit")
+			return value, true
+		}
+	}
+	if err != nil {
+		metrics.ObserveCache("get", "error")
+		trace.SpanFromContext(ctx).RecordError(err)
+	} else {
+		metrics.ObserveCache("get", "miss")
+	}
+
+	return value, false
+}
+
+func store[T any](ctx context.Context, c Cache, key string, value T) {
+	if reflect.ValueOf(&value).Elem().IsZero() {
+		return
+	}
+
+	raw, err := json.Marshal(value)
+	if err == nil {
+		err = c.Set(ctx, key, raw)
+	}
+	if err != nil {
+		metrics.ObserveCache("set", "error")
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+// Invalidate drops keys after a write. The write already happened, so a
+// failure is recorded rather than returned; the TTL retires the stale entry.
+func Invalidate(ctx context.Context, c Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		metrics.ObserveCache("delete", "error")
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+// Nop caches nothing, so every read goes to the source.
+type Nop struct{}
+
+func (Nop) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (Nop) Set(context.Context, string, []byte) error {
+	return nil
+}
+
+func (Nop) Delete(context.Context, ...string) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type team struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, time.Minute)
+
+	loads := 0
+	load := func(context.Context) (team, error) {
+		loads++
+		return team{Id: "1", Name: "Internacional"}, nil
+	}
+
+	got, err := Fetch(ctx, c, "teams:1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, team{Id: "1", Name: "Internacional"}, got)
+
+	got, err = Fetch(ctx, c, "teams:1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, team{Id: "1", Name: "Internacional"}, got)
+	assert.Equal(t, 1, loads, "the second read is served from the cache")
+
+	Invalidate(ctx, c, "teams:1")
+
+	_, err = Fetch(ctx, c, "teams:1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loads, "an invalidated key is loaded again")
+}
+
+func TestFetch_whenLoadFails(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, time.Minute)
+
+	_, err := Fetch(ctx, c, "teams:1", func(context.Context) (team, error) {
+		return team{}, errors.New("failed to get team")
+	})
+
+	assert.Equal(t, errors.New("failed to get team"), err)
+	_, ok, _ := c.Get(ctx, "teams:1")
+	assert.False(t, ok, "failures are not cached")
+}
+
+func TestFetchMany(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, time.Minute)
+
+	var loads [][]string
+	load := func(ctx context.Context, missing []string) (map[string]team, error) {
+		loads = append(loads, missing)
+		found := map[string]team{}
+		for _, key := range missing {
+			if key != "teams:3" {
+				found[key] = team{Id: key[len("teams:"):]}
+			}
+		}
+		return found, nil
+	}
+
+	got, err := FetchMany(ctx, c, []string{"teams:1"}, load)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]team{"teams:1": {Id: "1"}}, got)
+
+	got, err = FetchMany(ctx, c, []string{"teams:1", "teams:2", "teams:3"}, load)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]team{"teams:1": {Id: "1"}, "teams:2": {Id: "2"}}, got)
+	assert.Equal(t, [][]string{{"teams:1"}, {"teams:2", "teams:3"}}, loads, "only the missing keys are loaded, together")
+
+	_, err = FetchMany(ctx, c, []string{"teams:4"}, func(context.Context, []string) (map[string]team, error) {
+		return nil, errors.New("failed to get teams")
+	})
+	assert.Equal(t, errors.New("failed to get teams"), err)
+}
+
+func TestFetch_whenNotFound(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, time.Minute)
+
+	got, err := Fetch(ctx, c, "teams:1", func(context.Context) (team, error) {
+		return team{}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, team{}, got)
+	_, ok, _ := c.Get(ctx, "teams:1")
+	assert.False(t, ok, "a missing record is not cached, for it may be created elsewhere")
+}
+
+type brokenCache struct{}
+
+func (brokenCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (brokenCache) Set(context.Context, string, []byte) error {
+	return errors.New("connection refused")
+}
+
+func (brokenCache) Delete(context.Context, ...string) error {
+	return errors.New("connection refused")
+}
+
+func TestFetch_whenCacheFails(t *testing.T) {
+	got, err := Fetch(context.Background(), brokenCache{}, "teams:1", func(context.Context) (team, error) {
+		return team{Id: "1", Name: "Internacional"}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, team{Id: "1", Name: "Internacional"}, got)
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"fmt"
+	"github.com/caarlos0/env/v11"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// Config selects the cache backend: "memory" for a per-replica LRU, "redis"
+// for a shared server at RedisURL, or "none" to disable caching.
+type Config struct {
+	Backend     string        `env:"CACHE_BACKEND" envDefault:"memory"`
+	TTL         time.Duration `env:"CACHE_TTL" envDefault:"1m"`
+	Size        int           `env:"CACHE_SIZE" envDefault:"10000"`
+	RedisURL    string        `env:"CACHE_REDIS_URL"`
+	RedisPrefix string        `env:"CACHE_REDIS_PREFIX" envDefault:"sc-internacional:"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	switch cfg.Backend {
+	case "none":
+	case "memory":
+		if cfg.Size <= 0 {
+			return nil, fmt.Errorf("CACHE_SIZE must be positive, got %d", cfg.Size)
+		}
+	case "redis":
+		if _, err := redis.ParseURL(cfg.RedisURL); err != nil {
+			return nil, fmt.Errorf("CACHE_REDIS_URL: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid CACHE_BACKEND %q, use memory, redis or none", cfg.Backend)
+	}
+	return &cfg, nil
+}
+
+// New builds the configured cache. Callers owning a Redis cache should close
+// it on shutdown.
+func New(config *Config) (Cache, error) {
+	switch config.Backend {
+	case "memory":
+		return NewLRU(config.Size, config.TTL), nil
+	case "redis":
+		options, err := redis.ParseURL(config.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedis(redis.NewClient(options), config.RedisPrefix, config.TTL), nil
+	default:
+		return Nop{}, nil
+	}
+}
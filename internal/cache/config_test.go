@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name: "when no variables are set",
+			env:  map[string]string{},
+			want: &Config{Backend: "memory", TTL: time.Minute, Size: 10000, RedisPrefix: "sc-internacional:"},
+		},
+		{
+			name: "when redis is selected",
+			env:  map[string]string{"CACHE_BACKEND": "redis", "CACHE_REDIS_URL": "redis://localhost:6379/0"},
+			want: &Config{Backend: "redis", TTL: time.Minute, Size: 10000, RedisURL: "redis://localhost:6379/0", RedisPrefix: "sc-internacional:"},
+		},
+		{
+			name:    "when redis is selected without an url",
+			env:     map[string]string{"CACHE_BACKEND": "redis"},
+			wantErr: "CACHE_REDIS_URL: redis: invalid URL scheme: ",
+		},
+		{
+			name:    "when memory size is not positive",
+			env:     map[string]string{"CACHE_SIZE": "0"},
+			wantErr: "CACHE_SIZE must be positive, got 0",
+		},
+		{
+			name:    "when backend is unknown",
+			env:     map[string]string{"CACHE_BACKEND": "memcached"},
+			wantErr: "invalid CACHE_BACKEND \"memcached\", use memory, redis or none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"CACHE_BACKEND", "CACHE_TTL", "CACHE_SIZE", "CACHE_REDIS_URL", "CACHE_REDIS_PREFIX"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := NewConfig()
+
+			assert.Equal(t, tt.want, got)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC)
+	l := NewLRU(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	get := func(key string) string {
+		value, ok, err := l.Get(ctx, key)
+		assert.NoError(t, err)
+		if !ok {
+			return ""
+		}
+		return string(value)
+	}
+
+	assert.NoError(t, l.Set(ctx, "teams:1", []byte("Internacional")))
+	assert.NoError(t, l.Set(ctx, "teams:2", []byte("Barcelona")))
+	assert.Equal(t, "Internacional", get("teams:1"))
+
+	assert.NoError(t, l.Set(ctx, "teams:3", []byte("Grêmio")))
+	assert.Equal(t, "", get("teams:2"), "the least recently used entry is evicted")
+	assert.Equal(t, "Internacional", get("teams:1"))
+	assert.Equal(t, "Grêmio", get("teams:3"))
+
+	assert.NoError(t, l.Set(ctx, "teams:1", []byte("Inter")))
+	assert.Equal(t, "Inter", get("teams:1"), "setting a key replaces its value")
+
+	assert.NoError(t, l.Delete(ctx, "teams:1", "teams:4"))
+	assert.Equal(t, "", get("teams:1"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, "", get("teams:3"), "entries expire after the ttl")
+	assert.Equal(t, 0, l.order.Len())
+}
@@ -2,13 +2,23 @@ package championships
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
 )
 
 type service interface {
 	createChampionship(ctx context.Context, championship Championship) (Championship, error)
-	getChampionship(ctx context.Context, id string) (Championship, error)
+	getChampionship(ctx context.Context, id string, includeDeleted bool) (Championship, error)
+	updateChampionship(ctx context.Context, id string, championship Championship, version int64) (Championship, error)
+	deleteChampionship(ctx context.Context, id string) (Championship, error)
+	restoreChampionship(ctx context.Context, id string) (Championship, error)
+	purgeChampionship(ctx context.Context, id string) (Championship, error)
 }
 
 type Controller struct {
@@ -21,35 +31,147 @@ func NewController(service service) *Controller {
 
 func (c *Controller) PostChampionship(ctx *gin.Context) {
 	var req Championship
-	if err := ctx.BindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
+	// Display names are answered, never taken.
+	req = req.withoutDisplayNames()
 
-	championship, err := c.service.createChampionship(ctx, req)
+	championship, err := c.service.createChampionship(ctx.Request.Context(), req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, championship)
+	ctx.Header("ETag", etag.Localized(championship.Version, language(ctx)))
+	apiversion.JSON(ctx, http.StatusCreated, championship.Localized(language(ctx)))
 }
 
 func (c *Controller) GetChampionship(ctx *gin.Context) {
-	championship, err := c.service.getChampionship(ctx, ctx.Param("id"))
+	includeDeleted, err := auth.AdminFlag(ctx, "includeDeleted")
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	championship, err := c.service.getChampionship(ctx.Request.Context(), ctx.Param("id"), includeDeleted)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
 
 	if championship.isEmpty() {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("championship not found")))
+		return
+	}
+
+	tag := etag.Localized(championship.Version, language(ctx))
+	ctx.Header("ETag", tag)
+	if etag.Match(ctx.GetHeader("If-None-Match"), tag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, championship.Localized(language(ctx)))
+}
+
+// PutChampionship replaces a championship. The If-Match header must carry
+// the ETag the caller last read, so that concurrent edits are refused
+// instead of lost.
+func (c *Controller) PutChampionship(ctx *gin.Context) {
+	version, err := etag.Version(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(etag.ErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, championship)
+	var req Championship
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+	req = req.withoutDisplayNames()
+
+	championship, err := c.service.updateChampionship(ctx.Request.Context(), ctx.Param("id"), req, version)
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if championship.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("championship not found")))
+		return
+	}
+
+	ctx.Header("ETag", etag.Localized(championship.Version, language(ctx)))
+	apiversion.JSON(ctx, http.StatusOK, championship.Localized(language(ctx)))
+}
+
+// DeleteChampionship soft deletes the championship, or removes it for good
+// when an admin passes ?hard=true and no match belongs to it anymore.
+func (c *Controller) DeleteChampionship(ctx *gin.Context) {
+	hard, err := auth.AdminFlag(ctx, "hard")
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	remove := c.service.deleteChampionship
+	if hard {
+		remove = c.service.purgeChampionship
+	}
+
+	championship, err := remove(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, errReferenced) {
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		return
+	}
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if championship.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("championship not found")))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *Controller) RestoreChampionship(ctx *gin.Context) {
+	championship, err := c.service.restoreChampionship(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if championship.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("championship not found")))
+		return
+	}
+
+	ctx.Header("ETag", etag.Localized(championship.Version, language(ctx)))
+	apiversion.JSON(ctx, http.StatusOK, championship.Localized(language(ctx)))
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
 }
 
-func errorResponse(err error) gin.H {
-	return gin.H{"error": err.Error()}
+func language(ctx *gin.Context) string {
+	return i18n.FromContext(ctx.Request.Context())
 }
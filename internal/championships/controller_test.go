@@ -0,0 +1,314 @@
+package championships
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/teams"
+	"strings"
+	"testing"
+)
+
+func TestController_PostChampionship(t *testing.T) {
+	tests := []struct {
+		name                 string
+		setup                func(*serviceMock)
+		requestBody          string
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "when request is invalid",
+			setup:                func(s *serviceMock) {},
+			requestBody:          "abcd",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"request body is not valid JSON\"}",
+		},
+		{
+			name: "when failed to create a championship",
+			setup: func(s *serviceMock) {
+				receivedChampionship := Championship{Name: "Campeonato Brasileiro", Season: "1979", Teams: []teams.Team{}}
+				s.On("createChampionship", mock.Anything, receivedChampionship).Return(Championship{}, errors.New("failed to create championship"))
+			},
+			requestBody:          "{\"name\": \"Campeonato Brasileiro\", \"season\": \"1979\", \"teams\": []}",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"error\":\"failed to create championship\"}",
+		},
+		{
+			name: "when successfully creates a championship",
+			setup: func(s *serviceMock) {
+				receivedChampionship := Championship{Name: "Campeonato Brasileiro", Season: "1979", Teams: []teams.Team{}}
+				returnChampionship := Championship{Id: "1", Name: "Campeonato Brasileiro", Season: "1979", Teams: []teams.Team{}}
+				s.On("createChampionship", mock.Anything, receivedChampionship).Return(returnChampionship, nil)
+			},
+			requestBody:          "{\"name\": \"Campeonato Brasileiro\", \"season\": \"1979\", \"teams\": []}",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":\"1\",\"name\":\"Campeonato Brasileiro\",\"season\":\"1979\",\"teams\":[],\"displayName\":\"Campeonato Brasileiro\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Body:   io.NopCloser(bytes.NewBuffer([]byte(tt.requestBody))),
+			}
+
+			c.PostChampionship(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_GetChampionship(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		id                 string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when failed to get championship",
+			setup: func(s *serviceMock) {
+				s.On("getChampionship", mock.Anything, "1", false).Return(Championship{}, errors.New("failed to get championship"))
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to get championship\"}",
+		},
+		{
+			name: "when championship is not found",
+			setup: func(s *serviceMock) {
+				s.On("getChampionship", mock.Anything, "1", false).Return(Championship{}, nil)
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"championship not found\"}",
+		},
+		{
+			name: "when successfully get championship",
+			setup: func(s *serviceMock) {
+				championship := Championship{Id: "1", Name: "Campeonato Brasileiro", Season: "1979", Teams: []teams.Team{}}
+				s.On("getChampionship", mock.Anything, "1", false).Return(championship, nil)
+			},
+			id:                 "1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Campeonato Brasileiro\",\"season\":\"1979\",\"teams\":[],\"displayName\":\"Campeonato Brasileiro\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", tt.id)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/championships/"+tt.id, nil)
+
+			c.GetChampionship(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_PutChampionship(t *testing.T) {
+	requestBody := "{\"name\": \"Copa Libertadores\", \"season\": \"2006\", \"teams\": []}"
+	received := Championship{Name: "Copa Libertadores", Season: "2006", Teams: []teams.Team{}}
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		ifMatch            string
+		expectedStatusCode int
+		expectedETag       string
+		expectedBody       string
+	}{
+		{
+			name:               "when If-Match is missing",
+			setup:              func(s *serviceMock) {},
+			expectedStatusCode: http.StatusPreconditionRequired,
+			expectedBody:       "{\"error\":\"If-Match header is required\"}",
+		},
+		{
+			name: "when championship changed since it was read",
+			setup: func(s *serviceMock) {
+				s.On("updateChampionship", mock.Anything, "1", received, int64(1)).Return(Championship{}, etag.ErrMismatch)
+			},
+			ifMatch:            "\"1\"",
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedBody:       "{\"error\":\"the resource was modified since it was read\"}",
+		},
+		{
+			name: "when successfully updates a championship",
+			setup: func(s *serviceMock) {
+				updated := Championship{Id: "1", Name: "Copa Libertadores", Season: "2006", Teams: []teams.Team{}, Version: 2}
+				s.On("updateChampionship", mock.Anything, "1", received, int64(1)).Return(updated, nil)
+			},
+			ifMatch:            "\"1\"",
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       "\"2-en\"",
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Copa Libertadores\",\"season\":\"2006\",\"teams\":[],\"displayName\":\"Copa Libertadores\",\"version\":2}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "1")
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/championships/1", strings.NewReader(requestBody))
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			c.PutChampionship(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedETag, recorder.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_DeleteChampionship(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		principal          auth.Principal
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when failed to delete championship",
+			setup: func(s *serviceMock) {
+				s.On("deleteChampionship", mock.Anything, "1").Return(Championship{}, errors.New("failed to delete championship"))
+			},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to delete championship\"}",
+		},
+		{
+			name: "when championship is not found",
+			setup: func(s *serviceMock) {
+				s.On("deleteChampionship", mock.Anything, "1").Return(Championship{}, nil)
+			},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"championship not found\"}",
+		},
+		{
+			name: "when successfully deletes a championship",
+			setup: func(s *serviceMock) {
+				s.On("deleteChampionship", mock.Anything, "1").Return(Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"}, nil)
+			},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       "",
+		},
+		{
+			name:               "when an editor asks for a hard delete",
+			setup:              func(s *serviceMock) {},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			query:              "?hard=true",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "{\"error\":\"insufficient role\"}",
+		},
+		{
+			name: "when the championship still has matches",
+			setup: func(s *serviceMock) {
+				s.On("purgeChampionship", mock.Anything, "1").Return(Championship{}, errReferenced)
+			},
+			principal:          auth.Principal{Subject: "root", Role: auth.Admin},
+			query:              "?hard=true",
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       "{\"error\":\"championship is still referenced by matches\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "1")
+			request := httptest.NewRequest(http.MethodDelete, "/championships/1"+tt.query, nil)
+			ctx.Request = request.WithContext(auth.WithPrincipal(request.Context(), tt.principal))
+
+			c.DeleteChampionship(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) createChampionship(ctx context.Context, championship Championship) (Championship, error) {
+	args := m.Called(ctx, championship)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *serviceMock) getChampionship(ctx context.Context, id string, includeDeleted bool) (Championship, error) {
+	args := m.Called(ctx, id, includeDeleted)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *serviceMock) updateChampionship(ctx context.Context, id string, championship Championship, version int64) (Championship, error) {
+	args := m.Called(ctx, id, championship, version)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *serviceMock) deleteChampionship(ctx context.Context, id string) (Championship, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *serviceMock) restoreChampionship(ctx context.Context, id string) (Championship, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *serviceMock) purgeChampionship(ctx context.Context, id string) (Championship, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
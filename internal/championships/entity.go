@@ -1,14 +1,55 @@
 package championships
 
-import "sc-internacional/internal/teams"
+import (
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/teams"
+	"time"
+)
 
+// Championship is a season of a competition. Names and DisplayName localize
+// its name like those of teams do.
 type Championship struct {
-	Id     string       `json:"id,omitempty"`
-	Name   string       `json:"name" binding:"required"`
-	Season string       `json:"season" binding:"required"`
-	Teams  []teams.Team `json:"teams" binding:"required"`
+	Id          string            `json:"id,omitempty" bson:"_id,omitempty"`
+	Name        string            `json:"name" binding:"required"`
+	Season      string            `json:"season" binding:"required"`
+	Teams       []teams.Team      `json:"teams" binding:"required"`
+	Names       map[string]string `json:"names,omitempty" bson:",omitempty" binding:"omitempty,dive,keys,oneof=pt-BR en es,endkeys,required"`
+	DisplayName string            `json:"displayName,omitempty" bson:"-"`
+	Version     int64             `json:"version,omitempty"`
+	DeletedAt   *time.Time        `json:"deletedAt,omitempty" bson:",omitempty"`
 }
 
 func (c *Championship) isEmpty() bool {
 	return c.Id == "" && c.Name == "" && c.Season == "" && len(c.Teams) == 0
 }
+
+// Localized returns the championship, and its teams, with the display
+// names of lang.
+func (c Championship) Localized(lang string) Championship {
+	c.DisplayName = i18n.Name(lang, c.Name, c.Names)
+	if c.Teams != nil {
+		localized := make([]teams.Team, len(c.Teams))
+		for i, team := range c.Teams {
+			localized[i] = team.Localized(lang)
+		}
+		c.Teams = localized
+	}
+	return c
+}
+
+func (c Championship) withoutDisplayNames() Championship {
+	c.DisplayName = ""
+	if c.Teams != nil {
+		stripped := make([]teams.Team, len(c.Teams))
+		for i, team := range c.Teams {
+			team.DisplayName = ""
+			stripped[i] = team
+		}
+		c.Teams = stripped
+	}
+	return c
+}
+
+func (c *Championship) isDeleted() bool {
+	return c.DeletedAt != nil
+}
@@ -0,0 +1,154 @@
+package championships
+
+import (
+	"context"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/pb"
+	"sc-internacional/internal/rpc"
+	"sc-internacional/internal/teams"
+)
+
+var errNotFound = status.Error(codes.NotFound, "championship not found")
+
+// GRPCServer serves the Championships gRPC service through the same service
+// as Controller, so that both APIs validate, audit and publish alike.
+type GRPCServer struct {
+	pb.UnimplementedChampionshipsServer
+	service service
+}
+
+func NewGRPCServer(service service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+func (s *GRPCServer) GetChampionship(ctx context.Context, req *pb.GetChampionshipRequest) (*pb.Championship, error) {
+	if err := auth.AdminOnly(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	championship, err := s.service.getChampionship(ctx, req.GetId(), req.GetIncludeDeleted())
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if championship.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return championship.proto(), nil
+}
+
+func (s *GRPCServer) CreateChampionship(ctx context.Context, req *pb.CreateChampionshipRequest) (*pb.Championship, error) {
+	championship := fromProto(req.GetChampionship())
+	if err := binding.Validator.ValidateStruct(&championship); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	createdChampionship, err := s.service.createChampionship(ctx, championship)
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	return createdChampionship.proto(), nil
+}
+
+// UpdateChampionship replaces a championship. Like If-Match over REST, the
+// request must carry the version the caller last read.
+func (s *GRPCServer) UpdateChampionship(ctx context.Context, req *pb.UpdateChampionshipRequest) (*pb.Championship, error) {
+	version, err := rpc.ExpectedVersion(req.Version, "championship")
+	if err != nil {
+		return nil, err
+	}
+
+	championship := fromProto(req.GetChampionship())
+	if err = binding.Validator.ValidateStruct(&championship); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	updatedChampionship, err := s.service.updateChampionship(ctx, req.GetId(), championship, version)
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if updatedChampionship.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return updatedChampionship.proto(), nil
+}
+
+// DeleteChampionship soft deletes the championship, or removes it for good
+// when an admin sets hard and no match belongs to it anymore.
+func (s *GRPCServer) DeleteChampionship(ctx context.Context, req *pb.DeleteChampionshipRequest) (*pb.DeleteChampionshipResponse, error) {
+	if err := auth.AdminOnly(ctx, req.GetHard()); err != nil {
+		return nil, err
+	}
+
+	remove := s.service.deleteChampionship
+	if req.GetHard() {
+		remove = s.service.purgeChampionship
+	}
+
+	championship, err := remove(ctx, req.GetId())
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if championship.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return &pb.DeleteChampionshipResponse{}, nil
+}
+
+func (s *GRPCServer) RestoreChampionship(ctx context.Context, req *pb.RestoreChampionshipRequest) (*pb.Championship, error) {
+	championship, err := s.service.restoreChampionship(ctx, req.GetId())
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if championship.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return championship.proto(), nil
+}
+
+func (c *Championship) proto() *pb.Championship {
+	championship := &pb.Championship{
+		Id:      c.Id,
+		Name:    c.Name,
+		Season:  c.Season,
+		Teams:   make([]*pb.Team, 0, len(c.Teams)),
+		Version: c.Version,
+		Names:   c.Names,
+	}
+	for _, team := range c.Teams {
+		championship.Teams = append(championship.Teams, team.Proto())
+	}
+	if c.DeletedAt != nil {
+		championship.DeletedAt = timestamppb.New(*c.DeletedAt)
+	}
+
+	return championship
+}
+
+// fromProto converts the fields of a championship message a client may set.
+// Teams stay nil when the message has none, so that validation rejects it.
+func fromProto(championship *pb.Championship) Championship {
+	var championshipTeams []teams.Team
+	for _, team := range championship.GetTeams() {
+		championshipTeams = append(championshipTeams, teams.TeamFromProto(team))
+	}
+
+	return Championship{
+		Name:   championship.GetName(),
+		Season: championship.GetSeason(),
+		Teams:  championshipTeams,
+		Names:  championship.GetNames(),
+	}
+}
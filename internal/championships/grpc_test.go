@@ -0,0 +1,186 @@
+package championships
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/pb"
+	"sc-internacional/internal/teams"
+	"testing"
+	"time"
+)
+
+func TestGRPCServer_CreateChampionship(t *testing.T) {
+	foundationDate := time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)
+	championship := Championship{Name: "Brasileirão", Season: "2024", Teams: []teams.Team{{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: foundationDate}}}
+	request := &pb.CreateChampionshipRequest{Championship: &pb.Championship{
+		Name:   "Brasileirão",
+		Season: "2024",
+		Teams:  []*pb.Team{{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: timestamppb.New(foundationDate)}},
+	}}
+
+	tests := []struct {
+		name                 string
+		setup                func(*serviceMock)
+		request              *pb.CreateChampionshipRequest
+		expectedCode         codes.Code
+		expectedChampionship *pb.Championship
+	}{
+		{
+			name:         "when championship has no teams",
+			setup:        func(s *serviceMock) {},
+			request:      &pb.CreateChampionshipRequest{Championship: &pb.Championship{Name: "Brasileirão", Season: "2024"}},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "when failed to create a championship",
+			setup: func(s *serviceMock) {
+				s.On("createChampionship", mock.Anything, championship).Return(Championship{}, errors.New("failed to insert"))
+			},
+			request:      request,
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "when successfully creates a championship",
+			setup: func(s *serviceMock) {
+				createdChampionship := championship
+				createdChampionship.Id = "10"
+				createdChampionship.Version = 1
+				s.On("createChampionship", mock.Anything, championship).Return(createdChampionship, nil)
+			},
+			request:      request,
+			expectedCode: codes.OK,
+			expectedChampionship: &pb.Championship{
+				Id:      "10",
+				Name:    "Brasileirão",
+				Season:  "2024",
+				Teams:   []*pb.Team{{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: timestamppb.New(foundationDate)}},
+				Version: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			got, err := NewGRPCServer(s).CreateChampionship(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.True(t, proto.Equal(tt.expectedChampionship, got))
+		})
+	}
+}
+
+func TestGRPCServer_UpdateChampionship(t *testing.T) {
+	request := func(version *int64) *pb.UpdateChampionshipRequest {
+		return &pb.UpdateChampionshipRequest{
+			Id:           "10",
+			Championship: &pb.Championship{Name: "Brasileirão", Season: "2024", Teams: []*pb.Team{{Id: "1", Name: "Internacional"}}},
+			Version:      version,
+		}
+	}
+	championship := Championship{Name: "Brasileirão", Season: "2024", Teams: []teams.Team{{Id: "1", Name: "Internacional"}}}
+
+	tests := []struct {
+		name         string
+		setup        func(*serviceMock)
+		request      *pb.UpdateChampionshipRequest
+		expectedCode codes.Code
+	}{
+		{
+			name:         "when version is missing",
+			setup:        func(s *serviceMock) {},
+			request:      request(nil),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "when version is invalid",
+			setup:        func(s *serviceMock) {},
+			request:      request(proto.Int64(-2)),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "when championship changed since it was read",
+			setup: func(s *serviceMock) {
+				s.On("updateChampionship", mock.Anything, "10", championship, int64(1)).Return(Championship{}, etag.ErrMismatch)
+			},
+			request:      request(proto.Int64(1)),
+			expectedCode: codes.Aborted,
+		},
+		{
+			name: "when championship is not found",
+			setup: func(s *serviceMock) {
+				s.On("updateChampionship", mock.Anything, "10", championship, etag.Any).Return(Championship{}, nil)
+			},
+			request:      request(proto.Int64(-1)),
+			expectedCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			_, err := NewGRPCServer(s).UpdateChampionship(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCServer_DeleteChampionship(t *testing.T) {
+	admin := auth.WithPrincipal(context.Background(), auth.Principal{Subject: "d'alessandro", Role: auth.Admin})
+	editor := auth.WithPrincipal(context.Background(), auth.Principal{Subject: "discord-bot", Role: auth.Editor})
+
+	tests := []struct {
+		name         string
+		setup        func(*serviceMock)
+		ctx          context.Context
+		request      *pb.DeleteChampionshipRequest
+		expectedCode codes.Code
+	}{
+		{
+			name: "when championship is not found",
+			setup: func(s *serviceMock) {
+				s.On("deleteChampionship", mock.Anything, "10").Return(Championship{}, nil)
+			},
+			ctx:          editor,
+			request:      &pb.DeleteChampionshipRequest{Id: "10"},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "when an editor purges a championship",
+			setup:        func(s *serviceMock) {},
+			ctx:          editor,
+			request:      &pb.DeleteChampionshipRequest{Id: "10", Hard: true},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "when purged championship still has matches",
+			setup: func(s *serviceMock) {
+				s.On("purgeChampionship", mock.Anything, "10").Return(Championship{}, errReferenced)
+			},
+			ctx:          admin,
+			request:      &pb.DeleteChampionshipRequest{Id: "10", Hard: true},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			_, err := NewGRPCServer(s).DeleteChampionship(tt.ctx, tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package championships
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/clients/mongodb"
+	"sc-internacional/internal/tracing"
+)
+
+type db interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+// referencesDB is a collection whose documents may point at a championship.
+type referencesDB interface {
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+}
+
+type Repository struct {
+	db
+	matches referencesDB
+}
+
+func NewRepository(db db, matches referencesDB) *Repository {
+	return &Repository{db: db, matches: matches}
+}
+
+func (r Repository) createChampionship(ctx context.Context, championship Championship) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Repository.createChampionship")
+	defer span.End()
+
+	championship.Version = 1
+	result, err := r.db.InsertOne(ctx, championship)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	championship.Id = result.InsertedID.(primitive.ObjectID).Hex()
+
+	return championship, nil
+}
+
+func (r Repository) getChampionship(ctx context.Context, id string, includeDeleted bool) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Repository.getChampionship")
+	defer span.End()
+
+	var championship Championship
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	err = r.db.FindOne(ctx, mongodb.NotDeleted(bson.M{"_id": docID}, includeDeleted)).Decode(&championship)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Championship{}, nil
+	}
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	return championship, nil
+}
+
+// updateChampionship replaces the stored championship with the same id and
+// version, bumping the version. It returns an empty championship when there
+// is no such championship at that version.
+func (r Repository) updateChampionship(ctx context.Context, championship Championship) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Repository.updateChampionship")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(championship.Id)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	replacement := championship
+	replacement.Id = ""
+	replacement.Version = championship.Version + 1
+
+	result, err := r.db.ReplaceOne(ctx, bson.M{"_id": docID, "version": mongodb.AtVersion(championship.Version)}, replacement)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return Championship{}, nil
+	}
+
+	championship.Version = replacement.Version
+
+	return championship, nil
+}
+
+// deleteChampionship removes the document for good. Soft deletes go through
+// updateChampionship with DeletedAt set.
+func (r Repository) deleteChampionship(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "championships.Repository.deleteChampionship")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	if _, err = r.db.DeleteOne(ctx, bson.M{"_id": docID}); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// getChampionships returns the championships with the given ids that exist
+// and are not deleted, in no particular order.
+func (r Repository) getChampionships(ctx context.Context, ids []string) ([]Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Repository.getChampionships")
+	defer span.End()
+
+	championships, err := r.findChampionships(ctx, bson.M{"_id": bson.M{"$in": mongodb.ObjectIDs(ids)}})
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return championships, nil
+}
+
+// getChampionshipsByTeams returns the championships that are not deleted and
+// that any of the teams with the given ids takes part in.
+func (r Repository) getChampionshipsByTeams(ctx context.Context, teamIds []string) ([]Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Repository.getChampionshipsByTeams")
+	defer span.End()
+
+	championships, err := r.findChampionships(ctx, bson.M{"teams._id": bson.M{"$in": teamIds}})
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return championships, nil
+}
+
+func (r Repository) findChampionships(ctx context.Context, filter bson.M) ([]Championship, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "season", Value: -1}, {Key: "name", Value: 1}})
+	cursor, err := r.db.Find(ctx, mongodb.NotDeleted(filter, false), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	championships := []Championship{}
+	if err = cursor.All(ctx, &championships); err != nil {
+		return nil, err
+	}
+
+	return championships, nil
+}
+
+// isReferenced reports whether any match, deleted or not, still belongs to
+// the championship.
+func (r Repository) isReferenced(ctx context.Context, id string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "championships.Repository.isReferenced")
+	defer span.End()
+
+	count, err := r.matches.CountDocuments(ctx, bson.M{"championshipid": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, tracing.Error(span, err)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,120 @@
+package championships
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"testing"
+)
+
+func TestRepository_createChampionship(t *testing.T) {
+	objectId := primitive.NewObjectID()
+	tests := []struct {
+		name         string
+		setup        func(d *dbMock)
+		championship Championship
+		want         Championship
+		wantErr      error
+	}{
+		{
+			name: "when failed to create a championship",
+			setup: func(d *dbMock) {
+				d.On("InsertOne", mock.Anything, Championship{Name: "FIFA Club World Cup", Season: "2006", Version: 1}, []*options.InsertOneOptions(nil)).Return(&mongo.InsertOneResult{}, errors.New("failed to create championship"))
+			},
+			championship: Championship{Name: "FIFA Club World Cup", Season: "2006"},
+			want:         Championship{},
+			wantErr:      errors.New("failed to create championship"),
+		},
+		{
+			name: "when successfully create a championship",
+			setup: func(d *dbMock) {
+				d.On("InsertOne", mock.Anything, Championship{Name: "FIFA Club World Cup", Season: "2006", Version: 1}, []*options.InsertOneOptions(nil)).Return(&mongo.InsertOneResult{InsertedID: objectId}, nil)
+			},
+			championship: Championship{Name: "FIFA Club World Cup", Season: "2006"},
+			want:         Championship{Id: objectId.Hex(), Name: "FIFA Club World Cup", Season: "2006", Version: 1},
+			wantErr:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dbMock{}
+			tt.setup(d)
+
+			r := NewRepository(d, &dbMock{})
+
+			got, err := r.createChampionship(context.Background(), tt.championship)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRepository_getChampionship(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(d *dbMock)
+		id      string
+		want    Championship
+		wantErr error
+	}{
+		{
+			name:    "when invalid id is received",
+			setup:   func(d *dbMock) {},
+			id:      "xpto",
+			want:    Championship{},
+			wantErr: errors.New("the provided hex string is not a valid ObjectID"),
+		},
+		{
+			name: "when sucessfully find championship",
+			setup: func(d *dbMock) {
+				hexId := primitive.ObjectID{0x67, 0xa, 0x95, 0xa8, 0xc1, 0x35, 0xef, 0x7c, 0x3d, 0x61, 0xf3, 0xb5}
+				result := map[string]interface{}{"_id": "670a95a8c135ef7c3d61f3b5", "name": "FIFA Club World Cup", "season": "2006"}
+				d.On("FindOne", mock.Anything, primitive.M{"_id": hexId, "deletedat": nil}, []*options.FindOneOptions(nil)).Return(mongo.NewSingleResultFromDocument(result, nil, nil))
+			},
+			id:      "670a95a8c135ef7c3d61f3b5",
+			want:    Championship{Id: "670a95a8c135ef7c3d61f3b5", Name: "FIFA Club World Cup", Season: "2006"},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dbMock{}
+			tt.setup(d)
+
+			r := NewRepository(d, &dbMock{})
+
+			got, err := r.getChampionship(context.Background(), tt.id, false)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+type dbMock struct {
+	db
+	mock.Mock
+}
+
+func (m *dbMock) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	args := m.Called(ctx, document, opts)
+
+	return args.Get(0).(*mongo.InsertOneResult), args.Error(1)
+}
+
+func (m *dbMock) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	args := m.Called(ctx, filter, opts)
+
+	return args.Get(0).(*mongo.SingleResult)
+}
+
+func (m *dbMock) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	args := m.Called(ctx, filter, opts)
+
+	return args.Get(0).(int64), args.Error(1)
+}
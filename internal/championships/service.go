@@ -0,0 +1,297 @@
+package championships
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+const (
+	auditEntity           = "championship"
+	championshipKeyPrefix = "championships:"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/championships")
+
+var errReferenced = errors.New("championship is still referenced by matches")
+
+type repository interface {
+	createChampionship(ctx context.Context, championship Championship) (Championship, error)
+	getChampionship(ctx context.Context, id string, includeDeleted bool) (Championship, error)
+	updateChampionship(ctx context.Context, championship Championship) (Championship, error)
+	deleteChampionship(ctx context.Context, id string) error
+	isReferenced(ctx context.Context, id string) (bool, error)
+	getChampionships(ctx context.Context, ids []string) ([]Championship, error)
+	getChampionshipsByTeams(ctx context.Context, teamIds []string) ([]Championship, error)
+}
+
+type auditor interface {
+	Record(ctx context.Context, action audit.Action, entity, entityId string, before, after interface{})
+}
+
+type publisher interface {
+	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
+	Publish(ctx context.Context, eventType events.Type, entityId string, previous, current interface{}) error
+}
+
+type Service struct {
+	repository repository
+	auditor    auditor
+	cache      cache.Cache
+	publisher  publisher
+	now        func() time.Time
+}
+
+func NewService(repository repository, auditor auditor, cache cache.Cache, publisher publisher) *Service {
+	return &Service{repository: repository, auditor: auditor, cache: cache, publisher: publisher, now: time.Now}
+}
+
+func (s Service) createChampionship(ctx context.Context, championship Championship) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.createChampionship")
+	defer span.End()
+
+	championship.DeletedAt = nil
+	var createdChampionship Championship
+	err := s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		createdChampionship, err = s.repository.createChampionship(ctx, championship)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.ChampionshipCreated, createdChampionship.Id, nil, createdChampionship)
+	})
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	s.invalidate(ctx, createdChampionship.Id)
+	s.auditor.Record(ctx, audit.Create, auditEntity, createdChampionship.Id, nil, createdChampionship)
+
+	return createdChampionship, nil
+}
+
+func (s Service) getChampionship(ctx context.Context, id string, includeDeleted bool) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.getChampionship")
+	defer span.End()
+
+	load := func(ctx context.Context) (Championship, error) {
+		return s.repository.getChampionship(ctx, id, includeDeleted)
+	}
+
+	var championship Championship
+	var err error
+	if includeDeleted {
+		// Deleted championships are only shown to admins, so that view is not
+		// cached.
+		championship, err = load(ctx)
+	} else {
+		championship, err = cache.Fetch(ctx, s.cache, championshipKeyPrefix+id, load)
+	}
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	return championship, nil
+}
+
+// GetChampionships returns the championships with the given ids that exist
+// and are not deleted, in no particular order, reading them all at once.
+func (s Service) GetChampionships(ctx context.Context, ids []string) ([]Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.GetChampionships")
+	defer span.End()
+
+	championships, err := s.repository.getChampionships(ctx, ids)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return championships, nil
+}
+
+// GetChampionshipsByTeams returns the championships, latest season first,
+// that any of the teams with the given ids takes part in.
+func (s Service) GetChampionshipsByTeams(ctx context.Context, teamIds []string) ([]Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.GetChampionshipsByTeams")
+	defer span.End()
+
+	championships, err := s.repository.getChampionshipsByTeams(ctx, teamIds)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return championships, nil
+}
+
+// updateChampionship replaces the championship with the given id if it is
+// still at version, or at any version when it is etag.Any. It returns an
+// empty championship when the championship does not exist or is deleted, and
+// etag.ErrMismatch when it changed.
+func (s Service) updateChampionship(ctx context.Context, id string, championship Championship, version int64) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.updateChampionship")
+	defer span.End()
+
+	before, err := s.repository.getChampionship(ctx, id, false)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Championship{}, nil
+	}
+	if version != etag.Any && version != before.Version {
+		return Championship{}, etag.ErrMismatch
+	}
+
+	championship.Id = id
+	championship.Version = before.Version
+	championship.DeletedAt = nil
+	var updatedChampionship Championship
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		updatedChampionship, err = s.repository.updateChampionship(ctx, championship)
+		if err != nil || updatedChampionship.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.ChampionshipUpdated, id, before, updatedChampionship)
+	})
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if updatedChampionship.isEmpty() {
+		return Championship{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Update, auditEntity, id, before, updatedChampionship)
+
+	return updatedChampionship, nil
+}
+
+// deleteChampionship marks the championship with the given id as deleted and
+// returns it, or an empty championship when it does not exist or is already
+// deleted.
+func (s Service) deleteChampionship(ctx context.Context, id string) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.deleteChampionship")
+	defer span.End()
+
+	before, err := s.repository.getChampionship(ctx, id, false)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Championship{}, nil
+	}
+
+	deletedAt := s.now().UTC().Truncate(time.Millisecond)
+	championship := before
+	championship.DeletedAt = &deletedAt
+
+	var deletedChampionship Championship
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		deletedChampionship, err = s.repository.updateChampionship(ctx, championship)
+		if err != nil || deletedChampionship.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.ChampionshipDeleted, id, before, deletedChampionship)
+	})
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if deletedChampionship.isEmpty() {
+		return Championship{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Delete, auditEntity, id, before, deletedChampionship)
+
+	return deletedChampionship, nil
+}
+
+// restoreChampionship clears the deletion marker of the championship with the
+// given id. A championship that is not deleted is returned unchanged.
+func (s Service) restoreChampionship(ctx context.Context, id string) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.restoreChampionship")
+	defer span.End()
+
+	before, err := s.repository.getChampionship(ctx, id, true)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() || !before.isDeleted() {
+		return before, nil
+	}
+
+	championship := before
+	championship.DeletedAt = nil
+
+	var restoredChampionship Championship
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		restoredChampionship, err = s.repository.updateChampionship(ctx, championship)
+		if err != nil || restoredChampionship.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.ChampionshipRestored, id, before, restoredChampionship)
+	})
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if restoredChampionship.isEmpty() {
+		return Championship{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Restore, auditEntity, id, before, restoredChampionship)
+
+	return restoredChampionship, nil
+}
+
+// purgeChampionship removes the championship with the given id for good,
+// deleted or not. It fails with errReferenced while a match belongs to it.
+func (s Service) purgeChampionship(ctx context.Context, id string) (Championship, error) {
+	ctx, span := tracer.Start(ctx, "championships.Service.purgeChampionship")
+	defer span.End()
+
+	before, err := s.repository.getChampionship(ctx, id, true)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Championship{}, nil
+	}
+
+	referenced, err := s.repository.isReferenced(ctx, id)
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+	if referenced {
+		return Championship{}, errReferenced
+	}
+
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		if err := s.repository.deleteChampionship(ctx, id); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.ChampionshipPurged, id, before, nil)
+	})
+	if err != nil {
+		return Championship{}, tracing.Error(span, err)
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Purge, auditEntity, id, before, nil)
+
+	return before, nil
+}
+
+// invalidate drops the cached reads a write to the championship with the
+// given id makes stale.
+func (s Service) invalidate(ctx context.Context, id string) {
+	cache.Invalidate(ctx, s.cache, championshipKeyPrefix+id)
+}
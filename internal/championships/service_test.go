@@ -0,0 +1,331 @@
+package championships
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/events"
+	"testing"
+	"time"
+)
+
+func TestService_createChampionship(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(r *repositoryMock)
+		championship Championship
+		want         Championship
+		wantErr      error
+	}{
+		{
+			name: "when repository fail to create championship",
+			setup: func(r *repositoryMock) {
+				r.On("createChampionship", mock.Anything, Championship{Name: "Copa Libertadores", Season: "2006"}).Return(Championship{}, errors.New("failed to create championship"))
+			},
+			championship: Championship{Name: "Copa Libertadores", Season: "2006"},
+			want:         Championship{},
+			wantErr:      errors.New("failed to create championship"),
+		},
+		{
+			name: "when repository successfully create championship",
+			setup: func(r *repositoryMock) {
+				r.On("createChampionship", mock.Anything, Championship{Name: "Copa Libertadores", Season: "2006"}).Return(Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"}, nil)
+			},
+			championship: Championship{Name: "Copa Libertadores", Season: "2006"},
+			want:         Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"},
+			wantErr:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.createChampionship(context.Background(), tt.championship)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestService_getChampionship(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(r *repositoryMock)
+		id      string
+		want    Championship
+		wantErr error
+	}{
+		{
+			name: "when repository fail to get championship",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(Championship{}, errors.New("failed to get championship"))
+			},
+			id:      "1",
+			want:    Championship{},
+			wantErr: errors.New("failed to get championship"),
+		},
+		{
+			name: "when repository successfully get championship",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"}, nil)
+			},
+			id:      "1",
+			want:    Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.getChampionship(context.Background(), tt.id, false)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestService_updateChampionship(t *testing.T) {
+	before := Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"}
+	after := Championship{Id: "1", Name: "Copa Libertadores da América", Season: "2006"}
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		version   int64
+		want      Championship
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when championship does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(Championship{}, nil)
+			},
+			want:    Championship{},
+			wantErr: nil,
+		},
+		{
+			name: "when championship is at another version",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(before, nil)
+			},
+			version: 4,
+			want:    Championship{},
+			wantErr: etag.ErrMismatch,
+		},
+		{
+			name: "when repository fail to update championship",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateChampionship", mock.Anything, after).Return(Championship{}, errors.New("failed to update championship"))
+			},
+			want:    Championship{},
+			wantErr: errors.New("failed to update championship"),
+		},
+		{
+			name: "when repository successfully update championship",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateChampionship", mock.Anything, after).Return(after, nil)
+			},
+			want:      after,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Update, "championship", "1", before, after).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.updateChampionship(context.Background(), "1", Championship{Name: "Copa Libertadores da América", Season: "2006"}, tt.version)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_deleteChampionship(t *testing.T) {
+	now := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+	before := Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"}
+	deleted := Championship{Id: "1", Name: "Copa Libertadores", Season: "2006", DeletedAt: &now}
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Championship
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when championship does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(Championship{}, nil)
+			},
+			want:    Championship{},
+			wantErr: nil,
+		},
+		{
+			name: "when repository fail to mark championship as deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateChampionship", mock.Anything, deleted).Return(Championship{}, errors.New("failed to update championship"))
+			},
+			want:    Championship{},
+			wantErr: errors.New("failed to update championship"),
+		},
+		{
+			name: "when repository successfully mark championship as deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateChampionship", mock.Anything, deleted).Return(deleted, nil)
+			},
+			want:      deleted,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Delete, "championship", "1", before, deleted).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+			s.now = func() time.Time { return now }
+
+			got, err := s.deleteChampionship(context.Background(), "1")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_purgeChampionship(t *testing.T) {
+	before := Championship{Id: "1", Name: "Copa Libertadores", Season: "2006"}
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Championship
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when championship still has matches",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", true).Return(before, nil)
+				r.On("isReferenced", mock.Anything, "1").Return(true, nil)
+			},
+			want:    Championship{},
+			wantErr: errReferenced,
+		},
+		{
+			name: "when repository successfully delete championship",
+			setup: func(r *repositoryMock) {
+				r.On("getChampionship", mock.Anything, "1", true).Return(before, nil)
+				r.On("isReferenced", mock.Anything, "1").Return(false, nil)
+				r.On("deleteChampionship", mock.Anything, "1").Return(nil)
+			},
+			want:      before,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Purge, "championship", "1", before, nil).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.purgeChampionship(context.Background(), "1")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) createChampionship(ctx context.Context, championship Championship) (Championship, error) {
+	args := m.Called(ctx, championship)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *repositoryMock) getChampionship(ctx context.Context, id string, includeDeleted bool) (Championship, error) {
+	args := m.Called(ctx, id, includeDeleted)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *repositoryMock) updateChampionship(ctx context.Context, championship Championship) (Championship, error) {
+	args := m.Called(ctx, championship)
+
+	return args.Get(0).(Championship), args.Error(1)
+}
+
+func (m *repositoryMock) deleteChampionship(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *repositoryMock) isReferenced(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+
+	return args.Bool(0), args.Error(1)
+}
+
+type auditorMock struct {
+	mock.Mock
+}
+
+func (m *auditorMock) Record(ctx context.Context, action audit.Action, entity, entityId string, before, after interface{}) {
+	m.Called(ctx, action, entity, entityId, before, after)
+}
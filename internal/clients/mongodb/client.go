@@ -2,8 +2,12 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"sc-internacional/internal/metrics"
 )
 
 type Client struct {
@@ -11,15 +15,50 @@ type Client struct {
 	MongoClient *mongo.Client
 }
 
+// NewMongoClient connects to Mongo and pings the primary, so a wrong URI or
+// an unreachable server fails at startup instead of on the first request.
 func NewMongoClient(config *Config) (*Client, error) {
 	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
 	uri := config.MongoURI
-	opts := options.Client().ApplyURI(uri).SetServerAPIOptions(serverAPI)
+	opts := options.Client().ApplyURI(uri).SetServerAPIOptions(serverAPI).
+		SetConnectTimeout(config.ConnectTimeout).
+		SetServerSelectionTimeout(config.ServerSelectionTimeout).
+		SetMaxPoolSize(config.MaxPoolSize).
+		SetMinPoolSize(config.MinPoolSize).
+		SetMaxConnIdleTime(config.MaxConnIdleTime).
+		SetRetryWrites(config.RetryWrites).
+		SetRetryReads(config.RetryReads).
+		SetPoolMonitor(metrics.PoolMonitor()).
+		SetMonitor(otelmongo.NewMonitor())
 
-	client, err := mongo.Connect(context.Background(), opts)
+	readConcern, err := config.readConcern()
+	if err != nil {
+		return nil, err
+	}
+	if readConcern != nil {
+		opts.SetReadConcern(readConcern)
+	}
+
+	writeConcern, err := config.writeConcern()
 	if err != nil {
 		return nil, err
 	}
+	if writeConcern != nil {
+		opts.SetWriteConcern(writeConcern)
+	}
+
+	client, err := mongo.Connect(context.Background(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to mongo: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.PingTimeout)
+	defer cancel()
+
+	if err = client.Ping(ctx, readpref.Primary()); err != nil {
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("pinging mongo database %q: %w", config.DBName, err)
+	}
 
 	return &Client{config: *config, MongoClient: client}, nil
 }
@@ -27,3 +66,31 @@ func NewMongoClient(config *Config) (*Client, error) {
 func (c Client) Database() *mongo.Database {
 	return c.MongoClient.Database(c.config.DBName)
 }
+
+// Collection returns a collection of the configured database whose
+// operations are recorded in the Mongo metrics.
+func (c Client) Collection(name string) Collection {
+	return Collection{c.Database().Collection(name)}
+}
+
+// Transaction runs fn in a transaction, committed when fn returns nil and
+// aborted otherwise. The operations fn makes with the ctx it is handed take
+// part in it, and fn runs again when a transient error aborts it.
+// Transactions need a replica set.
+func (c Client) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := c.MongoClient.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+
+	return err
+}
+
+func (c Client) Ping(ctx context.Context) error {
+	return c.MongoClient.Ping(ctx, readpref.Primary())
+}
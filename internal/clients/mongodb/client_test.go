@@ -0,0 +1,25 @@
+package mongodb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewMongoClient_FailsFastWhenServerIsUnreachable(t *testing.T) {
+	config := &Config{
+		MongoURI:               "mongodb://127.0.0.1:1",
+		DBName:                 "sc-internacional",
+		ConnectTimeout:         100 * time.Millisecond,
+		ServerSelectionTimeout: 100 * time.Millisecond,
+		PingTimeout:            time.Second,
+		MaxPoolSize:            1,
+	}
+
+	start := time.Now()
+	client, err := NewMongoClient(config)
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, "pinging mongo database \"sc-internacional\"")
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,106 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log/slog"
+	"sc-internacional/internal/metrics"
+	"time"
+)
+
+// Collection wraps a mongo.Collection, timing the operations used by the
+// repositories and logging their failures with the request context. Every
+// other method is inherited unchanged.
+type Collection struct {
+	*mongo.Collection
+}
+
+func (c Collection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	start := time.Now()
+	result, err := c.Collection.InsertOne(ctx, document, opts...)
+	c.observe(ctx, "InsertOne", start, err)
+
+	return result, err
+}
+
+func (c Collection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	start := time.Now()
+	result := c.Collection.FindOne(ctx, filter, opts...)
+	c.observe(ctx, "FindOne", start, result.Err())
+
+	return result
+}
+
+func (c Collection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	start := time.Now()
+	result, err := c.Collection.Find(ctx, filter, opts...)
+	c.observe(ctx, "Find", start, err)
+
+	return result, err
+}
+
+func (c Collection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	start := time.Now()
+	result, err := c.Collection.Aggregate(ctx, pipeline, opts...)
+	c.observe(ctx, "Aggregate", start, err)
+
+	return result, err
+}
+
+func (c Collection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	start := time.Now()
+	result, err := c.Collection.DeleteMany(ctx, filter, opts...)
+	c.observe(ctx, "DeleteMany", start, err)
+
+	return result, err
+}
+
+func (c Collection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	start := time.Now()
+	result, err := c.Collection.ReplaceOne(ctx, filter, replacement, opts...)
+	c.observe(ctx, "ReplaceOne", start, err)
+
+	return result, err
+}
+
+func (c Collection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	start := time.Now()
+	result, err := c.Collection.UpdateOne(ctx, filter, update, opts...)
+	c.observe(ctx, "UpdateOne", start, err)
+
+	return result, err
+}
+
+func (c Collection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	start := time.Now()
+	result := c.Collection.FindOneAndUpdate(ctx, filter, update, opts...)
+	c.observe(ctx, "FindOneAndUpdate", start, result.Err())
+
+	return result
+}
+
+func (c Collection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	start := time.Now()
+	result, err := c.Collection.DeleteOne(ctx, filter, opts...)
+	c.observe(ctx, "DeleteOne", start, err)
+
+	return result, err
+}
+
+func (c Collection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	start := time.Now()
+	result, err := c.Collection.CountDocuments(ctx, filter, opts...)
+	c.observe(ctx, "CountDocuments", start, err)
+
+	return result, err
+}
+
+func (c Collection) observe(ctx context.Context, operation string, start time.Time, err error) {
+	metrics.ObserveMongoOperation(c.Name(), operation, start, err)
+
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		slog.ErrorContext(ctx, "mongo operation failed", "collection", c.Name(), "operation", operation, "error", err)
+	}
+}
@@ -1,12 +1,27 @@
 package mongodb
 
 import (
+	"fmt"
 	"github.com/caarlos0/env/v11"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	MongoURI string `env:"MONGO_URI,required"`
-	DBName   string `env:"DB_NAME,required"`
+	MongoURI               string        `env:"MONGO_URI,required"`
+	DBName                 string        `env:"DB_NAME,required"`
+	ConnectTimeout         time.Duration `env:"MONGO_CONNECT_TIMEOUT" envDefault:"10s"`
+	ServerSelectionTimeout time.Duration `env:"MONGO_SERVER_SELECTION_TIMEOUT" envDefault:"5s"`
+	PingTimeout            time.Duration `env:"MONGO_PING_TIMEOUT" envDefault:"10s"`
+	MaxPoolSize            uint64        `env:"MONGO_MAX_POOL_SIZE" envDefault:"100"`
+	MinPoolSize            uint64        `env:"MONGO_MIN_POOL_SIZE" envDefault:"0"`
+	MaxConnIdleTime        time.Duration `env:"MONGO_MAX_CONN_IDLE_TIME" envDefault:"5m"`
+	RetryWrites            bool          `env:"MONGO_RETRY_WRITES" envDefault:"true"`
+	RetryReads             bool          `env:"MONGO_RETRY_READS" envDefault:"true"`
+	ReadConcern            string        `env:"MONGO_READ_CONCERN"`
+	WriteConcern           string        `env:"MONGO_WRITE_CONCERN"`
 }
 
 func NewConfig() (*Config, error) {
@@ -14,5 +29,44 @@ func NewConfig() (*Config, error) {
 	if err := env.Parse(&cfg); err != nil {
 		return nil, err
 	}
+	if cfg.MinPoolSize > cfg.MaxPoolSize && cfg.MaxPoolSize != 0 {
+		return nil, fmt.Errorf("MONGO_MIN_POOL_SIZE (%d) must not exceed MONGO_MAX_POOL_SIZE (%d)", cfg.MinPoolSize, cfg.MaxPoolSize)
+	}
+	if _, err := cfg.readConcern(); err != nil {
+		return nil, err
+	}
+	if _, err := cfg.writeConcern(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
+
+// readConcern returns nil when no level is configured, keeping the server default.
+func (c Config) readConcern() (*readconcern.ReadConcern, error) {
+	switch c.ReadConcern {
+	case "":
+		return nil, nil
+	case "local", "available", "majority", "linearizable", "snapshot":
+		return &readconcern.ReadConcern{Level: c.ReadConcern}, nil
+	default:
+		return nil, fmt.Errorf("invalid MONGO_READ_CONCERN %q", c.ReadConcern)
+	}
+}
+
+// writeConcern accepts "majority" or a number of acknowledging nodes, and
+// returns nil when none is configured, keeping the server default.
+func (c Config) writeConcern() (*writeconcern.WriteConcern, error) {
+	switch c.WriteConcern {
+	case "":
+		return nil, nil
+	case "majority":
+		return writeconcern.Majority(), nil
+	}
+
+	w, err := strconv.Atoi(c.WriteConcern)
+	if err != nil || w < 0 {
+		return nil, fmt.Errorf("invalid MONGO_WRITE_CONCERN %q", c.WriteConcern)
+	}
+
+	return &writeconcern.WriteConcern{W: w}, nil
+}
@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name:    "when required variables are missing",
+			env:     map[string]string{},
+			wantErr: "env: required environment variable \"MONGO_URI\" is not set; required environment variable \"DB_NAME\" is not set",
+		},
+		{
+			name: "when only required variables are set",
+			env:  map[string]string{"MONGO_URI": "mongodb://localhost:27017", "DB_NAME": "sc-internacional"},
+			want: &Config{
+				MongoURI:               "mongodb://localhost:27017",
+				DBName:                 "sc-internacional",
+				ConnectTimeout:         10 * time.Second,
+				ServerSelectionTimeout: 5 * time.Second,
+				PingTimeout:            10 * time.Second,
+				MaxPoolSize:            100,
+				MinPoolSize:            0,
+				MaxConnIdleTime:        5 * time.Minute,
+				RetryWrites:            true,
+				RetryReads:             true,
+			},
+		},
+		{
+			name:    "when min pool size exceeds max pool size",
+			env:     map[string]string{"MONGO_URI": "mongodb://localhost:27017", "DB_NAME": "sc-internacional", "MONGO_MIN_POOL_SIZE": "20", "MONGO_MAX_POOL_SIZE": "10"},
+			wantErr: "MONGO_MIN_POOL_SIZE (20) must not exceed MONGO_MAX_POOL_SIZE (10)",
+		},
+		{
+			name:    "when read concern is invalid",
+			env:     map[string]string{"MONGO_URI": "mongodb://localhost:27017", "DB_NAME": "sc-internacional", "MONGO_READ_CONCERN": "strong"},
+			wantErr: "invalid MONGO_READ_CONCERN \"strong\"",
+		},
+		{
+			name:    "when write concern is invalid",
+			env:     map[string]string{"MONGO_URI": "mongodb://localhost:27017", "DB_NAME": "sc-internacional", "MONGO_WRITE_CONCERN": "all"},
+			wantErr: "invalid MONGO_WRITE_CONCERN \"all\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"MONGO_URI", "DB_NAME"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := NewConfig()
+
+			assert.Equal(t, tt.want, got)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_concerns(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           Config
+		wantReadConcern  *readconcern.ReadConcern
+		wantWriteConcern *writeconcern.WriteConcern
+	}{
+		{
+			name:   "when no concern is configured",
+			config: Config{},
+		},
+		{
+			name:             "when majority is configured",
+			config:           Config{ReadConcern: "majority", WriteConcern: "majority"},
+			wantReadConcern:  readconcern.Majority(),
+			wantWriteConcern: writeconcern.Majority(),
+		},
+		{
+			name:             "when a number of nodes is configured",
+			config:           Config{ReadConcern: "local", WriteConcern: "2"},
+			wantReadConcern:  readconcern.Local(),
+			wantWriteConcern: &writeconcern.WriteConcern{W: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readConcern, err := tt.config.readConcern()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReadConcern, readConcern)
+
+			writeConcern, err := tt.config.writeConcern()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWriteConcern, writeConcern)
+		})
+	}
+}
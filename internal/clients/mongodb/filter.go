@@ -0,0 +1,38 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotDeleted narrows filter to the documents without a deletedAt marker,
+// unless includeDeleted is set.
+func NotDeleted(filter bson.M, includeDeleted bool) bson.M {
+	if !includeDeleted {
+		filter["deletedat"] = nil
+	}
+
+	return filter
+}
+
+// AtVersion matches the version field, counting documents written before
+// versions existed as version 0.
+func AtVersion(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+
+	return version
+}
+
+// ObjectIDs converts ids to document ids, skipping those no document can have.
+func ObjectIDs(ids []string) []primitive.ObjectID {
+	docIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if docID, err := primitive.ObjectIDFromHex(id); err == nil {
+			docIDs = append(docIDs, docID)
+		}
+	}
+
+	return docIDs
+}
@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func TestNotDeleted(t *testing.T) {
+	tests := []struct {
+		name           string
+		includeDeleted bool
+		want           bson.M
+	}{
+		{name: "when deleted documents are excluded", includeDeleted: false, want: bson.M{"name": "Internacional", "deletedat": nil}},
+		{name: "when deleted documents are included", includeDeleted: true, want: bson.M{"name": "Internacional"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NotDeleted(bson.M{"name": "Internacional"}, tt.includeDeleted))
+		})
+	}
+}
+
+func TestAtVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		want    interface{}
+	}{
+		{name: "when the version is 0", version: 0, want: bson.M{"$in": bson.A{0, nil}}},
+		{name: "when the version is past 0", version: 3, want: int64(3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AtVersion(tt.version))
+		})
+	}
+}
+
+func TestObjectIDs(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	assert.Equal(t, []primitive.ObjectID{id}, ObjectIDs([]string{id.Hex(), "1"}))
+}
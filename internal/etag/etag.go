@@ -0,0 +1,80 @@
+// Package etag maps entity versions to HTTP entity tags and evaluates the
+// If-Match and If-None-Match preconditions against them.
+package etag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Any is the version If-Match: * stands for: whatever is current.
+const Any int64 = -1
+
+var (
+	ErrMissing  = errors.New("If-Match header is required")
+	ErrInvalid  = errors.New("If-Match header must be a single entity tag")
+	ErrMismatch = errors.New("the resource was modified since it was read")
+)
+
+// Of returns the strong entity tag of a version.
+func Of(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// Localized returns the strong entity tag of a version answered in lang,
+// for representations whose localized names change with Accept-Language.
+// Version reads it back, so If-Match accepts it whatever the language.
+func Localized(version int64, lang string) string {
+	return `"` + strconv.FormatInt(version, 10) + "-" + lang + `"`
+}
+
+// Match reports whether an If-None-Match header lists tag. Weak tags
+// compare equal to their strong counterparts, as RFC 9110 requires for
+// If-None-Match.
+func Match(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Version parses an If-Match header holding a single strong tag produced by
+// Of or Localized, or *, which yields Any.
+func Version(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, ErrMissing
+	}
+	if header == "*" {
+		return Any, nil
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, ErrInvalid
+	}
+
+	tag, _, _ := strings.Cut(header[1:len(header)-1], "-")
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 0 {
+		return 0, ErrInvalid
+	}
+
+	return version, nil
+}
+
+// ErrorStatus is the status answering an If-Match header Version rejected:
+// 428 Precondition Required when it is missing, 400 Bad Request when it is
+// malformed.
+func ErrorStatus(err error) int {
+	if errors.Is(err, ErrMissing) {
+		return http.StatusPreconditionRequired
+	}
+
+	return http.StatusBadRequest
+}
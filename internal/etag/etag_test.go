@@ -0,0 +1,68 @@
+package etag
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "when header is empty", header: "", want: false},
+		{name: "when header holds the tag", header: `"3"`, want: true},
+		{name: "when header holds the weak tag", header: `W/"3"`, want: true},
+		{name: "when header lists the tag", header: `"1", "3"`, want: true},
+		{name: "when header is a wildcard", header: "*", want: true},
+		{name: "when header holds another tag", header: `"2"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.header, Of(3)))
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int64
+		wantErr error
+	}{
+		{name: "when header is missing", header: "", want: 0, wantErr: ErrMissing},
+		{name: "when header is a wildcard", header: "*", want: Any, wantErr: nil},
+		{name: "when header holds a tag", header: `"3"`, want: 3, wantErr: nil},
+		{name: "when header holds a localized tag", header: `"3-pt-BR"`, want: 3, wantErr: nil},
+		{name: "when header holds a weak tag", header: `W/"3"`, want: 0, wantErr: ErrInvalid},
+		{name: "when header lists several tags", header: `"1", "3"`, want: 0, wantErr: ErrInvalid},
+		{name: "when header is not quoted", header: "3", want: 0, wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Version(tt.header)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "when header is missing", err: ErrMissing, want: http.StatusPreconditionRequired},
+		{name: "when header is malformed", err: ErrInvalid, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorStatus(tt.err))
+		})
+	}
+}
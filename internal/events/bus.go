@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"sc-internacional/internal/tracing"
+	"sync"
+	"time"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/events")
+
+// Publisher announces changes. A write runs within Atomic and publishes its
+// event from there, with the ctx Atomic hands it: Bus dispatches the event
+// right away, and Outbox saves it in the same transaction as the write.
+type Publisher interface {
+	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
+	Publish(ctx context.Context, eventType Type, entityId string, previous, current interface{}) error
+}
+
+// Handler reacts to an event. Events may be delivered more than once, so
+// handlers must be idempotent.
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	handler Handler
+	types   map[Type]bool
+}
+
+// Bus hands events to the handlers subscribed to their type, in the order
+// they subscribed. Publish does it in the background, so that the request
+// causing an event does not wait for its handlers.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	inFlight      sync.WaitGroup
+	now           func() time.Time
+}
+
+func NewBus() *Bus {
+	return &Bus{now: time.Now}
+}
+
+// Subscribe registers handler for the given types, or for every event when
+// none is given.
+func (b *Bus) Subscribe(handler Handler, types ...Type) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var filter map[Type]bool
+	if len(types) > 0 {
+		filter = make(map[Type]bool, len(types))
+		for _, t := range types {
+			filter[t] = true
+		}
+	}
+
+	b.subscriptions = append(b.subscriptions, subscription{handler: handler, types: filter})
+}
+
+// Atomic runs fn. The bus keeps no events, so there is nothing to write
+// along with the change.
+func (b *Bus) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Publish dispatches, in the background, an event for a change that already
+// succeeded. Like the audit trail, failures are logged rather than returned;
+// without an outbox, an event a handler failed on is not retried.
+func (b *Bus) Publish(ctx context.Context, eventType Type, entityId string, previous, current interface{}) error {
+	event, err := newEvent(ctx, eventType, entityId, previous, current, b.now())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to encode event", "type", eventType, "entity_id", entityId, "error", err)
+		return nil
+	}
+
+	b.background(ctx, func(ctx context.Context) {
+		b.Dispatch(ctx, event)
+	})
+
+	return nil
+}
+
+// Wait blocks until the events being dispatched in the background are, for
+// a graceful shutdown.
+func (b *Bus) Wait() {
+	b.inFlight.Wait()
+}
+
+// background runs fn on its own goroutine with a context that outlives the
+// request ctx belongs to.
+func (b *Bus) background(ctx context.Context, fn func(ctx context.Context)) {
+	b.inFlight.Add(1)
+	go func() {
+		defer b.inFlight.Done()
+		fn(context.WithoutCancel(ctx))
+	}()
+}
+
+// Dispatch runs every handler subscribed to the event and returns their
+// errors joined. Handlers do not see the cancellation of the request that
+// caused the event, and a failing handler does not stop the others.
+func (b *Bus) Dispatch(ctx context.Context, event Event) error {
+	ctx, span := tracer.Start(context.WithoutCancel(ctx), "events.Bus.Dispatch", trace.WithAttributes(
+		attribute.String("event.type", string(event.Type)),
+		attribute.String("event.id", event.Id),
+	))
+	defer span.End()
+
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscriptions {
+		if s.types != nil && !s.types[event.Type] {
+			continue
+		}
+
+		if err := s.handler(ctx, event); err != nil {
+			tracing.Error(span, err)
+			slog.ErrorContext(ctx, "event handler failed", "type", event.Type, "event_id", event.Id, "entity_id", event.EntityId, "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type team struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestBus(t *testing.T) {
+	b := NewBus()
+	b.now = func() time.Time { return time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC) }
+
+	var matchEvents, allEvents []Type
+	b.Subscribe(func(ctx context.Context, event Event) error {
+		matchEvents = append(matchEvents, event.Type)
+		return errors.New("standings are unavailable")
+	}, MatchTypes...)
+	b.Subscribe(func(ctx context.Context, event Event) error {
+		allEvents = append(allEvents, event.Type)
+		return nil
+	})
+
+	b.Publish(context.Background(), TeamUpdated, "1", team{Id: "1", Name: "Internacional"}, team{Id: "1", Name: "Inter"})
+	b.Wait()
+	b.Publish(context.Background(), MatchFinished, "10", nil, nil)
+	b.Wait()
+
+	assert.Equal(t, []Type{MatchFinished}, matchEvents)
+	assert.Equal(t, []Type{TeamUpdated, MatchFinished}, allEvents, "a failing handler does not stop the others")
+
+	err := b.Dispatch(context.Background(), Event{Type: MatchFinished})
+	assert.EqualError(t, err, "standings are unavailable")
+	assert.NoError(t, b.Dispatch(context.Background(), Event{Type: TeamUpdated}))
+}
+
+func TestEvent_Decode(t *testing.T) {
+	event, err := newEvent(context.Background(), TeamUpdated, "1", team{Id: "1", Name: "Internacional"}, team{Id: "1", Name: "Inter"}, time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", event.EntityId)
+	assert.Equal(t, time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC), event.OccurredAt)
+	assert.NotEmpty(t, event.Id)
+
+	var current, previous team
+	assert.NoError(t, event.Decode(&current, &previous))
+	assert.Equal(t, team{Id: "1", Name: "Inter"}, current)
+	assert.Equal(t, team{Id: "1", Name: "Internacional"}, previous)
+
+	created, err := newEvent(context.Background(), TeamCreated, "1", nil, team{Id: "1", Name: "Inter"}, time.Now())
+	assert.NoError(t, err)
+	assert.Nil(t, created.Previous)
+
+	previous = team{}
+	assert.NoError(t, created.Decode(nil, &previous))
+	assert.Equal(t, team{}, previous, "a missing side is left untouched")
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config turns the Mongo outbox on or off and sets how often, and in batches
+// of how many events, undispatched events are relayed.
+type Config struct {
+	Outbox        bool          `env:"EVENTS_OUTBOX" envDefault:"true"`
+	RelayInterval time.Duration `env:"EVENTS_RELAY_INTERVAL" envDefault:"30s"`
+	RelayBatch    int64         `env:"EVENTS_RELAY_BATCH" envDefault:"100"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.RelayInterval <= 0 || cfg.RelayBatch <= 0 {
+		return nil, errors.New("EVENTS_RELAY_INTERVAL and EVENTS_RELAY_BATCH must be positive")
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,109 @@
+// Package events carries domain events from the services that cause them to
+// the parts of the API deriving data from them, such as standings.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sc-internacional/internal/logging"
+	"slices"
+	"time"
+)
+
+type Type string
+
+const (
+	TeamCreated  Type = "team.created"
+	TeamUpdated  Type = "team.updated"
+	TeamDeleted  Type = "team.deleted"
+	TeamRestored Type = "team.restored"
+	TeamPurged   Type = "team.purged"
+
+	ChampionshipCreated  Type = "championship.created"
+	ChampionshipUpdated  Type = "championship.updated"
+	ChampionshipDeleted  Type = "championship.deleted"
+	ChampionshipRestored Type = "championship.restored"
+	ChampionshipPurged   Type = "championship.purged"
+
+	// MatchScheduled, MatchStarted and MatchFinished record a new match,
+	// depending on whether it is still to be played, being played or over. A
+	// match moving on to one of the last two statuses announces it as well.
+	// MatchScoreChanged is a goal during play, MatchScoreCorrected an edit
+	// to the score of a finished match.
+	MatchScheduled      Type = "match.scheduled"
+	MatchStarted        Type = "match.started"
+	MatchFinished       Type = "match.finished"
+	MatchScoreChanged   Type = "match.score_changed"
+	MatchScoreCorrected Type = "match.score_corrected"
+	MatchUpdated        Type = "match.updated"
+	MatchDeleted        Type = "match.deleted"
+	MatchRestored       Type = "match.restored"
+	MatchPurged         Type = "match.purged"
+)
+
+// TeamTypes, ChampionshipTypes and MatchTypes list every event about one
+// kind of entity, for subscribers reacting to any of them.
+var (
+	TeamTypes         = []Type{TeamCreated, TeamUpdated, TeamDeleted, TeamRestored, TeamPurged}
+	ChampionshipTypes = []Type{ChampionshipCreated, ChampionshipUpdated, ChampionshipDeleted, ChampionshipRestored, ChampionshipPurged}
+	MatchTypes        = []Type{MatchScheduled, MatchStarted, MatchFinished, MatchScoreChanged, MatchScoreCorrected, MatchUpdated, MatchDeleted, MatchRestored, MatchPurged}
+)
+
+// Types lists every event the API publishes.
+var Types = slices.Concat(TeamTypes, ChampionshipTypes, MatchTypes)
+
+// Event is a change that already happened to an entity. Data holds the entity
+// after the change and Previous the entity before it, both encoded as the API
+// returns them, so that events read back from the outbox look the same as
+// freshly published ones.
+type Event struct {
+	Id         string          `json:"id" bson:"_id"`
+	Type       Type            `json:"type"`
+	EntityId   string          `json:"entityId"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	RequestId  string          `json:"requestId,omitempty" bson:",omitempty"`
+	Data       json.RawMessage `json:"data,omitempty" bson:",omitempty"`
+	Previous   json.RawMessage `json:"previous,omitempty" bson:",omitempty"`
+}
+
+func newEvent(ctx context.Context, eventType Type, entityId string, previous, current interface{}, now time.Time) (Event, error) {
+	event := Event{
+		Id:         primitive.NewObjectID().Hex(),
+		Type:       eventType,
+		EntityId:   entityId,
+		OccurredAt: now.UTC().Truncate(time.Millisecond),
+		RequestId:  logging.RequestID(ctx),
+	}
+
+	var err error
+	if current != nil {
+		if event.Data, err = json.Marshal(current); err != nil {
+			return Event{}, err
+		}
+	}
+	if previous != nil {
+		if event.Previous, err = json.Marshal(previous); err != nil {
+			return Event{}, err
+		}
+	}
+
+	return event, nil
+}
+
+// Decode reads Data into current and Previous into previous. Either may be
+// nil to skip it, and a side the event does not carry is left untouched.
+func (e Event) Decode(current, previous interface{}) error {
+	if current != nil && e.Data != nil {
+		if err := json.Unmarshal(e.Data, current); err != nil {
+			return err
+		}
+	}
+	if previous != nil && e.Previous != nil {
+		if err := json.Unmarshal(e.Previous, previous); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Store keeps events until they have been dispatched. Repository stores them
+// in Mongo; any other durable store can take its place.
+type Store interface {
+	Save(ctx context.Context, event Event) error
+	Pending(ctx context.Context, before time.Time, limit int64) ([]Event, error)
+	MarkDispatched(ctx context.Context, id string, at time.Time) error
+}
+
+// Transactor runs fn in a transaction of the store, so that what fn writes
+// with the ctx it is handed, events included, is kept all or nothing. fn may
+// run more than once when the transaction is retried.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Outbox saves every event in the transaction of the write it describes, so
+// that neither is kept without the other. Relay alone dispatches the saved
+// events, and marks them dispatched once every handler succeeded; the others
+// are dispatched again later, so subscribers see every event at least once.
+type Outbox struct {
+	store      Store
+	transactor Transactor
+	bus        *Bus
+	now        func() time.Time
+	saved      chan struct{}
+}
+
+func NewOutbox(store Store, transactor Transactor, bus *Bus) *Outbox {
+	return &Outbox{store: store, transactor: transactor, bus: bus, now: time.Now, saved: make(chan struct{}, 1)}
+}
+
+// Atomic runs fn in a transaction and, once it committed, wakes Relay up to
+// dispatch the events fn published.
+func (o *Outbox) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := o.transactor.Transaction(ctx, fn); err != nil {
+		return err
+	}
+
+	select {
+	case o.saved <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Publish saves an event to the outbox. Called with the ctx Atomic hands
+// over, the event is only kept if the write it describes is, and failing to
+// save it fails the write.
+func (o *Outbox) Publish(ctx context.Context, eventType Type, entityId string, previous, current interface{}) error {
+	event, err := newEvent(ctx, eventType, entityId, previous, current, o.now())
+	if err != nil {
+		return err
+	}
+
+	return o.store.Save(ctx, event)
+}
+
+// Relay dispatches the saved events never marked dispatched, up to batch at
+// a time, whenever Atomic committed some and every interval, until ctx is
+// done.
+func (o *Outbox) Relay(ctx context.Context, interval time.Duration, batch int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		o.relay(ctx, o.now(), batch)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.saved:
+		}
+	}
+}
+
+func (o *Outbox) relay(ctx context.Context, before time.Time, batch int64) {
+	ctx, span := tracer.Start(ctx, "events.Outbox.relay")
+	defer span.End()
+
+	pending, err := o.store.Pending(ctx, before, batch)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read pending events from the outbox", "error", err)
+		return
+	}
+
+	// Events a handler failed on stay pending, for the next round.
+	for _, event := range pending {
+		if err := o.bus.Dispatch(ctx, event); err != nil {
+			continue
+		}
+		o.markDispatched(ctx, event)
+	}
+}
+
+func (o *Outbox) markDispatched(ctx context.Context, event Event) {
+	if err := o.store.MarkDispatched(ctx, event.Id, o.now().UTC()); err != nil {
+		slog.ErrorContext(ctx, "failed to mark event dispatched", "type", event.Type, "event_id", event.Id, "error", err)
+	}
+}
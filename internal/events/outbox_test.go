@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestOutbox_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		saveErr error
+	}{
+		{name: "when event is saved"},
+		{name: "when event cannot be saved", saveErr: errors.New("failed to save event")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &storeMock{}
+			s.On("Save", mock.Anything, mock.Anything).Return(tt.saveErr)
+
+			b := NewBus()
+			dispatched := 0
+			b.Subscribe(func(ctx context.Context, event Event) error {
+				dispatched++
+				return nil
+			})
+
+			o := NewOutbox(s, transactorMock{}, b)
+
+			err := o.Publish(context.Background(), MatchFinished, "10", nil, nil)
+			b.Wait()
+
+			assert.Equal(t, tt.saveErr, err, "the write fails along with its event")
+			assert.Zero(t, dispatched, "only the relay dispatches saved events")
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOutbox_Atomic(t *testing.T) {
+	tests := []struct {
+		name      string
+		fnErr     error
+		wantWoken bool
+	}{
+		{name: "when the transaction commits", wantWoken: true},
+		{name: "when the transaction aborts", fnErr: errors.New("failed to update match")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewOutbox(&storeMock{}, transactorMock{}, NewBus())
+
+			err := o.Atomic(context.Background(), func(ctx context.Context) error {
+				assert.Equal(t, "tx", ctx.Value(transactionKey{}), "fn runs within the transaction")
+				return tt.fnErr
+			})
+
+			assert.Equal(t, tt.fnErr, err)
+			assert.Equal(t, tt.wantWoken, len(o.saved) == 1, "the relay is woken up once the events are committed")
+		})
+	}
+}
+
+func TestOutbox_relay(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)
+	pending := []Event{
+		{Id: "1", Type: MatchFinished, EntityId: "10"},
+		{Id: "2", Type: MatchScoreCorrected, EntityId: "10"},
+	}
+
+	s := &storeMock{}
+	s.On("Pending", mock.Anything, now.Add(-time.Minute), int64(100)).Return(pending, nil)
+	s.On("MarkDispatched", mock.Anything, "1", now).Return(nil)
+
+	b := NewBus()
+	var dispatched []Event
+	b.Subscribe(func(ctx context.Context, event Event) error {
+		dispatched = append(dispatched, event)
+		if event.Id == "2" {
+			return errors.New("standings are unavailable")
+		}
+		return nil
+	})
+
+	o := NewOutbox(s, transactorMock{}, b)
+	o.now = func() time.Time { return now }
+
+	o.relay(context.Background(), now.Add(-time.Minute), 100)
+
+	assert.Equal(t, pending, dispatched)
+	s.AssertExpectations(t)
+	s.AssertNotCalled(t, "MarkDispatched", mock.Anything, "2", mock.Anything)
+}
+
+type storeMock struct {
+	Store
+	mock.Mock
+}
+
+func (m *storeMock) Save(ctx context.Context, event Event) error {
+	args := m.Called(ctx, event)
+
+	return args.Error(0)
+}
+
+func (m *storeMock) Pending(ctx context.Context, before time.Time, limit int64) ([]Event, error) {
+	args := m.Called(ctx, before, limit)
+
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *storeMock) MarkDispatched(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+
+	return args.Error(0)
+}
+
+type transactionKey struct{}
+
+// transactorMock runs fn with a ctx telling it is in a transaction.
+type transactorMock struct{}
+
+func (transactorMock) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, transactionKey{}, "tx"))
+}
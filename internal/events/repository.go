@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+type db interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+}
+
+// Repository is the outbox Store backed by a Mongo collection. Dispatched
+// events stay in it, marked with dispatchedat.
+type Repository struct {
+	db
+}
+
+func NewRepository(db db) *Repository {
+	return &Repository{db}
+}
+
+func (r Repository) Save(ctx context.Context, event Event) error {
+	ctx, span := tracer.Start(ctx, "events.Repository.Save")
+	defer span.End()
+
+	if _, err := r.db.InsertOne(ctx, event); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// Pending returns the oldest events that occurred before the given time and
+// were never dispatched.
+func (r Repository) Pending(ctx context.Context, before time.Time, limit int64) ([]Event, error) {
+	ctx, span := tracer.Start(ctx, "events.Repository.Pending")
+	defer span.End()
+
+	filter := bson.M{"dispatchedat": nil, "occurredat": bson.M{"$lt": before}}
+	opts := options.Find().SetSort(bson.D{{Key: "occurredat", Value: 1}}).SetLimit(limit)
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []Event{}
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return events, nil
+}
+
+func (r Repository) MarkDispatched(ctx context.Context, id string, at time.Time) error {
+	ctx, span := tracer.Start(ctx, "events.Repository.MarkDispatched")
+	defer span.End()
+
+	if _, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"dispatchedat": at}}); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
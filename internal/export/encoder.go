@@ -0,0 +1,151 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// flushEvery is how many records are written between flushes to the client.
+const flushEvery = 100
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Encoder writes records to an HTTP response one at a time, so callers can
+// stream straight from a database cursor. Nothing is sent until the first
+// record is encoded or the encoder is closed, which lets callers still reply
+// with an error status when the stream fails before producing any data.
+type Encoder struct {
+	w        http.ResponseWriter
+	format   Format
+	filename string
+	header   []string
+	started  bool
+	count    int
+	csv      *csv.Writer
+	json     *json.Encoder
+}
+
+func NewEncoder(w http.ResponseWriter, format Format, filename string, header []string) *Encoder {
+	return &Encoder{w: w, format: format, filename: filename, header: header}
+}
+
+// Encode writes a single record. value is used by the JSON formats and record
+// by the CSV formats, in the same column order as the header.
+func (e *Encoder) Encode(value interface{}, record []string) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	var err error
+	switch e.format {
+	case CSV, Excel:
+		err = e.csv.Write(record)
+	case NDJSON:
+		err = e.json.Encode(value)
+	default:
+		if e.count > 0 {
+			if _, err = e.w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		err = e.json.Encode(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.count++
+	if e.count%flushEvery == 0 {
+		e.flush()
+	}
+
+	return nil
+}
+
+// Close terminates the document and flushes whatever is still buffered.
+func (e *Encoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if e.format == JSON {
+		if _, err := e.w.Write([]byte("]")); err != nil {
+			return err
+		}
+	}
+
+	return e.flush()
+}
+
+func (e *Encoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	e.w.Header().Set("Content-Type", e.contentType())
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.filename+e.extension()))
+	e.w.WriteHeader(http.StatusOK)
+
+	switch e.format {
+	case CSV, Excel:
+		if e.format == Excel {
+			if _, err := e.w.Write(utf8BOM); err != nil {
+				return err
+			}
+		}
+		e.csv = csv.NewWriter(e.w)
+		e.csv.UseCRLF = e.format == Excel
+		return e.csv.Write(e.header)
+	case NDJSON:
+		e.json = json.NewEncoder(e.w)
+	default:
+		e.json = json.NewEncoder(e.w)
+		_, err := e.w.Write([]byte("["))
+		return err
+	}
+
+	return nil
+}
+
+func (e *Encoder) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	if f, ok := e.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+func (e *Encoder) contentType() string {
+	switch e.format {
+	case CSV:
+		return MIMECSV + "; charset=utf-8"
+	case Excel:
+		// Excel reads the CSV as UTF-8 thanks to the byte order mark.
+		return MIMEExcel
+	case NDJSON:
+		return MIMENDJSON
+	default:
+		return MIMEJSON + "; charset=utf-8"
+	}
+}
+
+func (e *Encoder) extension() string {
+	switch e.format {
+	case CSV, Excel:
+		return ".csv"
+	case NDJSON:
+		return ".ndjson"
+	default:
+		return ".json"
+	}
+}
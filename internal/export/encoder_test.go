@@ -0,0 +1,92 @@
+package export
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type row struct {
+	Name  string `json:"name"`
+	Title int    `json:"titles"`
+}
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name                string
+		format              Format
+		rows                []row
+		expectedContentType string
+		expectedDisposition string
+		expectedBody        string
+	}{
+		{
+			name:                "when format is json",
+			format:              JSON,
+			rows:                []row{{Name: "Internacional", Title: 3}, {Name: "Grêmio", Title: 2}},
+			expectedContentType: "application/json; charset=utf-8",
+			expectedDisposition: "attachment; filename=\"teams.json\"",
+			expectedBody:        "[{\"name\":\"Internacional\",\"titles\":3}\n,{\"name\":\"Grêmio\",\"titles\":2}\n]",
+		},
+		{
+			name:                "when format is json and there are no rows",
+			format:              JSON,
+			rows:                []row{},
+			expectedContentType: "application/json; charset=utf-8",
+			expectedDisposition: "attachment; filename=\"teams.json\"",
+			expectedBody:        "[]",
+		},
+		{
+			name:                "when format is ndjson",
+			format:              NDJSON,
+			rows:                []row{{Name: "Internacional", Title: 3}, {Name: "Grêmio", Title: 2}},
+			expectedContentType: "application/x-ndjson",
+			expectedDisposition: "attachment; filename=\"teams.ndjson\"",
+			expectedBody:        "{\"name\":\"Internacional\",\"titles\":3}\n{\"name\":\"Grêmio\",\"titles\":2}\n",
+		},
+		{
+			name:                "when format is csv",
+			format:              CSV,
+			rows:                []row{{Name: "Internacional", Title: 3}, {Name: "Grêmio", Title: 2}},
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedDisposition: "attachment; filename=\"teams.csv\"",
+			expectedBody:        "name,titles\nInternacional,3\nGrêmio,2\n",
+		},
+		{
+			name:                "when format is excel",
+			format:              Excel,
+			rows:                []row{{Name: "Internacional", Title: 3}},
+			expectedContentType: "application/vnd.ms-excel",
+			expectedDisposition: "attachment; filename=\"teams.csv\"",
+			expectedBody:        "\xef\xbb\xbfname,titles\r\nInternacional,3\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			e := NewEncoder(recorder, tt.format, "teams", []string{"name", "titles"})
+			for _, r := range tt.rows {
+				assert.NoError(t, e.Encode(r, []string{r.Name, strconv.Itoa(r.Title)}))
+			}
+			assert.NoError(t, e.Close())
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tt.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedDisposition, recorder.Header().Get("Content-Disposition"))
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestEncoder_NothingWrittenBeforeFirstRecord(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	NewEncoder(recorder, CSV, "teams", []string{"name"})
+
+	assert.False(t, recorder.Flushed)
+	assert.Empty(t, recorder.Header().Get("Content-Type"))
+	assert.Empty(t, recorder.Body.String())
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+)
+
+type Format string
+
+const (
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+	Excel  Format = "excel"
+)
+
+const (
+	MIMEJSON   = "application/json"
+	MIMENDJSON = "application/x-ndjson"
+	MIMECSV    = "text/csv"
+	MIMEExcel  = "application/vnd.ms-excel"
+)
+
+var ErrNotAcceptable = errors.New("export format not acceptable, use json, ndjson, csv or excel")
+
+var formatsByMIME = map[string]Format{
+	MIMEJSON:   JSON,
+	MIMENDJSON: NDJSON,
+	MIMECSV:    CSV,
+	MIMEExcel:  Excel,
+}
+
+// NegotiateFormat picks the export format from the "format" query parameter,
+// falling back to the Accept header and then to JSON.
+func NegotiateFormat(ctx *gin.Context) (Format, error) {
+	if format := ctx.Query("format"); format != "" {
+		switch f := Format(format); f {
+		case JSON, NDJSON, CSV, Excel:
+			return f, nil
+		}
+		return "", ErrNotAcceptable
+	}
+
+	mime := ctx.NegotiateFormat(MIMEJSON, MIMENDJSON, MIMECSV, MIMEExcel)
+	if mime == "" {
+		return "", ErrNotAcceptable
+	}
+
+	return formatsByMIME[mime], nil
+}
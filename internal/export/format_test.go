@@ -0,0 +1,43 @@
+package export
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		accept  string
+		want    Format
+		wantErr error
+	}{
+		{name: "when nothing is requested", want: JSON},
+		{name: "when accept is any", accept: "*/*", want: JSON},
+		{name: "when accept is csv", accept: "text/csv", want: CSV},
+		{name: "when accept is ndjson", accept: "application/x-ndjson", want: NDJSON},
+		{name: "when accept is excel", accept: "application/vnd.ms-excel", want: Excel},
+		{name: "when accept is not supported", accept: "application/xml", wantErr: ErrNotAcceptable},
+		{name: "when format query overrides accept", query: "format=ndjson", accept: "text/csv", want: NDJSON},
+		{name: "when format query is not supported", query: "format=xml", wantErr: ErrNotAcceptable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = &http.Request{Header: make(http.Header), URL: &url.URL{RawQuery: tt.query}}
+			if tt.accept != "" {
+				ctx.Request.Header.Set("Accept", tt.accept)
+			}
+
+			got, err := NegotiateFormat(ctx)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
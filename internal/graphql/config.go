@@ -0,0 +1,25 @@
+package graphql
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+)
+
+// Config bounds the queries the endpoint runs. Depth counts nested fields,
+// and complexity counts every field once, with the fields below a list
+// counted listFactor times.
+type Config struct {
+	MaxDepth      int `env:"GRAPHQL_MAX_DEPTH" envDefault:"7"`
+	MaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" envDefault:"1000"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.MaxDepth < 1 || cfg.MaxComplexity < 1 {
+		return nil, errors.New("GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY must be positive")
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,110 @@
+// Package graphql serves teams, championships, matches and standings over
+// GraphQL, so that clients can fetch nested data in one round-trip.
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"net/http"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+)
+
+type request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Controller struct {
+	schema        graphql.Schema
+	config        *Config
+	teams         teamService
+	championships championshipService
+	matches       matchService
+	standings     standingService
+}
+
+func NewController(teams teamService, championships championshipService, matches matchService, standings standingService, config *Config) (*Controller, error) {
+	schema, err := newSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{schema: schema, config: config, teams: teams, championships: championships, matches: matches, standings: standings}, nil
+}
+
+// Query runs a GraphQL query sent as JSON in a POST body, or in the query,
+// operationName and variables parameters of a GET. Requests that cannot run
+// answer 400, or 413 past the body size limit; errors while resolving fields
+// come back next to the data.
+func (c Controller) Query(ctx *gin.Context) {
+	req, err := bindRequest(ctx)
+	if err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorsResponse(ctx, err))
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorsResponse(ctx, err))
+		return
+	}
+
+	if validation := graphql.ValidateDocument(&c.schema, document, nil); !validation.IsValid {
+		ctx.JSON(http.StatusBadRequest, &graphql.Result{Errors: validation.Errors})
+		return
+	}
+
+	if err = checkLimits(&c.schema, document, req.OperationName, c.config); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorsResponse(ctx, err))
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        c.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx.Request.Context(), c.newLoaders()),
+	})
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func bindRequest(ctx *gin.Context) (request, error) {
+	var req request
+	if ctx.Request.Method == http.MethodGet {
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			return request{}, err
+		}
+		if variables := ctx.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return request{}, errors.New("variables must be a JSON object")
+			}
+		}
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		return request{}, err
+	}
+
+	if req.Query == "" {
+		return request{}, errors.New("query is required")
+	}
+
+	return req, nil
+}
+
+// errorsResponse reports err in the language of the request. Errors left
+// untranslated, such as syntax errors, keep their locations in the query.
+func errorsResponse(ctx *gin.Context, err error) *graphql.Result {
+	if message := i18n.Message(ctx.Request.Context(), err); message != err.Error() {
+		err = errors.New(message)
+	}
+
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}}
+}
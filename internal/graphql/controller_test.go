@@ -0,0 +1,202 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/standings"
+	"sc-internacional/internal/teams"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	internacional = teams.Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "https://internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1}
+	barcelona     = teams.Team{Id: "2", Name: "Barcelona", FullName: "Futbol Club Barcelona", Website: "https://fcbarcelona.com", FoundationDate: time.Date(1899, time.November, 29, 0, 0, 0, 0, time.UTC), Version: 1}
+	worldCup      = championships.Championship{Id: "3", Name: "FIFA Club World Cup", Season: "2006", Teams: []teams.Team{{Id: "1"}, {Id: "2"}}, Version: 1}
+	final         = matches.Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", TeamHomeScore: 1, MatchDate: time.Date(2006, time.December, 17, 8, 30, 0, 0, time.UTC), ChampionshipId: "3", Version: 1}
+	semiFinal     = matches.Match{Id: "11", TeamHomeId: "1", TeamAwayId: "4", TeamHomeName: "Internacional", TeamAwayName: "Al Ahly", TeamHomeScore: 2, TeamAwayScore: 1, MatchDate: time.Date(2006, time.December, 13, 8, 30, 0, 0, time.UTC), ChampionshipId: "3", Version: 1}
+)
+
+func TestController_Query(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*teamServiceMock, *championshipServiceMock, *matchServiceMock, *standingServiceMock)
+		method             string
+		body               string
+		query              url.Values
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when there is no query",
+			setup:              func(*teamServiceMock, *championshipServiceMock, *matchServiceMock, *standingServiceMock) {},
+			method:             http.MethodPost,
+			body:               "{}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"data\":null,\"errors\":[{\"message\":\"query is required\",\"locations\":[]}]}",
+		},
+		{
+			name:               "when the body is too large",
+			setup:              func(*teamServiceMock, *championshipServiceMock, *matchServiceMock, *standingServiceMock) {},
+			method:             http.MethodPost,
+			body:               "{\"query\": \"{ teams { " + strings.Repeat("name ", 300) + "} }\"}",
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedBody:       "{\"data\":null,\"errors\":[{\"message\":\"request body is too large\",\"locations\":[]}]}",
+		},
+		{
+			name:               "when the query asks for an unknown field",
+			setup:              func(*teamServiceMock, *championshipServiceMock, *matchServiceMock, *standingServiceMock) {},
+			method:             http.MethodPost,
+			body:               "{\"query\": \"{ team(id: \\\"1\\\") { nickname } }\"}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"data\":null,\"errors\":[{\"message\":\"Cannot query field \\\"nickname\\\" on type \\\"Team\\\". Did you mean \\\"nicknames\\\" or \\\"name\\\"?\",\"locations\":[{\"line\":1,\"column\":19}]}]}",
+		},
+		{
+			name:               "when the query is too deep",
+			setup:              func(*teamServiceMock, *championshipServiceMock, *matchServiceMock, *standingServiceMock) {},
+			method:             http.MethodPost,
+			body:               "{\"query\": \"{ team(id: \\\"1\\\") { matches { championship { teams { matches { homeTeam { matches { id } } } } } } } }\"}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"data\":null,\"errors\":[{\"message\":\"query depth 8 exceeds the limit of 7\",\"locations\":[]}]}",
+		},
+		{
+			name:               "when the query is too complex",
+			setup:              func(*teamServiceMock, *championshipServiceMock, *matchServiceMock, *standingServiceMock) {},
+			method:             http.MethodPost,
+			body:               "{\"query\": \"{ teams { matches { homeTeam { matches { id } } } } }\"}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"data\":null,\"errors\":[{\"message\":\"query complexity 1211 exceeds the limit of 1000\",\"locations\":[]}]}",
+		},
+		{
+			name: "when a field fails to resolve",
+			setup: func(ts *teamServiceMock, cs *championshipServiceMock, ms *matchServiceMock, ss *standingServiceMock) {
+				ts.On("GetTeams", mock.Anything, []string{"1"}).Return([]teams.Team{}, errors.New("failed to get teams"))
+			},
+			method:             http.MethodPost,
+			body:               "{\"query\": \"{ team(id: \\\"1\\\") { name } }\"}",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"data\":{\"team\":null},\"errors\":[{\"message\":\"failed to get teams\",\"locations\":[{\"line\":1,\"column\":3}],\"path\":[\"team\"]}]}",
+		},
+		{
+			name: "when a query is sent with GET",
+			setup: func(ts *teamServiceMock, cs *championshipServiceMock, ms *matchServiceMock, ss *standingServiceMock) {
+				ts.On("GetTeams", mock.Anything, []string{"1"}).Return([]teams.Team{internacional}, nil)
+			},
+			method:             http.MethodGet,
+			query:              url.Values{"query": {"query Team($id: ID!) { team(id: $id) { name foundationDate } }"}, "variables": {"{\"id\": \"1\"}"}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"data\":{\"team\":{\"foundationDate\":\"1909-04-04T00:00:00Z\",\"name\":\"Internacional\"}}}",
+		},
+		{
+			name: "when a championship is read with its teams, matches and standings",
+			setup: func(ts *teamServiceMock, cs *championshipServiceMock, ms *matchServiceMock, ss *standingServiceMock) {
+				cs.On("GetChampionships", mock.Anything, []string{"3"}).Return([]championships.Championship{worldCup}, nil).Once()
+				ms.On("GetMatchesByChampionships", mock.Anything, []string{"3"}).Return([]matches.Match{semiFinal, final}, nil).Once()
+				ss.On("GetStandings", mock.Anything, []string{"3"}).Return([]standings.Standing{{ChampionshipId: "3", TeamId: "1", TeamName: "Internacional", Played: 2, Won: 2, Points: 6}}, nil).Once()
+				// Teams are read once per level of the query at most, and never
+				// twice. Which level reads them depends on the order in which
+				// sibling fields resolve.
+				ts.On("GetTeams", mock.Anything, mock.Anything).Return([]teams.Team{internacional, barcelona}, nil).Once()
+				ts.On("GetTeams", mock.Anything, mock.Anything).Return([]teams.Team{}, nil).Maybe()
+			},
+			method:             http.MethodPost,
+			body:               "{\"query\": \"{ championship(id: \\\"3\\\") { name teams { name } matches { homeTeam { name } awayTeam { name } homeScore awayScore } standings { team { name } points } } }\"}",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "{\"data\":{\"championship\":{\"matches\":[" +
+				"{\"awayScore\":1,\"awayTeam\":null,\"homeScore\":2,\"homeTeam\":{\"name\":\"Internacional\"}}," +
+				"{\"awayScore\":0,\"awayTeam\":{\"name\":\"Barcelona\"},\"homeScore\":1,\"homeTeam\":{\"name\":\"Internacional\"}}]," +
+				"\"name\":\"FIFA Club World Cup\",\"standings\":[{\"points\":6,\"team\":{\"name\":\"Internacional\"}}]," +
+				"\"teams\":[{\"name\":\"Internacional\"},{\"name\":\"Barcelona\"}]}}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, cs, ms, ss := &teamServiceMock{}, &championshipServiceMock{}, &matchServiceMock{}, &standingServiceMock{}
+			tt.setup(ts, cs, ms, ss)
+
+			c, err := NewController(ts, cs, ms, ss, &Config{MaxDepth: 7, MaxComplexity: 1000})
+			assert.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(tt.method, "/graphql?"+tt.query.Encode(), strings.NewReader(tt.body))
+			ctx.Request.Body = http.MaxBytesReader(recorder, ctx.Request.Body, 1024)
+
+			c.Query(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			ts.AssertExpectations(t)
+			var read []string
+			for _, call := range ts.Calls {
+				read = append(read, call.Arguments.Get(1).([]string)...)
+			}
+			assert.Len(t, read, len(uniq(read)))
+			cs.AssertExpectations(t)
+			ms.AssertExpectations(t)
+			ss.AssertExpectations(t)
+		})
+	}
+}
+
+func uniq(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
+
+type teamServiceMock struct {
+	teamService
+	mock.Mock
+}
+
+func (m *teamServiceMock) GetTeams(ctx context.Context, ids []string) ([]teams.Team, error) {
+	args := m.Called(ctx, ids)
+
+	return args.Get(0).([]teams.Team), args.Error(1)
+}
+
+type championshipServiceMock struct {
+	championshipService
+	mock.Mock
+}
+
+func (m *championshipServiceMock) GetChampionships(ctx context.Context, ids []string) ([]championships.Championship, error) {
+	args := m.Called(ctx, ids)
+
+	return args.Get(0).([]championships.Championship), args.Error(1)
+}
+
+type matchServiceMock struct {
+	matchService
+	mock.Mock
+}
+
+func (m *matchServiceMock) GetMatchesByChampionships(ctx context.Context, championshipIds []string) ([]matches.Match, error) {
+	args := m.Called(ctx, championshipIds)
+
+	return args.Get(0).([]matches.Match), args.Error(1)
+}
+
+type standingServiceMock struct {
+	standingService
+	mock.Mock
+}
+
+func (m *standingServiceMock) GetStandings(ctx context.Context, championshipIds []string) ([]standings.Standing, error) {
+	args := m.Called(ctx, championshipIds)
+
+	return args.Get(0).([]standings.Standing), args.Error(1)
+}
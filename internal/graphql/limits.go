@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"sc-internacional/internal/i18n"
+	"strings"
+)
+
+// listFactor is how many items a list field is assumed to hold when
+// estimating the complexity of a query.
+const listFactor = 10
+
+// checkLimits rejects the operation to run when it nests fields deeper than
+// MaxDepth or its estimated complexity exceeds MaxComplexity. The document
+// must be valid. Introspection fields are not counted, so that tools can
+// always read the schema.
+func checkLimits(schema *graphql.Schema, document *ast.Document, operationName string, config *Config) error {
+	fragments := map[string]*ast.FragmentDefinition{}
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operation = d
+			}
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	m := measurer{schema: schema, fragments: fragments}
+	depth, complexity := m.measure(operation.SelectionSet, schema.QueryType(), 1)
+	if depth > config.MaxDepth {
+		return i18n.Errorf("query depth %d exceeds the limit of %d", depth, config.MaxDepth)
+	}
+	if complexity > config.MaxComplexity {
+		return i18n.Errorf("query complexity %d exceeds the limit of %d", complexity, config.MaxComplexity)
+	}
+
+	return nil
+}
+
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// measure returns the depth and complexity of the selections made on parent
+// at the given depth.
+func (m measurer) measure(selections *ast.SelectionSet, parent graphql.Type, depth int) (int, int) {
+	if selections == nil {
+		return 0, 0
+	}
+
+	maxDepth, complexity := 0, 0
+	for _, selection := range selections.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, c = m.measureField(s, parent, depth)
+		case *ast.InlineFragment:
+			on := parent
+			if s.TypeCondition != nil {
+				on = m.schema.Type(s.TypeCondition.Name.Value)
+			}
+			d, c = m.measure(s.SelectionSet, on, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := m.fragments[s.Name.Value]; ok {
+				d, c = m.measure(fragment.SelectionSet, m.schema.Type(fragment.TypeCondition.Name.Value), depth)
+			}
+		}
+		maxDepth = max(maxDepth, d)
+		complexity += c
+	}
+
+	return maxDepth, complexity
+}
+
+func (m measurer) measureField(field *ast.Field, parent graphql.Type, depth int) (int, int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return depth, 1
+	}
+	definition, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return depth, 1
+	}
+
+	fieldType, isList := definition.Type, false
+	for {
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		} else if list, ok := fieldType.(*graphql.List); ok {
+			fieldType, isList = list.OfType, true
+		} else {
+			break
+		}
+	}
+
+	childDepth, childComplexity := m.measure(field.SelectionSet, fieldType, depth+1)
+	if isList {
+		childComplexity *= listFactor
+	}
+
+	return max(depth, childDepth), 1 + childComplexity
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// loader batches the keys requested while a query resolves into one fetch.
+// Resolvers return the thunk load gives them, and graphql-go only runs thunks
+// once every sibling field was resolved, so the first thunk to run fetches
+// the keys of all of them: one fetch per level of the query rather than one
+// per key. Results are kept for the rest of the request.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	pending map[string]bool
+	loaded  map[string]bool
+	values  map[string]V
+	errs    map[string]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, pending: map[string]bool{}, loaded: map[string]bool{}, values: map[string]V{}, errs: map[string]error{}}
+}
+
+// load returns a thunk resolving to the value for key, or to nil when fetch
+// found none.
+func (l *loader[V]) load(ctx context.Context, key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.loaded[key] {
+		l.pending[key] = true
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if l.pending[key] {
+			l.flush(ctx)
+		}
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		if value, ok := l.values[key]; ok {
+			return value, nil
+		}
+
+		return nil, nil
+	}
+}
+
+func (l *loader[V]) flush(ctx context.Context) {
+	keys := make([]string, 0, len(l.pending))
+	for key := range l.pending {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	l.pending = map[string]bool{}
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		l.loaded[key] = true
+		if err != nil {
+			l.errs[key] = err
+		} else if value, ok := values[key]; ok {
+			l.values[key] = value
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoader(t *testing.T) {
+	var fetched [][]string
+	l := newLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		fetched = append(fetched, keys)
+		if len(keys) == 1 && keys[0] == "broken" {
+			return nil, errors.New("failed to fetch")
+		}
+		return map[string]int{"a": 1, "b": 2}, nil
+	})
+	ctx := context.Background()
+
+	a, b, missing, again := l.load(ctx, "a"), l.load(ctx, "b"), l.load(ctx, "z"), l.load(ctx, "a")
+
+	value, err := b()
+	assert.Equal(t, 2, value)
+	assert.NoError(t, err)
+	value, err = a()
+	assert.Equal(t, 1, value)
+	assert.NoError(t, err)
+	value, err = missing()
+	assert.Nil(t, value)
+	assert.NoError(t, err)
+	value, err = again()
+	assert.Equal(t, 1, value)
+	assert.NoError(t, err)
+
+	value, err = l.load(ctx, "z")()
+	assert.Nil(t, value)
+	assert.NoError(t, err)
+
+	_, err = l.load(ctx, "broken")()
+	assert.EqualError(t, err, "failed to fetch")
+
+	assert.Equal(t, [][]string{{"a", "b", "z"}, {"broken"}}, fetched)
+}
@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"context"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/standings"
+	"sc-internacional/internal/teams"
+)
+
+type teamService interface {
+	ListTeams(ctx context.Context) ([]teams.Team, error)
+	GetTeams(ctx context.Context, ids []string) ([]teams.Team, error)
+}
+
+type championshipService interface {
+	GetChampionships(ctx context.Context, ids []string) ([]championships.Championship, error)
+	GetChampionshipsByTeams(ctx context.Context, teamIds []string) ([]championships.Championship, error)
+}
+
+type matchService interface {
+	GetMatches(ctx context.Context, ids []string) ([]matches.Match, error)
+	GetMatchesByChampionships(ctx context.Context, championshipIds []string) ([]matches.Match, error)
+	GetMatchesByTeams(ctx context.Context, teamIds []string) ([]matches.Match, error)
+}
+
+type standingService interface {
+	GetStandings(ctx context.Context, championshipIds []string) ([]standings.Standing, error)
+}
+
+// loaders are the batching loaders of one request, along with the listing
+// of every team, which has nothing to batch.
+type loaders struct {
+	allTeams              func(ctx context.Context) ([]teams.Team, error)
+	teams                 *loader[teams.Team]
+	championships         *loader[championships.Championship]
+	championshipsByTeam   *loader[[]championships.Championship]
+	matches               *loader[matches.Match]
+	matchesByChampionship *loader[[]matches.Match]
+	matchesByTeam         *loader[[]matches.Match]
+	standings             *loader[[]standings.Standing]
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (c Controller) newLoaders() *loaders {
+	return &loaders{
+		allTeams: c.teams.ListTeams,
+		teams: newLoader(func(ctx context.Context, ids []string) (map[string]teams.Team, error) {
+			found, err := c.teams.GetTeams(ctx, ids)
+			return byId(found, func(t teams.Team) string { return t.Id }), err
+		}),
+		championships: newLoader(func(ctx context.Context, ids []string) (map[string]championships.Championship, error) {
+			found, err := c.championships.GetChampionships(ctx, ids)
+			return byId(found, func(championship championships.Championship) string { return championship.Id }), err
+		}),
+		championshipsByTeam: newLoader(func(ctx context.Context, teamIds []string) (map[string][]championships.Championship, error) {
+			found, err := c.championships.GetChampionshipsByTeams(ctx, teamIds)
+			return groupBy(teamIds, found, func(championship championships.Championship) []string {
+				ids := make([]string, 0, len(championship.Teams))
+				for _, team := range championship.Teams {
+					ids = append(ids, team.Id)
+				}
+				return ids
+			}), err
+		}),
+		matches: newLoader(func(ctx context.Context, ids []string) (map[string]matches.Match, error) {
+			found, err := c.matches.GetMatches(ctx, ids)
+			return byId(found, func(m matches.Match) string { return m.Id }), err
+		}),
+		matchesByChampionship: newLoader(func(ctx context.Context, championshipIds []string) (map[string][]matches.Match, error) {
+			found, err := c.matches.GetMatchesByChampionships(ctx, championshipIds)
+			return groupBy(championshipIds, found, func(m matches.Match) []string { return []string{m.ChampionshipId} }), err
+		}),
+		matchesByTeam: newLoader(func(ctx context.Context, teamIds []string) (map[string][]matches.Match, error) {
+			found, err := c.matches.GetMatchesByTeams(ctx, teamIds)
+			return groupBy(teamIds, found, func(m matches.Match) []string { return []string{m.TeamHomeId, m.TeamAwayId} }), err
+		}),
+		standings: newLoader(func(ctx context.Context, championshipIds []string) (map[string][]standings.Standing, error) {
+			found, err := c.standings.GetStandings(ctx, championshipIds)
+			return groupBy(championshipIds, found, func(standing standings.Standing) []string { return []string{standing.ChampionshipId} }), err
+		}),
+	}
+}
+
+func byId[V any](values []V, id func(V) string) map[string]V {
+	result := make(map[string]V, len(values))
+	for _, value := range values {
+		result[id(value)] = value
+	}
+
+	return result
+}
+
+// groupBy files each value under the keys it belongs to, keeping their
+// order, and gives every key a list even when no value belongs to it.
+func groupBy[V any](keys []string, values []V, keysOf func(V) []string) map[string][]V {
+	result := make(map[string][]V, len(keys))
+	for _, key := range keys {
+		result[key] = []V{}
+	}
+	for _, value := range values {
+		for _, key := range keysOf(value) {
+			if list, ok := result[key]; ok {
+				result[key] = append(list, value)
+			}
+		}
+	}
+
+	return result
+}
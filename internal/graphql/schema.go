@@ -0,0 +1,252 @@
+package graphql
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/standings"
+	"sc-internacional/internal/teams"
+	"time"
+)
+
+// newSchema describes teams, championships, matches and standings. Fields
+// named as in the REST API resolve through the JSON tags of the entities;
+// references to other entities go through the request's loaders.
+func newSchema() (graphql.Schema, error) {
+	var teamType, championshipType, matchType *graphql.Object
+
+	nonNullList := func(t graphql.Type) graphql.Output {
+		return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+	}
+
+	teamType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Team",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             {Type: graphql.NewNonNull(graphql.ID)},
+				"name":           {Type: graphql.NewNonNull(graphql.String)},
+				"fullName":       {Type: graphql.NewNonNull(graphql.String)},
+				"website":        {Type: graphql.NewNonNull(graphql.String)},
+				"foundationDate": {Type: graphql.NewNonNull(graphql.DateTime)},
+				"nicknames": {
+					Type: nonNullList(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if nicknames := p.Source.(teams.Team).Nicknames; nicknames != nil {
+							return nicknames, nil
+						}
+						return []string{}, nil
+					},
+				},
+				"displayName": {
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						team := p.Source.(teams.Team)
+						return i18n.Name(i18n.FromContext(p.Context), team.Name, team.Names), nil
+					},
+				},
+				"version": {Type: graphql.NewNonNull(graphql.Int)},
+				"championships": {
+					Type: nonNullList(championshipType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).championshipsByTeam.load(p.Context, p.Source.(teams.Team).Id), nil
+					},
+				},
+				"matches": {
+					Type: nonNullList(matchType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).matchesByTeam.load(p.Context, p.Source.(teams.Team).Id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	standingType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Standing",
+		Fields: graphql.Fields{
+			"team": {
+				Type: teamType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).teams.load(p.Context, p.Source.(standings.Standing).TeamId), nil
+				},
+			},
+			"teamName":       {Type: graphql.NewNonNull(graphql.String)},
+			"played":         {Type: graphql.NewNonNull(graphql.Int)},
+			"won":            {Type: graphql.NewNonNull(graphql.Int)},
+			"drawn":          {Type: graphql.NewNonNull(graphql.Int)},
+			"lost":           {Type: graphql.NewNonNull(graphql.Int)},
+			"goalsFor":       {Type: graphql.NewNonNull(graphql.Int)},
+			"goalsAgainst":   {Type: graphql.NewNonNull(graphql.Int)},
+			"goalDifference": {Type: graphql.NewNonNull(graphql.Int)},
+			"points":         {Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	championshipType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Championship",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      {Type: graphql.NewNonNull(graphql.ID)},
+				"name":    {Type: graphql.NewNonNull(graphql.String)},
+				"season":  {Type: graphql.NewNonNull(graphql.String)},
+				"version": {Type: graphql.NewNonNull(graphql.Int)},
+				"displayName": {
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						championship := p.Source.(championships.Championship)
+						return i18n.Name(i18n.FromContext(p.Context), championship.Name, championship.Names), nil
+					},
+				},
+				"teams": {
+					Type: nonNullList(teamType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						championship := p.Source.(championships.Championship)
+						ids := make([]string, 0, len(championship.Teams))
+						for _, team := range championship.Teams {
+							ids = append(ids, team.Id)
+						}
+						return loadAll(p.Context, loadersFrom(p.Context).teams, ids), nil
+					},
+				},
+				"matches": {
+					Type: nonNullList(matchType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).matchesByChampionship.load(p.Context, p.Source.(championships.Championship).Id), nil
+					},
+				},
+				"standings": {
+					Type: nonNullList(standingType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).standings.load(p.Context, p.Source.(championships.Championship).Id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	incidentType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Incident",
+		Fields: graphql.Fields{
+			"minute": {Type: graphql.NewNonNull(graphql.Int)},
+			"type":   {Type: graphql.NewNonNull(graphql.String)},
+			"player": {Type: graphql.String},
+			"teamId": {
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(matches.Incident).TeamId, nil
+				},
+			},
+			"team": {
+				Type: teamType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).teams.load(p.Context, p.Source.(matches.Incident).TeamId), nil
+				},
+			},
+		},
+	})
+
+	match := func(resolve func(matches.Match) interface{}) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			return resolve(p.Source.(matches.Match)), nil
+		}
+	}
+	matchType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Match",
+		Fields: graphql.Fields{
+			"id":           {Type: graphql.NewNonNull(graphql.ID)},
+			"homeTeamName": {Type: graphql.NewNonNull(graphql.String), Resolve: match(func(m matches.Match) interface{} { return m.TeamHomeName })},
+			"awayTeamName": {Type: graphql.NewNonNull(graphql.String), Resolve: match(func(m matches.Match) interface{} { return m.TeamAwayName })},
+			"homeScore":    {Type: graphql.NewNonNull(graphql.Int), Resolve: match(func(m matches.Match) interface{} { return m.TeamHomeScore })},
+			"awayScore":    {Type: graphql.NewNonNull(graphql.Int), Resolve: match(func(m matches.Match) interface{} { return m.TeamAwayScore })},
+			"matchDate":    {Type: graphql.NewNonNull(graphql.DateTime), Resolve: match(func(m matches.Match) interface{} { return m.MatchDate })},
+			"status":       {Type: graphql.NewNonNull(graphql.String), Resolve: match(func(m matches.Match) interface{} { return m.StatusAt(time.Now()) })},
+			"incidents":    {Type: nonNullList(incidentType), Resolve: match(func(m matches.Match) interface{} { return m.Incidents })},
+			"version":      {Type: graphql.NewNonNull(graphql.Int)},
+			"homeTeam": {
+				Type: teamType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).teams.load(p.Context, p.Source.(matches.Match).TeamHomeId), nil
+				},
+			},
+			"awayTeam": {
+				Type: teamType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).teams.load(p.Context, p.Source.(matches.Match).TeamAwayId), nil
+				},
+			},
+			"championship": {
+				Type: championshipType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).championships.load(p.Context, p.Source.(matches.Match).ChampionshipId), nil
+				},
+			},
+		},
+	})
+
+	idArgument := graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}}
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"team": {
+				Type: teamType,
+				Args: idArgument,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).teams.load(p.Context, p.Args["id"].(string)), nil
+				},
+			},
+			"teams": {
+				Type: nonNullList(teamType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).allTeams(p.Context)
+				},
+			},
+			"championship": {
+				Type: championshipType,
+				Args: idArgument,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).championships.load(p.Context, p.Args["id"].(string)), nil
+				},
+			},
+			"match": {
+				Type: matchType,
+				Args: idArgument,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).matches.load(p.Context, p.Args["id"].(string)), nil
+				},
+			},
+			"standings": {
+				Type: nonNullList(standingType),
+				Args: graphql.FieldConfigArgument{"championshipId": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).standings.load(p.Context, p.Args["championshipId"].(string)), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// loadAll resolves to the values found for keys, in their order.
+func loadAll[V any](ctx context.Context, l *loader[V], keys []string) func() (interface{}, error) {
+	thunks := make([]func() (interface{}, error), 0, len(keys))
+	for _, key := range keys {
+		thunks = append(thunks, l.load(ctx, key))
+	}
+
+	return func() (interface{}, error) {
+		values := make([]V, 0, len(thunks))
+		for _, thunk := range thunks {
+			value, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				values = append(values, value.(V))
+			}
+		}
+		return values, nil
+	}
+}
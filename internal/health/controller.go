@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout bounds how long a single dependency check may take.
+const checkTimeout = 2 * time.Second
+
+type Checker interface {
+	Ping(ctx context.Context) error
+}
+
+type Controller struct {
+	checks       map[string]Checker
+	shuttingDown atomic.Bool
+}
+
+func NewController(checks map[string]Checker) *Controller {
+	return &Controller{checks: checks}
+}
+
+type dependency struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Shutdown marks the process as shutting down, making Readyz fail so load
+// balancers stop routing new requests while in-flight ones drain.
+func (c *Controller) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Healthz reports that the process is alive. It never checks dependencies,
+// so a database outage does not get the process restarted.
+func (c *Controller) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz reports whether the process can serve traffic, pinging every
+// dependency concurrently.
+func (c *Controller) Readyz(ctx *gin.Context) {
+	if c.shuttingDown.Load() {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting down"})
+		return
+	}
+
+	dependencies := make(map[string]dependency, len(c.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func(name string, check Checker) {
+			defer wg.Done()
+			d := ping(ctx.Request.Context(), check)
+
+			mu.Lock()
+			dependencies[name] = d
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	for _, d := range dependencies {
+		if d.Status != "up" {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+	}
+
+	ctx.JSON(code, gin.H{"status": status, "dependencies": dependencies})
+}
+
+func ping(ctx context.Context, check Checker) dependency {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Ping(ctx)
+	latency := float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		return dependency{Status: "down", LatencyMs: latency, Error: err.Error()}
+	}
+
+	return dependency{Status: "up", LatencyMs: latency}
+}
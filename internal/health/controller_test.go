@@ -0,0 +1,102 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_Healthz(t *testing.T) {
+	c := NewController(map[string]Checker{})
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+
+	c.Healthz(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "{\"status\":\"ok\"}", recorder.Body.String())
+}
+
+func TestController_Readyz(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*checkerMock)
+		shutdown           bool
+		expectedStatusCode int
+		expectedStatus     string
+		expectedMongo      dependency
+	}{
+		{
+			name: "when mongo is up",
+			setup: func(c *checkerMock) {
+				c.On("Ping", mock.Anything).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedStatus:     "ok",
+			expectedMongo:      dependency{Status: "up"},
+		},
+		{
+			name: "when mongo is down",
+			setup: func(c *checkerMock) {
+				c.On("Ping", mock.Anything).Return(errors.New("server selection error"))
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     "unavailable",
+			expectedMongo:      dependency{Status: "down", Error: "server selection error"},
+		},
+		{
+			name:               "when shutting down",
+			setup:              func(c *checkerMock) {},
+			shutdown:           true,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     "shutting down",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &checkerMock{}
+			tt.setup(m)
+
+			c := NewController(map[string]Checker{"mongodb": m})
+			if tt.shutdown {
+				c.Shutdown()
+			}
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+			c.Readyz(ctx)
+
+			var body struct {
+				Status       string                `json:"status"`
+				Dependencies map[string]dependency `json:"dependencies"`
+			}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedStatus, body.Status)
+			mongo := body.Dependencies["mongodb"]
+			mongo.LatencyMs = 0
+			assert.Equal(t, tt.expectedMongo, mongo)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+type checkerMock struct {
+	mock.Mock
+}
+
+func (m *checkerMock) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
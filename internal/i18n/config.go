@@ -0,0 +1,24 @@
+package i18n
+
+import (
+	"fmt"
+	"github.com/caarlos0/env/v11"
+	"slices"
+)
+
+// Config holds the language of requests without an Accept-Language header,
+// or accepting none of the supported languages.
+type Config struct {
+	DefaultLanguage string `env:"DEFAULT_LANGUAGE" envDefault:"en"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(Languages, cfg.DefaultLanguage) {
+		return nil, fmt.Errorf("invalid DEFAULT_LANGUAGE %q, use pt-BR, en or es", cfg.DefaultLanguage)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,81 @@
+// Package i18n negotiates the language of each request from its
+// Accept-Language header and localizes the error messages and the names the
+// API answers with. Portuguese (Brazil), English and Spanish are supported.
+package i18n
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+const (
+	PortugueseBR = "pt-BR"
+	English      = "en"
+	Spanish      = "es"
+)
+
+// Languages lists the supported languages, in the order the matcher prefers
+// them when a request accepts several equally.
+var Languages = []string{PortugueseBR, English, Spanish}
+
+var matcher = language.NewMatcher([]language.Tag{language.BrazilianPortuguese, language.English, language.Spanish})
+
+type languageKey struct{}
+
+// WithLanguage returns a copy of ctx carrying the language of the request.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// FromContext returns the language carried by ctx, or English when there is
+// none, as in background work.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok {
+		return lang
+	}
+
+	return English
+}
+
+// Negotiate picks the supported language best matching an Accept-Language
+// header: "pt" and "pt-PT" get pt-BR, "es-AR" gets es. Headers naming none
+// of them, or none at all, get fallback.
+func Negotiate(header, fallback string) string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return fallback
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return fallback
+	}
+
+	return Languages[index]
+}
+
+// Middleware negotiates the language of every request and announces it with
+// Content-Language. Responses vary with Accept-Language, which caches must
+// know about.
+func Middleware(config *Config) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		lang := Negotiate(ctx.GetHeader("Accept-Language"), config.DefaultLanguage)
+
+		ctx.Header("Content-Language", lang)
+		ctx.Writer.Header().Add("Vary", "Accept-Language")
+		ctx.Request = ctx.Request.WithContext(WithLanguage(ctx.Request.Context(), lang))
+
+		ctx.Next()
+	}
+}
+
+// Name returns the display name of an entity in lang: its localized name
+// when it has one, its name otherwise.
+func Name(lang, name string, names map[string]string) string {
+	if localized := names[lang]; localized != "" {
+		return localized
+	}
+
+	return name
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "when there is no header", header: "", want: Spanish},
+		{name: "when the header asks for a supported language", header: "pt-BR", want: PortugueseBR},
+		{name: "when the header asks for another region of a supported language", header: "pt-PT", want: PortugueseBR},
+		{name: "when the header asks for a supported base language", header: "es-AR", want: Spanish},
+		{name: "when the header weighs several languages", header: "fr-FR, en;q=0.8, pt;q=0.9", want: PortugueseBR},
+		{name: "when the header asks for no supported language", header: "fr, de;q=0.5", want: Spanish},
+		{name: "when the header is malformed", header: "pt-BR;q=x", want: Spanish},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.header, Spanish))
+		})
+	}
+}
+
+type team struct {
+	Name      string            `json:"name" binding:"required"`
+	Website   string            `json:"website" binding:"omitempty,url"`
+	Nicknames []string          `json:"nicknames" binding:"max=2,dive,min=2"`
+	Names     map[string]string `json:"names" binding:"omitempty,dive,keys,oneof=pt-BR en es,endkeys,required"`
+	Founded   int               `json:"founded" binding:"min=1850"`
+}
+
+func TestMessage(t *testing.T) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		name string
+		lang string
+		err  error
+		want string
+	}{
+		{name: "when the message is in the catalog", lang: PortugueseBR, err: errors.New("team not found"), want: "time não encontrado"},
+		{name: "when the message is not in the catalog", lang: Spanish, err: errors.New("connection refused"), want: "connection refused"},
+		{name: "when the English catalog rewrites a library message", lang: English, err: errors.New("the provided hex string is not a valid ObjectID"), want: "id must be an ObjectID of 24 hexadecimal characters"},
+		{name: "when the message has arguments", lang: Spanish, err: Errorf("unknown event type %q", "team.renamed"), want: "tipo de evento desconocido \"team.renamed\""},
+		{name: "when the body is missing", lang: PortugueseBR, err: binding.JSON.BindBody(nil, &body), want: "o corpo da requisição é obrigatório"},
+		{name: "when the body is malformed", lang: English, err: binding.JSON.BindBody([]byte("{"), &body), want: "request body is not valid JSON"},
+		{name: "when a field has the wrong type", lang: PortugueseBR, err: binding.JSON.BindBody([]byte(`{"name": 1}`), &body), want: "name tem o tipo errado"},
+		{
+			name: "when fields are invalid",
+			lang: English,
+			err:  binding.JSON.BindBody([]byte(`{"website": "inter", "nicknames": ["Inter", "C", "Clube do Povo"], "names": {"fr": "Inter"}, "founded": 1800}`), &team{}),
+			want: "name is required; website must be a URL; nicknames must have at most 2 items; names[fr] must be one of pt-BR en es; founded must be at least 1850",
+		},
+		{
+			name: "when fields are invalid in another language",
+			lang: Spanish,
+			err:  binding.JSON.BindBody([]byte(`{"name": "Internacional", "nicknames": ["C"], "founded": 1909}`), &team{}),
+			want: "nicknames[0] debe tener como mínimo 2 caracteres",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Message(WithLanguage(context.Background(), tt.lang), tt.err))
+		})
+	}
+}
+
+func TestMessage_withoutLanguage(t *testing.T) {
+	assert.Equal(t, "team not found", Message(context.Background(), errors.New("team not found")))
+}
+
+// TestCatalogs fails when a message is translated into one language but not
+// the others.
+func TestCatalogs(t *testing.T) {
+	keys := func(catalog map[string]string) []string {
+		var keys []string
+		for key := range catalog {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	assert.Equal(t, keys(catalogs[PortugueseBR]), keys(catalogs[Spanish]))
+	for key := range catalogs[English] {
+		assert.Contains(t, catalogs[PortugueseBR], key)
+	}
+	for _, lang := range Languages {
+		for key, message := range catalogs[lang] {
+			assert.Equal(t, strings.Count(key, "%"), strings.Count(message, "%"), "%s: %q and %q take different arguments", lang, key, message)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	r := gin.New()
+	r.Use(Middleware(&Config{DefaultLanguage: English}))
+	r.GET("/", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, FromContext(ctx.Request.Context()))
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Language", "es-419,es;q=0.9")
+	r.ServeHTTP(recorder, request)
+
+	assert.Equal(t, Spanish, recorder.Body.String())
+	assert.Equal(t, Spanish, recorder.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", recorder.Header().Get("Vary"))
+}
+
+func TestName(t *testing.T) {
+	names := map[string]string{Spanish: "Copa Mundial de Clubes de la FIFA"}
+
+	assert.Equal(t, "Copa Mundial de Clubes de la FIFA", Name(Spanish, "FIFA Club World Cup", names))
+	assert.Equal(t, "FIFA Club World Cup", Name(PortugueseBR, "FIFA Club World Cup", names))
+	assert.Equal(t, "FIFA Club World Cup", Name(English, "FIFA Club World Cup", nil))
+}
@@ -0,0 +1,147 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The catalogs map the English messages of the API, or the formats of
+// those built with Errorf, to their translations. The English one only
+// rewrites the messages of the libraries the API relies on.
+//
+//go:embed catalogs/*.json
+var catalogFiles embed.FS
+
+var catalogs = map[string]map[string]string{}
+
+func init() {
+	for _, lang := range Languages {
+		data, err := catalogFiles.ReadFile("catalogs/" + lang + ".json")
+		if err != nil {
+			panic(err)
+		}
+		catalog := map[string]string{}
+		if err = json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Errorf("catalogs/%s.json: %w", lang, err))
+		}
+		catalogs[lang] = catalog
+	}
+
+	// Name fields in validation errors as the JSON bodies do.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// Error is an error whose message is a format with arguments, so that the
+// format can be translated before the arguments are filled in.
+type Error struct {
+	format string
+	args   []interface{}
+}
+
+// Errorf builds an Error from a format found in the catalogs.
+func Errorf(format string, args ...interface{}) error {
+	return &Error{format: format, args: args}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf(e.format, e.args...)
+}
+
+// Message returns the message of err in the language of ctx. Messages
+// missing from the catalog of that language are returned as they are.
+func Message(ctx context.Context, err error) string {
+	return translate(FromContext(ctx), err)
+}
+
+func translate(lang string, err error) string {
+	var formatted *Error
+	var validation validator.ValidationErrors
+	var syntax *json.SyntaxError
+	var unmarshalType *json.UnmarshalTypeError
+	var parse *time.ParseError
+	var maxBytes *http.MaxBytesError
+	switch {
+	case errors.As(err, &formatted):
+		return sprintf(lang, formatted.format, formatted.args...)
+	case errors.As(err, &validation):
+		messages := make([]string, 0, len(validation))
+		for _, fieldErr := range validation {
+			messages = append(messages, fieldMessage(lang, fieldErr))
+		}
+		return strings.Join(messages, "; ")
+	case errors.As(err, &syntax), errors.Is(err, io.ErrUnexpectedEOF):
+		return lookup(lang, "request body is not valid JSON")
+	case errors.As(err, &unmarshalType):
+		return sprintf(lang, "%s has the wrong type", unmarshalType.Field)
+	case errors.As(err, &parse):
+		return lookup(lang, "dates must follow RFC 3339, like 2006-12-17T00:00:00Z")
+	case errors.As(err, &maxBytes):
+		return lookup(lang, "request body is too large")
+	case errors.Is(err, io.EOF):
+		return lookup(lang, "request body is required")
+	}
+
+	return lookup(lang, err.Error())
+}
+
+// fieldMessage describes a failed validation rule. Rules without a message
+// of their own read as the field being invalid.
+func fieldMessage(lang string, fieldErr validator.FieldError) string {
+	// The namespace starts with the name of the bound struct, which the
+	// caller never sees.
+	_, field, _ := strings.Cut(fieldErr.Namespace(), ".")
+
+	switch fieldErr.Tag() {
+	case "required":
+		return sprintf(lang, "%s is required", field)
+	case "oneof":
+		return sprintf(lang, "%s must be one of %s", field, fieldErr.Param())
+	case "url":
+		return sprintf(lang, "%s must be a URL", field)
+	case "min", "max":
+		bound := "at least"
+		if fieldErr.Tag() == "max" {
+			bound = "at most"
+		}
+		switch fieldErr.Kind() {
+		case reflect.String:
+			return sprintf(lang, "%s must be "+bound+" %s characters long", field, fieldErr.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return sprintf(lang, "%s must have "+bound+" %s items", field, fieldErr.Param())
+		default:
+			return sprintf(lang, "%s must be "+bound+" %s", field, fieldErr.Param())
+		}
+	}
+
+	return sprintf(lang, "%s is invalid", field)
+}
+
+func lookup(lang, message string) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		return translated
+	}
+
+	return message
+}
+
+func sprintf(lang, format string, args ...interface{}) string {
+	return fmt.Sprintf(lookup(lang, format), args...)
+}
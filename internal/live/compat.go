@@ -0,0 +1,57 @@
+package live
+
+import (
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"time"
+)
+
+func init() {
+	apiversion.Register(apiversion.V1, func(u Update) interface{} { return ToV1(u) })
+}
+
+// V1Change is a change in the shape v1 streams it, with its incident in the
+// v1 shape of incidents.
+type V1Change struct {
+	Kind      ChangeKind          `json:"kind"`
+	HomeScore *int                `json:"homeScore,omitempty"`
+	AwayScore *int                `json:"awayScore,omitempty"`
+	From      matches.Status      `json:"from,omitempty"`
+	To        matches.Status      `json:"to,omitempty"`
+	Incident  *matches.V1Incident `json:"incident,omitempty"`
+}
+
+// V1Update is an update in the shape v1 streams it, with its match in the v1
+// shape of matches.
+type V1Update struct {
+	MatchId    string           `json:"matchId"`
+	Version    int64            `json:"version"`
+	Type       events.Type      `json:"type"`
+	Changes    []V1Change       `json:"changes"`
+	Match      *matches.V1Match `json:"match,omitempty"`
+	OccurredAt time.Time        `json:"occurredAt"`
+}
+
+func ToV1(u Update) V1Update {
+	var changes []V1Change
+	if u.Changes != nil {
+		changes = make([]V1Change, 0, len(u.Changes))
+	}
+	for _, change := range u.Changes {
+		v1 := V1Change{Kind: change.Kind, HomeScore: change.HomeScore, AwayScore: change.AwayScore, From: change.From, To: change.To}
+		if change.Incident != nil {
+			incident := matches.V1Incident(*change.Incident)
+			v1.Incident = &incident
+		}
+		changes = append(changes, v1)
+	}
+
+	v1 := V1Update{MatchId: u.MatchId, Version: u.Version, Type: u.Type, Changes: changes, OccurredAt: u.OccurredAt}
+	if u.Match != nil {
+		match := matches.ToV1(*u.Match)
+		v1.Match = &match
+	}
+
+	return v1
+}
@@ -0,0 +1,28 @@
+package live
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config tunes streams. Writes handled by other replicas are only noticed by
+// polling every PollInterval, and idle streams are kept open by a heartbeat
+// every Heartbeat. WebSocket handshakes are accepted from the same origin and
+// from AllowedOrigins, where "*" allows any.
+type Config struct {
+	PollInterval   time.Duration `env:"LIVE_POLL_INTERVAL" envDefault:"2s"`
+	Heartbeat      time.Duration `env:"LIVE_HEARTBEAT" envDefault:"15s"`
+	AllowedOrigins []string      `env:"LIVE_ALLOWED_ORIGINS"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.PollInterval <= 0 || cfg.Heartbeat <= 0 {
+		return nil, errors.New("LIVE_POLL_INTERVAL and LIVE_HEARTBEAT must be positive")
+	}
+	return &cfg, nil
+}
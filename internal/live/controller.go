@@ -0,0 +1,201 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeWait bounds how long a WebSocket client may take to accept a message.
+const writeWait = 10 * time.Second
+
+type service interface {
+	start(ctx context.Context, id string, cursor int64, resume bool) (*Update, int64, error)
+	follow(ctx context.Context, id string, cursor int64, send func(Update) error, ping func() error) error
+}
+
+type Controller struct {
+	service  service
+	config   *Config
+	upgrader websocket.Upgrader
+}
+
+func NewController(service service, config *Config) *Controller {
+	c := &Controller{service: service, config: config}
+	c.upgrader = websocket.Upgrader{CheckOrigin: c.checkOrigin}
+
+	return c
+}
+
+// GetLiveMatch streams the updates to a match as Server-Sent Events, or over
+// a WebSocket when the request asks to upgrade. Clients resume after the
+// version in the Last-Event-ID header or the lastEventId query parameter,
+// which browsers cannot set on a WebSocket; without one they get a snapshot
+// of the match first.
+func (c Controller) GetLiveMatch(ctx *gin.Context) {
+	cursor, resume, err := lastEventId(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	id := ctx.Param("id")
+	snapshot, cursor, err := c.service.start(ctx.Request.Context(), id, cursor, resume)
+	if errors.Is(err, errMatchNotFound) {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(ctx.Request) {
+		err = c.streamSocket(ctx, id, snapshot, cursor)
+	} else {
+		err = c.streamEvents(ctx, id, snapshot, cursor)
+	}
+	if err != nil {
+		slog.InfoContext(ctx.Request.Context(), "live stream ended", "match_id", id, "error", err)
+	}
+}
+
+func (c Controller) streamEvents(ctx *gin.Context, id string, snapshot *Update, cursor int64) error {
+	// Streams outlive the server timeouts meant for ordinary requests. Writers
+	// without deadlines, such as test recorders, do not support clearing them.
+	rc := http.NewResponseController(ctx.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.WriteHeaderNow()
+	ctx.Writer.Flush()
+
+	version := apiversion.From(ctx)
+	send := func(update Update) error {
+		data, err := json.Marshal(apiversion.Render(version, update))
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(ctx.Writer, "id: %d\nevent: %s\ndata: %s\n\n", update.Version, update.Type, data); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		return nil
+	}
+	ping := func() error {
+		if _, err := ctx.Writer.WriteString(": keep-alive\n\n"); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		return nil
+	}
+
+	if snapshot != nil {
+		if err := send(*snapshot); err != nil {
+			return err
+		}
+	}
+
+	return c.service.follow(ctx.Request.Context(), id, cursor, send, ping)
+}
+
+func (c Controller) streamSocket(ctx *gin.Context, id string, snapshot *Update, cursor int64) error {
+	conn, err := c.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// Upgrade already answered the client.
+		return err
+	}
+	defer conn.Close()
+
+	streamCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	// Clients only send control frames. Reading them answers pings and notices
+	// a client going away or no longer answering the heartbeat.
+	idle := 2 * c.config.Heartbeat
+	_ = conn.SetReadDeadline(time.Now().Add(idle))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(idle))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	version := apiversion.From(ctx)
+	send := func(update Update) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return conn.WriteJSON(apiversion.Render(version, update))
+	}
+	ping := func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+	}
+
+	if snapshot != nil {
+		if err = send(*snapshot); err != nil {
+			return err
+		}
+	}
+
+	err = c.service.follow(streamCtx, id, cursor, send, ping)
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+
+	return err
+}
+
+// checkOrigin accepts handshakes without an Origin header, which only
+// browsers send, from the API's own host and from the configured origins.
+func (c Controller) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(c.config.AllowedOrigins, "*") || slices.Contains(c.config.AllowedOrigins, origin) {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// lastEventId reads the cursor to resume from, reporting whether there is
+// one.
+func lastEventId(ctx *gin.Context) (int64, bool, error) {
+	raw := ctx.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = ctx.Query("lastEventId")
+	}
+	if raw == "" {
+		return 0, false, nil
+	}
+
+	cursor, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || cursor < 0 {
+		return 0, false, errors.New("Last-Event-ID must be a match version")
+	}
+
+	return cursor, true, nil
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
@@ -0,0 +1,51 @@
+// Package live streams what happens to a match while it is played, over
+// Server-Sent Events and WebSocket.
+package live
+
+import (
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"strconv"
+	"time"
+)
+
+// Snapshot opens a stream with the match as it is, for clients without a
+// cursor or whose cursor is older than the updates still kept.
+const Snapshot events.Type = "match.snapshot"
+
+type ChangeKind string
+
+const (
+	ScoreChanged    ChangeKind = "score"
+	StatusChanged   ChangeKind = "status"
+	IncidentAdded   ChangeKind = "incident"
+	IncidentRemoved ChangeKind = "incident_removed"
+)
+
+// Change is one thing an update did to the match. Only the fields of its
+// kind are set.
+type Change struct {
+	Kind      ChangeKind        `json:"kind"`
+	HomeScore *int              `json:"homeScore,omitempty" bson:",omitempty"`
+	AwayScore *int              `json:"awayScore,omitempty" bson:",omitempty"`
+	From      matches.Status    `json:"from,omitempty" bson:",omitempty"`
+	To        matches.Status    `json:"to,omitempty" bson:",omitempty"`
+	Incident  *matches.Incident `json:"incident,omitempty" bson:",omitempty"`
+}
+
+// Update is a match event as streamed to clients. Version is the version the
+// match reached with it, which every write bumps, so it doubles as the cursor
+// clients resume from.
+type Update struct {
+	Id         string         `json:"-" bson:"_id"`
+	MatchId    string         `json:"matchId"`
+	Version    int64          `json:"version"`
+	Type       events.Type    `json:"type"`
+	Changes    []Change       `json:"changes"`
+	Match      *matches.Match `json:"match,omitempty" bson:",omitempty"`
+	OccurredAt time.Time      `json:"occurredAt"`
+}
+
+func updateId(matchId string, version int64) string {
+	return matchId + ":" + strconv.FormatInt(version, 10)
+}
@@ -0,0 +1,49 @@
+package live
+
+import "sync"
+
+// hub wakes the streams following a match when this replica records an
+// update for it.
+type hub struct {
+	mu       sync.Mutex
+	watchers map[string]map[chan struct{}]bool
+}
+
+func newHub() *hub {
+	return &hub{watchers: map[string]map[chan struct{}]bool{}}
+}
+
+// watch returns a channel signalled after updates to the match, and a
+// function to stop watching.
+func (h *hub) watch(matchId string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.watchers[matchId] == nil {
+		h.watchers[matchId] = map[chan struct{}]bool{}
+	}
+	h.watchers[matchId][ch] = true
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.watchers[matchId], ch)
+		if len(h.watchers[matchId]) == 0 {
+			delete(h.watchers, matchId)
+		}
+	}
+}
+
+func (h *hub) notify(matchId string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.watchers[matchId] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
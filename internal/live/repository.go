@@ -0,0 +1,89 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/tracing"
+)
+
+type db interface {
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+}
+
+type matchesDB interface {
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+}
+
+type Repository struct {
+	db
+	matches matchesDB
+}
+
+func NewRepository(db db, matches matchesDB) *Repository {
+	return &Repository{db: db, matches: matches}
+}
+
+// saveUpdate stores the update, replacing the one already stored for the
+// same match version, so that redelivered events are stored once.
+func (r Repository) saveUpdate(ctx context.Context, update Update) error {
+	ctx, span := tracer.Start(ctx, "live.Repository.saveUpdate")
+	defer span.End()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.db.ReplaceOne(ctx, bson.M{"_id": update.Id}, update, opts); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// getUpdates returns up to limit updates to the match after the given
+// version, oldest first.
+func (r Repository) getUpdates(ctx context.Context, matchId string, after int64, limit int64) ([]Update, error) {
+	ctx, span := tracer.Start(ctx, "live.Repository.getUpdates")
+	defer span.End()
+
+	filter := bson.M{"matchid": matchId, "version": bson.M{"$gt": after}}
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}}).SetLimit(limit)
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	updates := []Update{}
+	if err = cursor.All(ctx, &updates); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return updates, nil
+}
+
+// getMatch returns the match with the given id, or an empty match when it
+// does not exist or is deleted.
+func (r Repository) getMatch(ctx context.Context, id string) (matches.Match, error) {
+	ctx, span := tracer.Start(ctx, "live.Repository.getMatch")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return matches.Match{}, tracing.Error(span, err)
+	}
+
+	var match matches.Match
+	err = r.matches.FindOne(ctx, bson.M{"_id": docID, "deletedat": nil}).Decode(&match)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return matches.Match{}, nil
+	}
+	if err != nil {
+		return matches.Match{}, tracing.Error(span, err)
+	}
+
+	return match, nil
+}
@@ -0,0 +1,223 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/tracing"
+	"sync"
+	"time"
+)
+
+// batchSize bounds how many stored updates a stream reads at once.
+const batchSize = 100
+
+var tracer = otel.Tracer("sc-internacional/internal/live")
+
+var errMatchNotFound = errors.New("match not found")
+
+type repository interface {
+	saveUpdate(ctx context.Context, update Update) error
+	getUpdates(ctx context.Context, matchId string, after int64, limit int64) ([]Update, error)
+	getMatch(ctx context.Context, id string) (matches.Match, error)
+}
+
+type Service struct {
+	repository repository
+	config     *Config
+	hub        *hub
+	done       chan struct{}
+	closeOnce  *sync.Once
+	now        func() time.Time
+}
+
+func NewService(repository repository, config *Config) *Service {
+	return &Service{
+		repository: repository,
+		config:     config,
+		hub:        newHub(),
+		done:       make(chan struct{}),
+		closeOnce:  &sync.Once{},
+		now:        time.Now,
+	}
+}
+
+// HandleEvent stores a match event as an update and wakes the streams
+// following the match on this replica. It is subscribed to every match event.
+func (s Service) HandleEvent(ctx context.Context, event events.Event) error {
+	ctx, span := tracer.Start(ctx, "live.Service.HandleEvent")
+	defer span.End()
+
+	var current, previous matches.Match
+	if err := event.Decode(&current, &previous); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	update := Update{
+		MatchId:    event.EntityId,
+		Type:       event.Type,
+		Changes:    changes(previous, current, event.OccurredAt),
+		OccurredAt: event.OccurredAt,
+	}
+	if event.Data != nil {
+		update.Version = current.Version
+		update.Match = &current
+	} else {
+		// Purging does not write the match, so it takes the version a write
+		// would have.
+		update.Version = previous.Version + 1
+	}
+	update.Id = updateId(update.MatchId, update.Version)
+
+	if err := s.repository.saveUpdate(ctx, update); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	s.hub.notify(update.MatchId)
+
+	return nil
+}
+
+// start prepares a stream of the match with the given id. Resuming from a
+// cursor the stored updates continue, it returns no snapshot; otherwise it
+// returns one of the match as it is. Either way it returns the cursor to
+// follow from, and errMatchNotFound when the match does not exist or is
+// deleted.
+func (s Service) start(ctx context.Context, id string, cursor int64, resume bool) (*Update, int64, error) {
+	ctx, span := tracer.Start(ctx, "live.Service.start")
+	defer span.End()
+
+	match, err := s.repository.getMatch(ctx, id)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	if match.Id == "" {
+		return nil, 0, errMatchNotFound
+	}
+
+	if resume {
+		if cursor >= match.Version {
+			return nil, cursor, nil
+		}
+
+		next, err := s.repository.getUpdates(ctx, id, cursor, 1)
+		if err != nil {
+			return nil, 0, tracing.Error(span, err)
+		}
+		if len(next) > 0 && next[0].Version == cursor+1 {
+			return nil, cursor, nil
+		}
+	}
+
+	snapshot := Update{
+		Id:         updateId(id, match.Version),
+		MatchId:    id,
+		Version:    match.Version,
+		Type:       Snapshot,
+		Changes:    []Change{},
+		Match:      &match,
+		OccurredAt: s.now().UTC().Truncate(time.Millisecond),
+	}
+
+	return &snapshot, match.Version, nil
+}
+
+// follow sends the updates to the match after cursor, as they are recorded,
+// until ctx is done, the service shuts down, the match is purged or send
+// fails. It calls ping whenever the stream was idle for a heartbeat.
+func (s Service) follow(ctx context.Context, id string, cursor int64, send func(Update) error, ping func() error) error {
+	notified, stop := s.hub.watch(id)
+	defer stop()
+
+	poll := time.NewTicker(s.config.PollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(s.config.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		updates, err := s.repository.getUpdates(ctx, id, cursor, batchSize)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		for _, update := range updates {
+			if err = send(update); err != nil {
+				return err
+			}
+			cursor = update.Version
+			heartbeat.Reset(s.config.Heartbeat)
+
+			if update.Type == events.MatchPurged {
+				return nil
+			}
+		}
+		if len(updates) == batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return nil
+		case <-notified:
+		case <-poll.C:
+		case <-heartbeat.C:
+			if err = ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Shutdown ends every stream, so that clients reconnect to another replica
+// instead of holding this one until the shutdown timeout.
+func (s Service) Shutdown() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// changes tells what the update from before to after did to the match. Either
+// side is empty when the match was just created or purged. Statuses are read
+// at the time of the update.
+func changes(before, after matches.Match, at time.Time) []Change {
+	result := []Change{}
+	if after.Id == "" {
+		return result
+	}
+
+	to := after.StatusAt(at)
+	if before.Id == "" {
+		result = append(result, Change{Kind: StatusChanged, To: to})
+	} else if from := before.StatusAt(at); from != to {
+		result = append(result, Change{Kind: StatusChanged, From: from, To: to})
+	}
+
+	if before.TeamHomeScore != after.TeamHomeScore || before.TeamAwayScore != after.TeamAwayScore {
+		home, away := after.TeamHomeScore, after.TeamAwayScore
+		result = append(result, Change{Kind: ScoreChanged, HomeScore: &home, AwayScore: &away})
+	}
+
+	remaining := map[matches.Incident]int{}
+	for _, incident := range before.Incidents {
+		remaining[incident]++
+	}
+	for _, incident := range after.Incidents {
+		if remaining[incident] > 0 {
+			remaining[incident]--
+			continue
+		}
+		incident := incident
+		result = append(result, Change{Kind: IncidentAdded, Incident: &incident})
+	}
+	for _, incident := range before.Incidents {
+		if remaining[incident] > 0 {
+			remaining[incident]--
+			incident := incident
+			result = append(result, Change{Kind: IncidentRemoved, Incident: &incident})
+		}
+	}
+
+	return result
+}
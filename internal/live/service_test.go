@@ -0,0 +1,259 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"testing"
+	"time"
+)
+
+var kickOff = time.Date(2006, time.December, 17, 8, 30, 0, 0, time.UTC)
+
+func match(version int64) matches.Match {
+	return matches.Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", MatchDate: kickOff, ChampionshipId: "3", Status: matches.Scheduled, Version: version}
+}
+
+func event(t *testing.T, eventType events.Type, previous, current *matches.Match) events.Event {
+	e := events.Event{Id: "e", Type: eventType, EntityId: "10", OccurredAt: kickOff.Add(time.Hour)}
+	var err error
+	if current != nil {
+		e.Data, err = json.Marshal(current)
+		assert.NoError(t, err)
+	}
+	if previous != nil {
+		e.Previous, err = json.Marshal(previous)
+		assert.NoError(t, err)
+	}
+
+	return e
+}
+
+func TestService_HandleEvent(t *testing.T) {
+	scheduled := match(1)
+	started := match(2)
+	started.Status = matches.InProgress
+	goal := match(3)
+	goal.Status = matches.InProgress
+	goal.TeamHomeScore = 1
+	goal.Incidents = []matches.Incident{{Minute: 37, Type: matches.Goal, TeamId: "1", Player: "Gabiru"}}
+	one, zero := 1, 0
+	tests := []struct {
+		name     string
+		event    events.Event
+		expected Update
+	}{
+		{
+			name:  "when a match is scheduled",
+			event: event(t, events.MatchScheduled, nil, &scheduled),
+			expected: Update{Id: "10:1", MatchId: "10", Version: 1, Type: events.MatchScheduled, Match: &scheduled,
+				Changes: []Change{{Kind: StatusChanged, To: matches.Scheduled}}},
+		},
+		{
+			name:  "when a match kicks off",
+			event: event(t, events.MatchStarted, &scheduled, &started),
+			expected: Update{Id: "10:2", MatchId: "10", Version: 2, Type: events.MatchStarted, Match: &started,
+				Changes: []Change{{Kind: StatusChanged, From: matches.Scheduled, To: matches.InProgress}}},
+		},
+		{
+			name:  "when a goal is scored",
+			event: event(t, events.MatchScoreCorrected, &started, &goal),
+			expected: Update{Id: "10:3", MatchId: "10", Version: 3, Type: events.MatchScoreCorrected, Match: &goal,
+				Changes: []Change{{Kind: ScoreChanged, HomeScore: &one, AwayScore: &zero}, {Kind: IncidentAdded, Incident: &goal.Incidents[0]}}},
+		},
+		{
+			name:  "when a goal is disallowed",
+			event: event(t, events.MatchScoreCorrected, &goal, &started),
+			expected: Update{Id: "10:2", MatchId: "10", Version: 2, Type: events.MatchScoreCorrected, Match: &started,
+				Changes: []Change{{Kind: ScoreChanged, HomeScore: &zero, AwayScore: &zero}, {Kind: IncidentRemoved, Incident: &goal.Incidents[0]}}},
+		},
+		{
+			name:     "when a match is purged",
+			event:    event(t, events.MatchPurged, &goal, nil),
+			expected: Update{Id: "10:4", MatchId: "10", Version: 4, Type: events.MatchPurged, Changes: []Change{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.OccurredAt = tt.event.OccurredAt
+			r := &repositoryMock{}
+			r.On("saveUpdate", mock.Anything, tt.expected).Return(nil)
+
+			s := NewService(r, &Config{PollInterval: time.Minute, Heartbeat: time.Minute})
+			notified, stop := s.hub.watch("10")
+			defer stop()
+
+			err := s.HandleEvent(context.Background(), tt.event)
+
+			assert.NoError(t, err)
+			r.AssertExpectations(t)
+			assert.Len(t, notified, 1)
+		})
+	}
+}
+
+func TestService_start(t *testing.T) {
+	now := kickOff.Add(time.Hour)
+	current := match(5)
+	snapshot := &Update{Id: "10:5", MatchId: "10", Version: 5, Type: Snapshot, Changes: []Change{}, Match: &current, OccurredAt: now}
+	tests := []struct {
+		name             string
+		setup            func(*repositoryMock)
+		cursor           int64
+		resume           bool
+		expectedSnapshot *Update
+		expectedCursor   int64
+		expectedErr      error
+	}{
+		{
+			name: "when match does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10").Return(matches.Match{}, nil)
+			},
+			expectedErr: errMatchNotFound,
+		},
+		{
+			name: "when there is no cursor",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10").Return(current, nil)
+			},
+			expectedSnapshot: snapshot,
+			expectedCursor:   5,
+		},
+		{
+			name: "when the cursor is up to date",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10").Return(current, nil)
+			},
+			cursor:         5,
+			resume:         true,
+			expectedCursor: 5,
+		},
+		{
+			name: "when the stored updates continue the cursor",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10").Return(current, nil)
+				r.On("getUpdates", mock.Anything, "10", int64(3), int64(1)).Return([]Update{{MatchId: "10", Version: 4}}, nil)
+			},
+			cursor:         3,
+			resume:         true,
+			expectedCursor: 3,
+		},
+		{
+			name: "when updates after the cursor are missing",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10").Return(current, nil)
+				r.On("getUpdates", mock.Anything, "10", int64(1), int64(1)).Return([]Update{{MatchId: "10", Version: 4}}, nil)
+			},
+			cursor:           1,
+			resume:           true,
+			expectedSnapshot: snapshot,
+			expectedCursor:   5,
+		},
+		{
+			name: "when failed to get the match",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10").Return(matches.Match{}, errors.New("failed to get match"))
+			},
+			expectedErr: errors.New("failed to get match"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+
+			s := NewService(r, &Config{PollInterval: time.Minute, Heartbeat: time.Minute})
+			s.now = func() time.Time { return now }
+
+			snapshot, cursor, err := s.start(context.Background(), "10", tt.cursor, tt.resume)
+
+			assert.Equal(t, tt.expectedSnapshot, snapshot)
+			assert.Equal(t, tt.expectedCursor, cursor)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestService_follow(t *testing.T) {
+	r := &repositoryMock{}
+	r.On("getUpdates", mock.Anything, "10", int64(2), int64(batchSize)).Return([]Update{}, nil).Once()
+	r.On("getUpdates", mock.Anything, "10", int64(2), int64(batchSize)).Return([]Update{{MatchId: "10", Version: 3, Type: events.MatchScoreCorrected}}, nil).Once()
+	r.On("getUpdates", mock.Anything, "10", int64(3), int64(batchSize)).Return([]Update{{MatchId: "10", Version: 4, Type: events.MatchPurged}}, nil).Once()
+
+	s := NewService(r, &Config{PollInterval: time.Minute, Heartbeat: time.Minute})
+
+	var sent []int64
+	send := func(update Update) error {
+		sent = append(sent, update.Version)
+		return nil
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.follow(context.Background(), "10", 2, send, func() error { return nil })
+	}()
+
+	// The follower is idle until this replica records an update.
+	timeout := time.After(time.Second)
+	for stopped := false; !stopped; {
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+			stopped = true
+		case <-time.After(10 * time.Millisecond):
+			s.hub.notify("10")
+		case <-timeout:
+			t.Fatal("follow did not stop after the match was purged")
+		}
+	}
+	assert.Equal(t, []int64{3, 4}, sent)
+	r.AssertExpectations(t)
+}
+
+func TestService_Shutdown(t *testing.T) {
+	r := &repositoryMock{}
+	r.On("getUpdates", mock.Anything, "10", int64(0), int64(batchSize)).Return([]Update{}, nil)
+
+	s := NewService(r, &Config{PollInterval: time.Minute, Heartbeat: time.Minute})
+
+	done := make(chan error)
+	go func() {
+		done <- s.follow(context.Background(), "10", 0, func(Update) error { return nil }, func() error { return nil })
+	}()
+	s.Shutdown()
+	s.Shutdown()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("follow did not stop on shutdown")
+	}
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) saveUpdate(ctx context.Context, update Update) error {
+	args := m.Called(ctx, update)
+
+	return args.Error(0)
+}
+
+func (m *repositoryMock) getUpdates(ctx context.Context, matchId string, after int64, limit int64) ([]Update, error) {
+	args := m.Called(ctx, matchId, after, limit)
+
+	return args.Get(0).([]Update), args.Error(1)
+}
+
+func (m *repositoryMock) getMatch(ctx context.Context, id string) (matches.Match, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(matches.Match), args.Error(1)
+}
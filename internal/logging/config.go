@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"fmt"
+	"github.com/caarlos0/env/v11"
+	"log/slog"
+)
+
+type Config struct {
+	Level  string `env:"LOG_LEVEL" envDefault:"info"`
+	Format string `env:"LOG_FORMAT" envDefault:"json"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if _, err := cfg.level(); err != nil {
+		return nil, err
+	}
+	if cfg.Format != "json" && cfg.Format != "text" {
+		return nil, fmt.Errorf("invalid LOG_FORMAT %q, use json or text", cfg.Format)
+	}
+	return &cfg, nil
+}
+
+func (c Config) level() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return 0, fmt.Errorf("invalid LOG_LEVEL %q: %w", c.Level, err)
+	}
+	return level, nil
+}
@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// UnaryInterceptor is the gRPC counterpart of RequestIDMiddleware,
+// AccessLogMiddleware and RecoveryMiddleware: it tags the call with the
+// x-request-id metadata or a new id, turns panics into Internal errors and
+// logs one record per call.
+func UnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx = withCallRequestID(ctx)
+		start := time.Now()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorContext(ctx, "panic recovered", "panic", r)
+				err = status.Error(codes.Internal, "internal error")
+			}
+			logCall(ctx, logger, info.FullMethod, start, err)
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods.
+func StreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := withCallRequestID(stream.Context())
+		start := time.Now()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorContext(ctx, "panic recovered", "panic", r)
+				err = status.Error(codes.Internal, "internal error")
+			}
+			logCall(ctx, logger, info.FullMethod, start, err)
+		}()
+
+		return handler(srv, requestIDStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// withCallRequestID stores the request id of the call in ctx and echoes it in
+// the response headers.
+func withCallRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(strings.ToLower(RequestIDHeader)); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" || len(id) > 128 {
+		id = newRequestID()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RequestIDHeader), id))
+
+	return WithRequestID(ctx, id)
+}
+
+// logCall logs at warn level for errors the caller is responsible for and at
+// error level for the others.
+func logCall(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented, codes.DeadlineExceeded:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("client_ip", p.Addr.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("errors", status.Convert(err).Message()))
+	}
+
+	logger.LogAttrs(ctx, level, "call", attrs...)
+}
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s requestIDStream) Context() context.Context {
+	return s.ctx
+}
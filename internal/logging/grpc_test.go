@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       grpc.UnaryHandler
+		expectedCode  codes.Code
+		expectedLevel string
+	}{
+		{
+			name:          "when call succeeds",
+			handler:       func(ctx context.Context, req any) (any, error) { return nil, nil },
+			expectedCode:  codes.OK,
+			expectedLevel: "INFO",
+		},
+		{
+			name: "when call is invalid",
+			handler: func(ctx context.Context, req any) (any, error) {
+				return nil, status.Error(codes.NotFound, "team not found")
+			},
+			expectedCode:  codes.NotFound,
+			expectedLevel: "WARN",
+		},
+		{
+			name: "when call fails",
+			handler: func(ctx context.Context, req any) (any, error) {
+				return nil, status.Error(codes.Internal, "mongo is down")
+			},
+			expectedCode:  codes.Internal,
+			expectedLevel: "ERROR",
+		},
+		{
+			name:          "when handler panics",
+			handler:       func(ctx context.Context, req any) (any, error) { panic("boom") },
+			expectedCode:  codes.Internal,
+			expectedLevel: "ERROR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := New(&Config{Level: "info", Format: "json"}, &out)
+
+			var seen string
+			handler := func(ctx context.Context, req any) (any, error) {
+				seen = RequestID(ctx)
+				return tt.handler(ctx, req)
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc-123"))
+			_, err := UnaryInterceptor(logger)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/scinternacional.v1.Teams/GetTeam"}, handler)
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			var record map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &record))
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, "abc-123", seen)
+			assert.Equal(t, tt.expectedLevel, record["level"])
+			assert.Equal(t, "call", record["msg"])
+			assert.Equal(t, "/scinternacional.v1.Teams/GetTeam", record["method"])
+			assert.Equal(t, tt.expectedCode.String(), record["code"])
+			assert.Equal(t, "abc-123", record["request_id"])
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestID       string
+		expectGenerated bool
+	}{
+		{name: "when client sends a request id", requestID: "abc-123"},
+		{name: "when client sends no request id", requestID: "", expectGenerated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			r := gin.New()
+			r.Use(RequestIDMiddleware())
+			r.GET("/teams", func(ctx *gin.Context) { seen = RequestID(ctx.Request.Context()) })
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/teams", nil)
+			request.Header.Set(RequestIDHeader, tt.requestID)
+			r.ServeHTTP(recorder, request)
+
+			if tt.expectGenerated {
+				assert.Len(t, seen, 32)
+			} else {
+				assert.Equal(t, tt.requestID, seen)
+			}
+			assert.Equal(t, seen, recorder.Header().Get(RequestIDHeader))
+		})
+	}
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		expectedLevel string
+	}{
+		{name: "when request succeeds", status: http.StatusOK, expectedLevel: "INFO"},
+		{name: "when request is invalid", status: http.StatusBadRequest, expectedLevel: "WARN"},
+		{name: "when request fails", status: http.StatusInternalServerError, expectedLevel: "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := New(&Config{Level: "info", Format: "json"}, &out)
+
+			r := gin.New()
+			r.Use(RequestIDMiddleware(), AccessLogMiddleware(logger))
+			r.GET("/teams/:id", func(ctx *gin.Context) { ctx.Status(tt.status) })
+
+			request := httptest.NewRequest(http.MethodGet, "/teams/1", nil)
+			request.Header.Set(RequestIDHeader, "abc-123")
+			r.ServeHTTP(httptest.NewRecorder(), request)
+
+			var record map[string]interface{}
+			assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+			assert.Equal(t, tt.expectedLevel, record["level"])
+			assert.Equal(t, "request", record["msg"])
+			assert.Equal(t, "/teams/:id", record["route"])
+			assert.Equal(t, float64(tt.status), record["status"])
+			assert.Equal(t, "abc-123", record["request_id"])
+		})
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&Config{Level: "info", Format: "json"}, &out)
+
+	r := gin.New()
+	r.Use(RequestIDMiddleware(), RecoveryMiddleware(logger))
+	r.GET("/teams", func(ctx *gin.Context) { panic("boom") })
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/teams", nil))
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, "panic recovered", record["msg"])
+	assert.Equal(t, "boom", record["panic"])
+	assert.NotEmpty(t, record["request_id"])
+}
+
+func TestNew_Level(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&Config{Level: "warn", Format: "text"}, &out)
+
+	logger.Info("ignored")
+	logger.Warn("kept")
+
+	assert.NotContains(t, out.String(), "ignored")
+	assert.Contains(t, out.String(), "level=WARN msg=kept")
+	slog.SetDefault(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+}
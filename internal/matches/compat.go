@@ -0,0 +1,154 @@
+package matches
+
+import (
+	"bytes"
+	"encoding/json"
+	"sc-internacional/internal/apiversion"
+	"time"
+)
+
+// Matches used snake_case names before the API settled on camelCase. v1
+// still renders them, and decoding accepts them, so that v1 clients and the
+// events, cache entries and dumps written before keep working.
+var (
+	v1MatchNames = map[string]string{
+		"team_home_id":    "teamHomeId",
+		"team_away_id":    "teamAwayId",
+		"team_home_name":  "teamHomeName",
+		"team_away_name":  "teamAwayName",
+		"team_home_score": "teamHomeScore",
+		"team_away_score": "teamAwayScore",
+		"match_date":      "matchDate",
+		"championship_id": "championshipId",
+		"deleted_at":      "deletedAt",
+	}
+	v1IncidentNames = map[string]string{"team_id": "teamId"}
+	v1CSVHeader     = []string{"id", "championship_id", "match_date", "team_home_id", "team_home_name", "team_home_score", "team_away_score", "team_away_name", "team_away_id"}
+)
+
+func init() {
+	apiversion.Register(apiversion.V1, func(m Match) interface{} { return ToV1(m) })
+}
+
+// V1Incident is an incident in the shape v1 renders it.
+type V1Incident struct {
+	Minute int          `json:"minute"`
+	Type   IncidentType `json:"type"`
+	TeamId string       `json:"team_id"`
+	Player string       `json:"player,omitempty"`
+}
+
+// V1Match is a match in the shape v1 renders it.
+type V1Match struct {
+	Id             string       `json:"id,omitempty"`
+	TeamHomeId     string       `json:"team_home_id"`
+	TeamAwayId     string       `json:"team_away_id"`
+	TeamHomeName   string       `json:"team_home_name"`
+	TeamAwayName   string       `json:"team_away_name"`
+	TeamHomeScore  int          `json:"team_home_score"`
+	TeamAwayScore  int          `json:"team_away_score"`
+	MatchDate      time.Time    `json:"match_date"`
+	ChampionshipId string       `json:"championship_id"`
+	Status         Status       `json:"status,omitempty"`
+	Incidents      []V1Incident `json:"incidents,omitempty"`
+	Version        int64        `json:"version,omitempty"`
+	DeletedAt      *time.Time   `json:"deleted_at,omitempty"`
+}
+
+func ToV1(m Match) V1Match {
+	var incidents []V1Incident
+	for _, incident := range m.Incidents {
+		incidents = append(incidents, V1Incident(incident))
+	}
+
+	return V1Match{
+		Id:             m.Id,
+		TeamHomeId:     m.TeamHomeId,
+		TeamAwayId:     m.TeamAwayId,
+		TeamHomeName:   m.TeamHomeName,
+		TeamAwayName:   m.TeamAwayName,
+		TeamHomeScore:  m.TeamHomeScore,
+		TeamAwayScore:  m.TeamAwayScore,
+		MatchDate:      m.MatchDate,
+		ChampionshipId: m.ChampionshipId,
+		Status:         m.Status,
+		Incidents:      incidents,
+		Version:        m.Version,
+		DeletedAt:      m.DeletedAt,
+	}
+}
+
+func (m *Match) UnmarshalJSON(data []byte) error {
+	type match Match
+	return unmarshalRenaming(championshipIdOnly(data), (*match)(m), v1MatchNames)
+}
+
+// championshipIdOnly replaces a championship embedded whole as the
+// championship id of the match in data, as the first Match type held it,
+// with the id of that championship.
+func championshipIdOnly(data []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return data
+	}
+
+	replaced := false
+	for _, name := range []string{"championship_id", "championshipId"} {
+		var championship struct {
+			Id string `json:"id"`
+		}
+		value, ok := fields[name]
+		if !ok || !bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) || json.Unmarshal(value, &championship) != nil {
+			continue
+		}
+		fields[name], _ = json.Marshal(championship.Id)
+		replaced = true
+	}
+	if !replaced {
+		return data
+	}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+
+	return encoded
+}
+
+func (i *Incident) UnmarshalJSON(data []byte) error {
+	type incident Incident
+	return unmarshalRenaming(data, (*incident)(i), v1IncidentNames)
+}
+
+// unmarshalRenaming decodes the JSON object in data into v after renaming
+// its fields as names says. A field already present under its new name wins.
+func unmarshalRenaming(data []byte, v interface{}, names map[string]string) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		// Not an object: let v report what is wrong with it.
+		return json.Unmarshal(data, v)
+	}
+
+	renamed := false
+	for old, name := range names {
+		value, ok := fields[old]
+		if !ok {
+			continue
+		}
+		if _, ok = fields[name]; !ok {
+			fields[name] = value
+		}
+		delete(fields, old)
+		renamed = true
+	}
+
+	if renamed {
+		var err error
+		if data, err = json.Marshal(fields); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, v)
+}
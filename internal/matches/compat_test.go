@@ -0,0 +1,78 @@
+package matches
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"sc-internacional/internal/apiversion"
+	"testing"
+	"time"
+)
+
+func TestMatch_UnmarshalJSON(t *testing.T) {
+	expected := Match{
+		TeamHomeId:     "670000000000000000000001",
+		TeamAwayId:     "670000000000000000000005",
+		TeamHomeName:   "Internacional",
+		TeamAwayName:   "Barcelona",
+		TeamHomeScore:  1,
+		MatchDate:      time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC),
+		ChampionshipId: "671000000000000000000003",
+		Incidents:      []Incident{{Minute: 82, Type: Goal, TeamId: "670000000000000000000001", Player: "Adriano Gabiru"}},
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "when the names are camelCase",
+			body: `{"teamHomeId":"670000000000000000000001","teamAwayId":"670000000000000000000005","teamHomeName":"Internacional","teamAwayName":"Barcelona","teamHomeScore":1,"teamAwayScore":0,"matchDate":"2006-12-17T00:00:00Z","championshipId":"671000000000000000000003","incidents":[{"minute":82,"type":"goal","teamId":"670000000000000000000001","player":"Adriano Gabiru"}]}`,
+		},
+		{
+			name: "when the names are the snake_case ones of v1",
+			body: `{"team_home_id":"670000000000000000000001","team_away_id":"670000000000000000000005","team_home_name":"Internacional","team_away_name":"Barcelona","team_home_score":1,"team_away_score":0,"match_date":"2006-12-17T00:00:00Z","championship_id":"671000000000000000000003","incidents":[{"minute":82,"type":"goal","team_id":"670000000000000000000001","player":"Adriano Gabiru"}]}`,
+		},
+		{
+			name: "when the championship is embedded whole, as the first shape had it",
+			body: `{"team_home_id":"670000000000000000000001","team_away_id":"670000000000000000000005","team_home_name":"Internacional","team_away_name":"Barcelona","team_home_score":1,"team_away_score":0,"match_date":"2006-12-17T00:00:00Z","championship_id":{"id":"671000000000000000000003","name":"FIFA Club World Cup"},"incidents":[{"minute":82,"type":"goal","team_id":"670000000000000000000001","player":"Adriano Gabiru"}]}`,
+		},
+		{
+			name: "when both are set the camelCase one wins",
+			body: `{"teamHomeId":"670000000000000000000001","team_home_id":"670000000000000000000009","teamAwayId":"670000000000000000000005","teamHomeName":"Internacional","teamAwayName":"Barcelona","teamHomeScore":1,"matchDate":"2006-12-17T00:00:00Z","championshipId":"671000000000000000000003","incidents":[{"minute":82,"type":"goal","teamId":"670000000000000000000001","player":"Adriano Gabiru"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var match Match
+			assert.NoError(t, json.Unmarshal([]byte(tt.body), &match))
+			assert.Equal(t, expected, match)
+		})
+	}
+
+	var match Match
+	assert.Error(t, json.Unmarshal([]byte(`"internacional"`), &match))
+}
+
+func TestToV1(t *testing.T) {
+	match := Match{
+		Id:             "672000000000000000000005",
+		TeamHomeId:     "670000000000000000000001",
+		TeamAwayId:     "670000000000000000000005",
+		TeamHomeName:   "Internacional",
+		TeamAwayName:   "Barcelona",
+		TeamHomeScore:  1,
+		MatchDate:      time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC),
+		ChampionshipId: "671000000000000000000003",
+		Incidents:      []Incident{{Minute: 82, Type: Goal, TeamId: "670000000000000000000001"}},
+		Version:        2,
+	}
+
+	v1, err := json.Marshal(apiversion.Render(apiversion.V1, match))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"672000000000000000000005","team_home_id":"670000000000000000000001","team_away_id":"670000000000000000000005","team_home_name":"Internacional","team_away_name":"Barcelona","team_home_score":1,"team_away_score":0,"match_date":"2006-12-17T00:00:00Z","championship_id":"671000000000000000000003","incidents":[{"minute":82,"type":"goal","team_id":"670000000000000000000001"}],"version":2}`, string(v1))
+
+	v2, err := json.Marshal(apiversion.Render(apiversion.V2, match))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"672000000000000000000005","teamHomeId":"670000000000000000000001","teamAwayId":"670000000000000000000005","teamHomeName":"Internacional","teamAwayName":"Barcelona","teamHomeScore":1,"teamAwayScore":0,"matchDate":"2006-12-17T00:00:00Z","championshipId":"671000000000000000000003","incidents":[{"minute":82,"type":"goal","teamId":"670000000000000000000001"}],"version":2}`, string(v2))
+}
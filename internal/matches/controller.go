@@ -0,0 +1,201 @@
+package matches
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/export"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+)
+
+type service interface {
+	createMatch(ctx context.Context, match Match) (Match, error)
+	getMatch(ctx context.Context, id string, includeDeleted bool) (Match, error)
+	updateMatch(ctx context.Context, id string, match Match, version int64) (Match, error)
+	deleteMatch(ctx context.Context, id string) (Match, error)
+	restoreMatch(ctx context.Context, id string) (Match, error)
+	purgeMatch(ctx context.Context, id string) (Match, error)
+	streamMatches(ctx context.Context, fn func(Match) error) error
+}
+
+type Controller struct {
+	service service
+}
+
+func NewController(service service) *Controller {
+	return &Controller{service: service}
+}
+
+func (c Controller) PostMatch(ctx *gin.Context) {
+	var req Match
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	match, err := c.service.createMatch(ctx.Request.Context(), req)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	ctx.Header("ETag", etag.Of(match.Version))
+	apiversion.JSON(ctx, http.StatusCreated, match)
+}
+
+func (c Controller) GetMatch(ctx *gin.Context) {
+	includeDeleted, err := auth.AdminFlag(ctx, "includeDeleted")
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	match, err := c.service.getMatch(ctx.Request.Context(), ctx.Param("id"), includeDeleted)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if match.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("match not found")))
+		return
+	}
+
+	tag := etag.Of(match.Version)
+	ctx.Header("ETag", tag)
+	if etag.Match(ctx.GetHeader("If-None-Match"), tag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, match)
+}
+
+// PutMatch replaces a match. The If-Match header must carry the ETag the
+// caller last read, so that two editors correcting the same score cannot
+// silently overwrite each other.
+func (c Controller) PutMatch(ctx *gin.Context) {
+	version, err := etag.Version(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(etag.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	var req Match
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	match, err := c.service.updateMatch(ctx.Request.Context(), ctx.Param("id"), req, version)
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if match.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("match not found")))
+		return
+	}
+
+	ctx.Header("ETag", etag.Of(match.Version))
+	apiversion.JSON(ctx, http.StatusOK, match)
+}
+
+// DeleteMatch soft deletes the match, or removes it for good when an admin
+// passes ?hard=true.
+func (c Controller) DeleteMatch(ctx *gin.Context) {
+	hard, err := auth.AdminFlag(ctx, "hard")
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	remove := c.service.deleteMatch
+	if hard {
+		remove = c.service.purgeMatch
+	}
+
+	match, err := remove(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if match.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("match not found")))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c Controller) RestoreMatch(ctx *gin.Context) {
+	match, err := c.service.restoreMatch(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if match.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("match not found")))
+		return
+	}
+
+	ctx.Header("ETag", etag.Of(match.Version))
+	apiversion.JSON(ctx, http.StatusOK, match)
+}
+
+func (c Controller) ExportMatches(ctx *gin.Context) {
+	format, err := export.NegotiateFormat(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusNotAcceptable, errorResponse(ctx, err))
+		return
+	}
+
+	version := apiversion.From(ctx)
+	header := csvHeader
+	if version == apiversion.V1 {
+		header = v1CSVHeader
+	}
+
+	encoder := export.NewEncoder(ctx.Writer, format, "matches", header)
+	err = c.service.streamMatches(ctx.Request.Context(), func(match Match) error {
+		return encoder.Encode(apiversion.Render(version, match), match.csvRecord())
+	})
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+			return
+		}
+		ctx.Error(err)
+		return
+	}
+
+	// The status is sent already, so a failure to finish the document can
+	// only be logged.
+	if err = encoder.Close(); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
@@ -0,0 +1,284 @@
+package matches
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sc-internacional/internal/etag"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestController_PostMatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		requestBody        string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when request is invalid",
+			setup:              func(s *serviceMock) {},
+			requestBody:        "{\"team_home_score\": -1}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "when failed to create a match",
+			setup: func(s *serviceMock) {
+				s.On("createMatch", mock.Anything, mock.Anything).Return(Match{}, errors.New("failed to create match"))
+			},
+			requestBody:        "{\"team_home_id\": \"1\", \"team_away_id\": \"2\", \"team_home_name\": \"Internacional\", \"team_away_name\": \"Barcelona\", \"team_home_score\": 1, \"team_away_score\": 0, \"match_date\": \"2006-12-17T00:00:00Z\", \"championship_id\": \"3\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to create match\"}",
+		},
+		{
+			name: "when successfully creates a match",
+			setup: func(s *serviceMock) {
+				received := Match{TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", TeamHomeScore: 1, TeamAwayScore: 0, MatchDate: time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC), ChampionshipId: "3"}
+				created := received
+				created.Id = "10"
+				s.On("createMatch", mock.Anything, received).Return(created, nil)
+			},
+			requestBody:        "{\"team_home_id\": \"1\", \"team_away_id\": \"2\", \"team_home_name\": \"Internacional\", \"team_away_name\": \"Barcelona\", \"team_home_score\": 1, \"team_away_score\": 0, \"match_date\": \"2006-12-17T00:00:00Z\", \"championship_id\": \"3\"}",
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       "{\"id\":\"10\",\"team_home_id\":\"1\",\"team_away_id\":\"2\",\"team_home_name\":\"Internacional\",\"team_away_name\":\"Barcelona\",\"team_home_score\":1,\"team_away_score\":0,\"match_date\":\"2006-12-17T00:00:00Z\",\"championship_id\":\"3\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Body:   io.NopCloser(bytes.NewBuffer([]byte(tt.requestBody))),
+			}
+
+			c.PostMatch(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestController_GetMatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when failed to get match",
+			setup: func(s *serviceMock) {
+				s.On("getMatch", mock.Anything, "10", false).Return(Match{}, errors.New("failed to get match"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to get match\"}",
+		},
+		{
+			name: "when match is not found",
+			setup: func(s *serviceMock) {
+				s.On("getMatch", mock.Anything, "10", false).Return(Match{}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"match not found\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "10")
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/matches/10", nil)
+
+			c.GetMatch(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_PutMatch(t *testing.T) {
+	requestBody := "{\"team_home_id\": \"1\", \"team_away_id\": \"2\", \"team_home_name\": \"Internacional\", \"team_away_name\": \"Barcelona\", \"team_home_score\": 1, \"team_away_score\": 0, \"match_date\": \"2006-12-17T00:00:00Z\", \"championship_id\": \"3\"}"
+	received := Match{TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", TeamHomeScore: 1, TeamAwayScore: 0, MatchDate: time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC), ChampionshipId: "3"}
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		ifMatch            string
+		expectedStatusCode int
+		expectedETag       string
+		expectedBody       string
+	}{
+		{
+			name:               "when If-Match is missing",
+			setup:              func(s *serviceMock) {},
+			expectedStatusCode: http.StatusPreconditionRequired,
+			expectedBody:       "{\"error\":\"If-Match header is required\"}",
+		},
+		{
+			name:               "when If-Match is not an entity tag",
+			setup:              func(s *serviceMock) {},
+			ifMatch:            "1",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"If-Match header must be a single entity tag\"}",
+		},
+		{
+			name: "when match changed since it was read",
+			setup: func(s *serviceMock) {
+				s.On("updateMatch", mock.Anything, "10", received, int64(1)).Return(Match{}, etag.ErrMismatch)
+			},
+			ifMatch:            "\"1\"",
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedBody:       "{\"error\":\"the resource was modified since it was read\"}",
+		},
+		{
+			name: "when successfully updates a match",
+			setup: func(s *serviceMock) {
+				updated := received
+				updated.Id = "10"
+				updated.Version = 2
+				s.On("updateMatch", mock.Anything, "10", received, int64(1)).Return(updated, nil)
+			},
+			ifMatch:            "\"1\"",
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       "\"2\"",
+			expectedBody:       "{\"id\":\"10\",\"team_home_id\":\"1\",\"team_away_id\":\"2\",\"team_home_name\":\"Internacional\",\"team_away_name\":\"Barcelona\",\"team_home_score\":1,\"team_away_score\":0,\"match_date\":\"2006-12-17T00:00:00Z\",\"championship_id\":\"3\",\"version\":2}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "10")
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/matches/10", strings.NewReader(requestBody))
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			c.PutMatch(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedETag, recorder.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_DeleteMatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when match is not found",
+			setup: func(s *serviceMock) {
+				s.On("deleteMatch", mock.Anything, "10").Return(Match{}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"match not found\"}",
+		},
+		{
+			name: "when successfully deletes a match",
+			setup: func(s *serviceMock) {
+				s.On("deleteMatch", mock.Anything, "10").Return(Match{Id: "10"}, nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "10")
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/matches/10", nil)
+
+			c.DeleteMatch(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) createMatch(ctx context.Context, match Match) (Match, error) {
+	args := m.Called(ctx, match)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *serviceMock) getMatch(ctx context.Context, id string, includeDeleted bool) (Match, error) {
+	args := m.Called(ctx, id, includeDeleted)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *serviceMock) updateMatch(ctx context.Context, id string, match Match, version int64) (Match, error) {
+	args := m.Called(ctx, id, match, version)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *serviceMock) deleteMatch(ctx context.Context, id string) (Match, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *serviceMock) restoreMatch(ctx context.Context, id string) (Match, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *serviceMock) purgeMatch(ctx context.Context, id string) (Match, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *serviceMock) streamMatches(ctx context.Context, fn func(Match) error) error {
+	args := m.Called(ctx, fn)
+
+	return args.Error(0)
+}
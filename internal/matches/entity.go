@@ -1,18 +1,86 @@
 package matches
 
 import (
-	"sc-internacional/internal/championships"
+	"strconv"
 	"time"
 )
 
+var csvHeader = []string{"id", "championshipId", "matchDate", "teamHomeId", "teamHomeName", "teamHomeScore", "teamAwayScore", "teamAwayName", "teamAwayId"}
+
+// Status is where a match stands on its day. Matches recorded before it
+// existed have none, see StatusAt.
+type Status string
+
+const (
+	Scheduled  Status = "scheduled"
+	InProgress Status = "in_progress"
+	Finished   Status = "finished"
+)
+
+// IncidentType is something worth telling about a match while it is played.
+type IncidentType string
+
+const (
+	Goal       IncidentType = "goal"
+	OwnGoal    IncidentType = "own_goal"
+	YellowCard IncidentType = "yellow_card"
+	RedCard    IncidentType = "red_card"
+)
+
+type Incident struct {
+	Minute int          `json:"minute" binding:"min=0,max=130"`
+	Type   IncidentType `json:"type" binding:"required,oneof=goal own_goal yellow_card red_card"`
+	TeamId string       `json:"teamId" binding:"required"`
+	Player string       `json:"player,omitempty" bson:",omitempty"`
+}
+
 type Match struct {
-	Id             string                     `json:"id,omitempty"`
-	TeamHomeId     string                     `json:"team_home_id" binding:"required"`
-	TeamAwayId     string                     `json:"team_away_id" binding:"required"`
-	TeamHomeName   string                     `json:"team_home_name" binding:"required"`
-	TeamAwayName   string                     `json:"team_away_name" binding:"required"`
-	TeamHomeScore  int                        `json:"team_home_score" binding:"required"`
-	TeamAwayScore  int                        `json:"team_away_score" binding:"required"`
-	MatchDate      time.Time                  `json:"match_date" binding:"required"`
-	ChampionshipId championships.Championship `json:"championship_id" binding:"required"`
+	Id             string     `json:"id,omitempty" bson:"_id,omitempty"`
+	TeamHomeId     string     `json:"teamHomeId" binding:"required"`
+	TeamAwayId     string     `json:"teamAwayId" binding:"required"`
+	TeamHomeName   string     `json:"teamHomeName" binding:"required"`
+	TeamAwayName   string     `json:"teamAwayName" binding:"required"`
+	TeamHomeScore  int        `json:"teamHomeScore" binding:"min=0"`
+	TeamAwayScore  int        `json:"teamAwayScore" binding:"min=0"`
+	MatchDate      time.Time  `json:"matchDate" binding:"required"`
+	ChampionshipId string     `json:"championshipId" binding:"required"`
+	Status         Status     `json:"status,omitempty" bson:",omitempty" binding:"omitempty,oneof=scheduled in_progress finished"`
+	Incidents      []Incident `json:"incidents,omitempty" bson:",omitempty" binding:"dive"`
+	Version        int64      `json:"version,omitempty"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" bson:",omitempty"`
+}
+
+func (m *Match) csvRecord() []string {
+	return []string{
+		m.Id,
+		m.ChampionshipId,
+		m.MatchDate.Format(time.RFC3339),
+		m.TeamHomeId,
+		m.TeamHomeName,
+		strconv.Itoa(m.TeamHomeScore),
+		strconv.Itoa(m.TeamAwayScore),
+		m.TeamAwayName,
+		m.TeamAwayId,
+	}
+}
+
+func (m *Match) isEmpty() bool {
+	return m.Id == ""
+}
+
+func (m *Match) isDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+// StatusAt returns the status of the match, or for a match without one,
+// scheduled until its date and finished after it.
+func (m *Match) StatusAt(now time.Time) Status {
+	if m.Status != "" {
+		return m.Status
+	}
+	if m.MatchDate.After(now) {
+		return Scheduled
+	}
+
+	return Finished
 }
@@ -0,0 +1,190 @@
+package matches
+
+import (
+	"context"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/pb"
+	"sc-internacional/internal/rpc"
+	"time"
+)
+
+var errNotFound = status.Error(codes.NotFound, "match not found")
+
+// GRPCServer serves the Matches gRPC service through the same service as
+// Controller, so that both APIs validate, audit and publish alike.
+type GRPCServer struct {
+	pb.UnimplementedMatchesServer
+	service service
+}
+
+func NewGRPCServer(service service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+func (s GRPCServer) GetMatch(ctx context.Context, req *pb.GetMatchRequest) (*pb.Match, error) {
+	if err := auth.AdminOnly(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	match, err := s.service.getMatch(ctx, req.GetId(), req.GetIncludeDeleted())
+	if err != nil {
+		return nil, rpc.Error(err)
+	}
+
+	if match.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return match.proto(), nil
+}
+
+// ListMatches streams the matches straight from the database cursor, like
+// the export does.
+func (s GRPCServer) ListMatches(req *pb.ListMatchesRequest, stream grpc.ServerStreamingServer[pb.Match]) error {
+	err := s.service.streamMatches(stream.Context(), func(match Match) error {
+		return stream.Send(match.proto())
+	})
+
+	return rpc.Error(err)
+}
+
+func (s GRPCServer) CreateMatch(ctx context.Context, req *pb.CreateMatchRequest) (*pb.Match, error) {
+	match := fromProto(req.GetMatch())
+	if err := binding.Validator.ValidateStruct(&match); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	createdMatch, err := s.service.createMatch(ctx, match)
+	if err != nil {
+		return nil, rpc.Error(err)
+	}
+
+	return createdMatch.proto(), nil
+}
+
+// UpdateMatch replaces a match. Like If-Match over REST, the request must
+// carry the version the caller last read.
+func (s GRPCServer) UpdateMatch(ctx context.Context, req *pb.UpdateMatchRequest) (*pb.Match, error) {
+	version, err := rpc.ExpectedVersion(req.Version, "match")
+	if err != nil {
+		return nil, err
+	}
+
+	match := fromProto(req.GetMatch())
+	if err = binding.Validator.ValidateStruct(&match); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	updatedMatch, err := s.service.updateMatch(ctx, req.GetId(), match, version)
+	if err != nil {
+		return nil, rpc.Error(err)
+	}
+
+	if updatedMatch.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return updatedMatch.proto(), nil
+}
+
+// DeleteMatch soft deletes the match, or removes it for good when an admin
+// sets hard.
+func (s GRPCServer) DeleteMatch(ctx context.Context, req *pb.DeleteMatchRequest) (*pb.DeleteMatchResponse, error) {
+	if err := auth.AdminOnly(ctx, req.GetHard()); err != nil {
+		return nil, err
+	}
+
+	remove := s.service.deleteMatch
+	if req.GetHard() {
+		remove = s.service.purgeMatch
+	}
+
+	match, err := remove(ctx, req.GetId())
+	if err != nil {
+		return nil, rpc.Error(err)
+	}
+
+	if match.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return &pb.DeleteMatchResponse{}, nil
+}
+
+func (s GRPCServer) RestoreMatch(ctx context.Context, req *pb.RestoreMatchRequest) (*pb.Match, error) {
+	match, err := s.service.restoreMatch(ctx, req.GetId())
+	if err != nil {
+		return nil, rpc.Error(err)
+	}
+
+	if match.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return match.proto(), nil
+}
+
+func (m *Match) proto() *pb.Match {
+	match := &pb.Match{
+		Id:             m.Id,
+		TeamHomeId:     m.TeamHomeId,
+		TeamAwayId:     m.TeamAwayId,
+		TeamHomeName:   m.TeamHomeName,
+		TeamAwayName:   m.TeamAwayName,
+		TeamHomeScore:  int32(m.TeamHomeScore),
+		TeamAwayScore:  int32(m.TeamAwayScore),
+		MatchDate:      timestamppb.New(m.MatchDate),
+		ChampionshipId: m.ChampionshipId,
+		Status:         string(m.Status),
+		Version:        m.Version,
+	}
+	for _, incident := range m.Incidents {
+		match.Incidents = append(match.Incidents, &pb.Incident{
+			Minute: int32(incident.Minute),
+			Type:   string(incident.Type),
+			TeamId: incident.TeamId,
+			Player: incident.Player,
+		})
+	}
+	if m.DeletedAt != nil {
+		match.DeletedAt = timestamppb.New(*m.DeletedAt)
+	}
+
+	return match
+}
+
+// fromProto converts the fields of a match message a client may set.
+func fromProto(match *pb.Match) Match {
+	var matchDate time.Time
+	if match.GetMatchDate() != nil {
+		matchDate = match.GetMatchDate().AsTime()
+	}
+
+	var incidents []Incident
+	for _, incident := range match.GetIncidents() {
+		incidents = append(incidents, Incident{
+			Minute: int(incident.GetMinute()),
+			Type:   IncidentType(incident.GetType()),
+			TeamId: incident.GetTeamId(),
+			Player: incident.GetPlayer(),
+		})
+	}
+
+	return Match{
+		TeamHomeId:     match.GetTeamHomeId(),
+		TeamAwayId:     match.GetTeamAwayId(),
+		TeamHomeName:   match.GetTeamHomeName(),
+		TeamAwayName:   match.GetTeamAwayName(),
+		TeamHomeScore:  int(match.GetTeamHomeScore()),
+		TeamAwayScore:  int(match.GetTeamAwayScore()),
+		MatchDate:      matchDate,
+		ChampionshipId: match.GetChampionshipId(),
+		Status:         Status(match.GetStatus()),
+		Incidents:      incidents,
+	}
+}
@@ -0,0 +1,177 @@
+package matches
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"net"
+	"sc-internacional/internal/pb"
+	"testing"
+	"time"
+)
+
+func TestGRPCServer_CreateMatch(t *testing.T) {
+	matchDate := time.Date(2024, time.May, 1, 21, 30, 0, 0, time.UTC)
+	request := func(incidentType string) *pb.CreateMatchRequest {
+		return &pb.CreateMatchRequest{Match: &pb.Match{
+			TeamHomeId:     "1",
+			TeamAwayId:     "2",
+			TeamHomeName:   "Internacional",
+			TeamAwayName:   "Juventude",
+			TeamHomeScore:  2,
+			MatchDate:      timestamppb.New(matchDate),
+			ChampionshipId: "10",
+			Status:         "in_progress",
+			Incidents:      []*pb.Incident{{Minute: 12, Type: incidentType, TeamId: "1", Player: "Alan Patrick"}},
+		}}
+	}
+	match := Match{
+		TeamHomeId:     "1",
+		TeamAwayId:     "2",
+		TeamHomeName:   "Internacional",
+		TeamAwayName:   "Juventude",
+		TeamHomeScore:  2,
+		MatchDate:      matchDate,
+		ChampionshipId: "10",
+		Status:         InProgress,
+		Incidents:      []Incident{{Minute: 12, Type: Goal, TeamId: "1", Player: "Alan Patrick"}},
+	}
+
+	tests := []struct {
+		name          string
+		setup         func(*serviceMock)
+		request       *pb.CreateMatchRequest
+		expectedCode  codes.Code
+		expectedMatch *pb.Match
+	}{
+		{
+			name:         "when incident type is unknown",
+			setup:        func(s *serviceMock) {},
+			request:      request("penalty"),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "when failed to create a match",
+			setup: func(s *serviceMock) {
+				s.On("createMatch", mock.Anything, match).Return(Match{}, errors.New("failed to insert"))
+			},
+			request:      request("goal"),
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "when successfully creates a match",
+			setup: func(s *serviceMock) {
+				createdMatch := match
+				createdMatch.Id = "100"
+				createdMatch.Version = 1
+				s.On("createMatch", mock.Anything, match).Return(createdMatch, nil)
+			},
+			request:      request("goal"),
+			expectedCode: codes.OK,
+			expectedMatch: func() *pb.Match {
+				m := request("goal").Match
+				m.Id = "100"
+				m.Version = 1
+				return m
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			got, err := NewGRPCServer(s).CreateMatch(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.True(t, proto.Equal(tt.expectedMatch, got))
+		})
+	}
+}
+
+func TestGRPCServer_ListMatches(t *testing.T) {
+	matches := []Match{
+		{Id: "100", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Juventude", ChampionshipId: "10"},
+		{Id: "101", TeamHomeId: "3", TeamAwayId: "1", TeamHomeName: "Caxias", TeamAwayName: "Internacional", ChampionshipId: "10"},
+	}
+
+	tests := []struct {
+		name         string
+		setup        func(*serviceMock)
+		expectedCode codes.Code
+		expectedIds  []string
+	}{
+		{
+			name: "when failed to stream matches",
+			setup: func(s *serviceMock) {
+				s.On("streamMatches", mock.Anything, mock.Anything).Return(errors.New("failed to find"))
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "when successfully streams matches",
+			setup: func(s *serviceMock) {
+				s.On("streamMatches", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					for _, match := range matches {
+						args.Get(1).(func(Match) error)(match)
+					}
+				}).Return(nil)
+			},
+			expectedCode: codes.OK,
+			expectedIds:  []string{"100", "101"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			stream, err := dial(t, s).ListMatches(context.Background(), &pb.ListMatchesRequest{})
+			assert.NoError(t, err)
+
+			var ids []string
+			code := codes.OK
+			for {
+				match, err := stream.Recv()
+				if err != nil {
+					if err != io.EOF {
+						code = status.Code(err)
+					}
+					break
+				}
+				ids = append(ids, match.Id)
+			}
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedIds, ids)
+		})
+	}
+}
+
+// dial serves s over an in-memory connection, for the streaming methods.
+func dial(t *testing.T, s service) pb.MatchesClient {
+	server := grpc.NewServer()
+	pb.RegisterMatchesServer(server, NewGRPCServer(s))
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewMatchesClient(conn)
+}
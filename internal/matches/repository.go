@@ -0,0 +1,179 @@
+package matches
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/clients/mongodb"
+	"sc-internacional/internal/tracing"
+)
+
+type db interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+type Repository struct {
+	db
+}
+
+func NewRepository(db db) *Repository {
+	return &Repository{db}
+}
+
+func (r Repository) createMatch(ctx context.Context, match Match) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Repository.createMatch")
+	defer span.End()
+
+	match.Version = 1
+	result, err := r.db.InsertOne(ctx, match)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	match.Id = result.InsertedID.(primitive.ObjectID).Hex()
+
+	return match, nil
+}
+
+func (r Repository) getMatch(ctx context.Context, id string, includeDeleted bool) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Repository.getMatch")
+	defer span.End()
+
+	var match Match
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	err = r.db.FindOne(ctx, mongodb.NotDeleted(bson.M{"_id": docID}, includeDeleted)).Decode(&match)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Match{}, nil
+	}
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	return match, nil
+}
+
+// updateMatch replaces the stored match with the same id and version,
+// bumping the version. It returns an empty match when there is no such match
+// at that version.
+func (r Repository) updateMatch(ctx context.Context, match Match) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Repository.updateMatch")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(match.Id)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	replacement := match
+	replacement.Id = ""
+	replacement.Version = match.Version + 1
+
+	result, err := r.db.ReplaceOne(ctx, bson.M{"_id": docID, "version": mongodb.AtVersion(match.Version)}, replacement)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return Match{}, nil
+	}
+
+	match.Version = replacement.Version
+
+	return match, nil
+}
+
+// deleteMatch removes the document for good. Soft deletes go through
+// updateMatch with DeletedAt set.
+func (r Repository) deleteMatch(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "matches.Repository.deleteMatch")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	if _, err = r.db.DeleteOne(ctx, bson.M{"_id": docID}); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// getMatches returns the matches that are not deleted among those with the
+// given ids, those of the championships with the given ids and those either
+// of the teams with the given ids plays, by date. Empty lists match nothing.
+func (r Repository) getMatches(ctx context.Context, ids, championshipIds, teamIds []string) ([]Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Repository.getMatches")
+	defer span.End()
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"_id": bson.M{"$in": mongodb.ObjectIDs(ids)}},
+		bson.M{"championshipid": bson.M{"$in": nonNil(championshipIds)}},
+		bson.M{"teamhomeid": bson.M{"$in": nonNil(teamIds)}},
+		bson.M{"teamawayid": bson.M{"$in": nonNil(teamIds)}},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "matchdate", Value: 1}})
+	cursor, err := r.db.Find(ctx, mongodb.NotDeleted(filter, false), opts)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	matches := []Match{}
+	if err = cursor.All(ctx, &matches); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return matches, nil
+}
+
+func (r Repository) streamMatches(ctx context.Context, fn func(Match) error) error {
+	ctx, span := tracer.Start(ctx, "matches.Repository.streamMatches")
+	defer span.End()
+
+	opts := options.Find().SetSort(bson.D{{Key: "matchdate", Value: 1}})
+	cursor, err := r.db.Find(ctx, mongodb.NotDeleted(bson.M{}, false), opts)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var match Match
+		if err = cursor.Decode(&match); err != nil {
+			return tracing.Error(span, err)
+		}
+
+		if err = fn(match); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	if err = cursor.Err(); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// nonNil keeps an $in over no ids from being encoded as null, which Mongo
+// rejects.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+
+	return ids
+}
@@ -0,0 +1,331 @@
+package matches
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+const (
+	auditEntity    = "match"
+	matchKeyPrefix = "matches:"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/matches")
+
+type repository interface {
+	createMatch(ctx context.Context, match Match) (Match, error)
+	getMatch(ctx context.Context, id string, includeDeleted bool) (Match, error)
+	updateMatch(ctx context.Context, match Match) (Match, error)
+	deleteMatch(ctx context.Context, id string) error
+	streamMatches(ctx context.Context, fn func(Match) error) error
+	getMatches(ctx context.Context, ids, championshipIds, teamIds []string) ([]Match, error)
+}
+
+type auditor interface {
+	Record(ctx context.Context, action audit.Action, entity, entityId string, before, after interface{})
+}
+
+type publisher interface {
+	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
+	Publish(ctx context.Context, eventType events.Type, entityId string, previous, current interface{}) error
+}
+
+type Service struct {
+	repository repository
+	auditor    auditor
+	cache      cache.Cache
+	publisher  publisher
+	now        func() time.Time
+}
+
+func NewService(repository repository, auditor auditor, cache cache.Cache, publisher publisher) *Service {
+	return &Service{repository: repository, auditor: auditor, cache: cache, publisher: publisher, now: time.Now}
+}
+
+func (s Service) createMatch(ctx context.Context, match Match) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Service.createMatch")
+	defer span.End()
+
+	match.DeletedAt = nil
+	var createdMatch Match
+	err := s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		createdMatch, err = s.repository.createMatch(ctx, match)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, s.createdEvent(createdMatch), createdMatch.Id, nil, createdMatch)
+	})
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	s.invalidate(ctx, createdMatch.Id)
+	s.auditor.Record(ctx, audit.Create, auditEntity, createdMatch.Id, nil, createdMatch)
+
+	return createdMatch, nil
+}
+
+func (s Service) getMatch(ctx context.Context, id string, includeDeleted bool) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Service.getMatch")
+	defer span.End()
+
+	load := func(ctx context.Context) (Match, error) {
+		return s.repository.getMatch(ctx, id, includeDeleted)
+	}
+
+	var match Match
+	var err error
+	if includeDeleted {
+		// Deleted matches are only shown to admins, so that view is not cached.
+		match, err = load(ctx)
+	} else {
+		match, err = cache.Fetch(ctx, s.cache, matchKeyPrefix+id, load)
+	}
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	return match, nil
+}
+
+// GetMatches returns the matches with the given ids that exist and are not
+// deleted, by date, reading them all at once.
+func (s Service) GetMatches(ctx context.Context, ids []string) ([]Match, error) {
+	return s.getMatches(ctx, "matches.Service.GetMatches", ids, nil, nil)
+}
+
+// GetMatchesByChampionships returns the matches, by date, of the
+// championships with the given ids.
+func (s Service) GetMatchesByChampionships(ctx context.Context, championshipIds []string) ([]Match, error) {
+	return s.getMatches(ctx, "matches.Service.GetMatchesByChampionships", nil, championshipIds, nil)
+}
+
+// GetMatchesByTeams returns the matches, by date, that any of the teams with
+// the given ids plays, home or away.
+func (s Service) GetMatchesByTeams(ctx context.Context, teamIds []string) ([]Match, error) {
+	return s.getMatches(ctx, "matches.Service.GetMatchesByTeams", nil, nil, teamIds)
+}
+
+func (s Service) getMatches(ctx context.Context, spanName string, ids, championshipIds, teamIds []string) ([]Match, error) {
+	ctx, span := tracer.Start(ctx, spanName)
+	defer span.End()
+
+	matches, err := s.repository.getMatches(ctx, ids, championshipIds, teamIds)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return matches, nil
+}
+
+// updateMatch replaces the match with the given id if it is still at
+// version, or at any version when it is etag.Any. It returns an empty match
+// when the match does not exist or is deleted, and etag.ErrMismatch when it
+// changed.
+func (s Service) updateMatch(ctx context.Context, id string, match Match, version int64) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Service.updateMatch")
+	defer span.End()
+
+	before, err := s.repository.getMatch(ctx, id, false)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Match{}, nil
+	}
+	if version != etag.Any && version != before.Version {
+		return Match{}, etag.ErrMismatch
+	}
+
+	match.Id = id
+	match.Version = before.Version
+	match.DeletedAt = nil
+	var updatedMatch Match
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		updatedMatch, err = s.repository.updateMatch(ctx, match)
+		if err != nil || updatedMatch.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, s.updatedEvent(before, updatedMatch), id, before, updatedMatch)
+	})
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if updatedMatch.isEmpty() {
+		return Match{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Update, auditEntity, id, before, updatedMatch)
+
+	return updatedMatch, nil
+}
+
+// deleteMatch marks the match with the given id as deleted and returns it,
+// or an empty match when it does not exist or is already deleted.
+func (s Service) deleteMatch(ctx context.Context, id string) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Service.deleteMatch")
+	defer span.End()
+
+	before, err := s.repository.getMatch(ctx, id, false)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Match{}, nil
+	}
+
+	deletedAt := s.now().UTC().Truncate(time.Millisecond)
+	match := before
+	match.DeletedAt = &deletedAt
+
+	var deletedMatch Match
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		deletedMatch, err = s.repository.updateMatch(ctx, match)
+		if err != nil || deletedMatch.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.MatchDeleted, id, before, deletedMatch)
+	})
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if deletedMatch.isEmpty() {
+		return Match{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Delete, auditEntity, id, before, deletedMatch)
+
+	return deletedMatch, nil
+}
+
+// restoreMatch clears the deletion marker of the match with the given id. A
+// match that is not deleted is returned unchanged.
+func (s Service) restoreMatch(ctx context.Context, id string) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Service.restoreMatch")
+	defer span.End()
+
+	before, err := s.repository.getMatch(ctx, id, true)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() || !before.isDeleted() {
+		return before, nil
+	}
+
+	match := before
+	match.DeletedAt = nil
+
+	var restoredMatch Match
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		restoredMatch, err = s.repository.updateMatch(ctx, match)
+		if err != nil || restoredMatch.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.MatchRestored, id, before, restoredMatch)
+	})
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if restoredMatch.isEmpty() {
+		return Match{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Restore, auditEntity, id, before, restoredMatch)
+
+	return restoredMatch, nil
+}
+
+// purgeMatch removes the match with the given id for good, deleted or not.
+// Nothing references matches, so it is never refused.
+func (s Service) purgeMatch(ctx context.Context, id string) (Match, error) {
+	ctx, span := tracer.Start(ctx, "matches.Service.purgeMatch")
+	defer span.End()
+
+	before, err := s.repository.getMatch(ctx, id, true)
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Match{}, nil
+	}
+
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		if err := s.repository.deleteMatch(ctx, id); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.MatchPurged, id, before, nil)
+	})
+	if err != nil {
+		return Match{}, tracing.Error(span, err)
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Purge, auditEntity, id, before, nil)
+
+	return before, nil
+}
+
+// invalidate drops the cached match with the given id. Standings react to
+// the published event instead.
+func (s Service) invalidate(ctx context.Context, id string) {
+	cache.Invalidate(ctx, s.cache, matchKeyPrefix+id)
+}
+
+// createdEvent tells a match still to be played, or being played, from a
+// result being recorded.
+func (s Service) createdEvent(match Match) events.Type {
+	switch match.StatusAt(s.now()) {
+	case Finished:
+		return events.MatchFinished
+	case InProgress:
+		return events.MatchStarted
+	default:
+		return events.MatchScheduled
+	}
+}
+
+// updatedEvent tells kicking off and finishing a match from goals during
+// play and corrections to the score of a played one, and those from any
+// other edit.
+func (s Service) updatedEvent(before, after Match) events.Type {
+	now := s.now()
+	from, to := before.StatusAt(now), after.StatusAt(now)
+	switch {
+	case from != Finished && to == Finished:
+		return events.MatchFinished
+	case from == Scheduled && to == InProgress:
+		return events.MatchStarted
+	case before.TeamHomeScore == after.TeamHomeScore && before.TeamAwayScore == after.TeamAwayScore:
+		return events.MatchUpdated
+	case from == InProgress:
+		return events.MatchScoreChanged
+	case from == Finished:
+		return events.MatchScoreCorrected
+	default:
+		return events.MatchUpdated
+	}
+}
+
+func (s Service) streamMatches(ctx context.Context, fn func(Match) error) error {
+	ctx, span := tracer.Start(ctx, "matches.Service.streamMatches")
+	defer span.End()
+
+	if err := s.repository.streamMatches(ctx, fn); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
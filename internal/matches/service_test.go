@@ -0,0 +1,341 @@
+package matches
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/events"
+	"testing"
+	"time"
+)
+
+func TestService_createMatch(t *testing.T) {
+	match := Match{TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", TeamHomeScore: 1, TeamAwayScore: 0, MatchDate: time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC), ChampionshipId: "3"}
+	created := match
+	created.Id = "10"
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Match
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when repository fail to create match",
+			setup: func(r *repositoryMock) {
+				r.On("createMatch", mock.Anything, match).Return(Match{}, errors.New("failed to create match"))
+			},
+			want:    Match{},
+			wantErr: errors.New("failed to create match"),
+		},
+		{
+			name: "when repository successfully create match",
+			setup: func(r *repositoryMock) {
+				r.On("createMatch", mock.Anything, match).Return(created, nil)
+			},
+			want:      created,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Create, "match", "10", nil, created).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.createMatch(context.Background(), match)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_updateMatch(t *testing.T) {
+	before := Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", TeamHomeScore: 0, TeamAwayScore: 0, MatchDate: time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC), ChampionshipId: "3", Version: 1}
+	after := before
+	after.TeamHomeScore = 1
+	updated := after
+	updated.Version = 2
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		version   int64
+		want      Match
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when match does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(Match{}, nil)
+			},
+			version: 1,
+			want:    Match{},
+			wantErr: nil,
+		},
+		{
+			name: "when match is at another version",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(before, nil)
+			},
+			version: 3,
+			want:    Match{},
+			wantErr: etag.ErrMismatch,
+		},
+		{
+			name: "when match changes before it is replaced",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(before, nil)
+				r.On("updateMatch", mock.Anything, after).Return(Match{}, nil)
+			},
+			version: 1,
+			want:    Match{},
+			wantErr: etag.ErrMismatch,
+		},
+		{
+			name: "when repository fail to update match",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(before, nil)
+				r.On("updateMatch", mock.Anything, after).Return(Match{}, errors.New("failed to update match"))
+			},
+			version: 1,
+			want:    Match{},
+			wantErr: errors.New("failed to update match"),
+		},
+		{
+			name: "when repository successfully update match",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(before, nil)
+				r.On("updateMatch", mock.Anything, after).Return(updated, nil)
+			},
+			version:   etag.Any,
+			want:      updated,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Update, "match", "10", before, updated).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			update := after
+			update.Id = ""
+			update.Version = 0
+			got, err := s.updateMatch(context.Background(), "10", update, tt.version)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_deleteMatch(t *testing.T) {
+	now := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+	before := Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", MatchDate: time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC), ChampionshipId: "3"}
+	deleted := before
+	deleted.DeletedAt = &now
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Match
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when match does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(Match{}, nil)
+			},
+			want:    Match{},
+			wantErr: nil,
+		},
+		{
+			name: "when repository fail to mark match as deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(before, nil)
+				r.On("updateMatch", mock.Anything, deleted).Return(Match{}, errors.New("failed to update match"))
+			},
+			want:    Match{},
+			wantErr: errors.New("failed to update match"),
+		},
+		{
+			name: "when repository successfully mark match as deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getMatch", mock.Anything, "10", false).Return(before, nil)
+				r.On("updateMatch", mock.Anything, deleted).Return(deleted, nil)
+			},
+			want:      deleted,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Delete, "match", "10", before, deleted).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+			s.now = func() time.Time { return now }
+
+			got, err := s.deleteMatch(context.Background(), "10")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_restoreMatch(t *testing.T) {
+	deletedAt := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+	restored := Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", MatchDate: time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC), ChampionshipId: "3"}
+	deleted := restored
+	deleted.DeletedAt = &deletedAt
+
+	r := &repositoryMock{}
+	r.On("getMatch", mock.Anything, "10", true).Return(deleted, nil)
+	r.On("updateMatch", mock.Anything, restored).Return(restored, nil)
+	a := &auditorMock{}
+	a.On("Record", mock.Anything, audit.Restore, "match", "10", deleted, restored).Return()
+
+	s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+	got, err := s.restoreMatch(context.Background(), "10")
+
+	assert.Equal(t, restored, got)
+	assert.NoError(t, err)
+	a.AssertExpectations(t)
+}
+
+func TestService_publishesMatchEvents(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)
+	played := Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeName: "Internacional", TeamAwayName: "Barcelona", TeamHomeScore: 1, MatchDate: now.Add(-2 * time.Hour), ChampionshipId: "3", Version: 1}
+	scheduled := played
+	scheduled.TeamHomeScore = 0
+	scheduled.MatchDate = now.Add(time.Hour)
+	corrected := played
+	corrected.TeamAwayScore = 1
+	renamed := played
+	renamed.TeamHomeName = "Inter"
+	started := scheduled
+	started.Status = InProgress
+	goal := started
+	goal.TeamHomeScore = 1
+	goal.Incidents = []Incident{{Minute: 37, Type: Goal, TeamId: "1", Player: "Gabiru"}}
+	ended := goal
+	ended.Status = Finished
+	tests := []struct {
+		name   string
+		before Match
+		after  Match
+		want   events.Type
+	}{
+		{name: "when a played match is recorded", after: played, want: events.MatchFinished},
+		{name: "when a match is scheduled", after: scheduled, want: events.MatchScheduled},
+		{name: "when the result of a scheduled match is entered", before: scheduled, after: played, want: events.MatchFinished},
+		{name: "when the score of a played match is corrected", before: played, after: corrected, want: events.MatchScoreCorrected},
+		{name: "when anything else changes", before: played, after: renamed, want: events.MatchUpdated},
+		{name: "when a scheduled match kicks off", before: scheduled, after: started, want: events.MatchStarted},
+		{name: "when the score of a match being played changes", before: started, after: goal, want: events.MatchScoreChanged},
+		{name: "when the final whistle blows", before: goal, after: ended, want: events.MatchFinished},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			b := events.NewBus()
+			var published []events.Event
+			b.Subscribe(func(ctx context.Context, event events.Event) error {
+				published = append(published, event)
+				return nil
+			})
+
+			s := NewService(r, a, cache.Nop{}, b)
+			s.now = func() time.Time { return now }
+
+			if tt.before.isEmpty() {
+				r.On("createMatch", mock.Anything, mock.Anything).Return(tt.after, nil)
+				_, err := s.createMatch(context.Background(), tt.after)
+				assert.NoError(t, err)
+			} else {
+				r.On("getMatch", mock.Anything, "10", false).Return(tt.before, nil)
+				r.On("updateMatch", mock.Anything, mock.Anything).Return(tt.after, nil)
+				_, err := s.updateMatch(context.Background(), "10", tt.after, etag.Any)
+				assert.NoError(t, err)
+			}
+			b.Wait()
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, tt.want, published[0].Type)
+			assert.Equal(t, "10", published[0].EntityId)
+		})
+	}
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) createMatch(ctx context.Context, match Match) (Match, error) {
+	args := m.Called(ctx, match)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *repositoryMock) getMatch(ctx context.Context, id string, includeDeleted bool) (Match, error) {
+	args := m.Called(ctx, id, includeDeleted)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *repositoryMock) updateMatch(ctx context.Context, match Match) (Match, error) {
+	args := m.Called(ctx, match)
+
+	return args.Get(0).(Match), args.Error(1)
+}
+
+func (m *repositoryMock) deleteMatch(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+type auditorMock struct {
+	mock.Mock
+}
+
+func (m *auditorMock) Record(ctx context.Context, action audit.Action, entity, entityId string, before, after interface{}) {
+	m.Called(ctx, action, entity, entityId, before, after)
+}
@@ -0,0 +1,30 @@
+package media
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config holds where images are stored and how they are checked and served.
+// Uploads are bounded by HTTP_MAX_BODY_BYTES as well as MaxBytes.
+type Config struct {
+	Dir           string        `env:"MEDIA_DIR" envDefault:"media"`
+	MaxBytes      int64         `env:"MEDIA_MAX_BYTES" envDefault:"524288"`
+	ThumbnailSize int           `env:"MEDIA_THUMBNAIL_SIZE" envDefault:"128"`
+	MaxAge        time.Duration `env:"MEDIA_MAX_AGE" envDefault:"24h"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Dir == "" {
+		return nil, errors.New("MEDIA_DIR must be set")
+	}
+	if cfg.MaxBytes <= 0 || cfg.ThumbnailSize <= 0 || cfg.MaxAge < 0 {
+		return nil, errors.New("MEDIA_MAX_BYTES and MEDIA_THUMBNAIL_SIZE must be positive and MEDIA_MAX_AGE not negative")
+	}
+	return &cfg, nil
+}
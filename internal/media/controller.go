@@ -0,0 +1,160 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+	"strconv"
+)
+
+// svgPolicy keeps scripts an uploaded SVG may carry from running when the
+// file is opened on its own.
+const svgPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+
+type service interface {
+	putCrest(ctx context.Context, teamId string, content []byte) (Object, error)
+	getCrest(ctx context.Context, teamId string, thumbnail bool) (Object, io.ReadSeekCloser, error)
+	deleteCrest(ctx context.Context, teamId string) error
+	putPhoto(ctx context.Context, player string, content []byte) (Object, error)
+	getPhoto(ctx context.Context, player string, thumbnail bool) (Object, io.ReadSeekCloser, error)
+	deletePhoto(ctx context.Context, player string) error
+}
+
+type Controller struct {
+	service service
+	config  *Config
+}
+
+func NewController(service service, config *Config) *Controller {
+	return &Controller{service: service, config: config}
+}
+
+// PutCrest replaces the crest of a team with the image in the request body,
+// sent as is rather than as a form.
+func (c Controller) PutCrest(ctx *gin.Context) {
+	c.put(ctx, func(content []byte) (Object, error) {
+		return c.service.putCrest(ctx.Request.Context(), ctx.Param("id"), content)
+	})
+}
+
+// GetCrest serves the crest of a team, or its thumbnail with
+// ?size=thumbnail, answering conditional and range requests.
+func (c Controller) GetCrest(ctx *gin.Context) {
+	c.serve(ctx, func(thumbnail bool) (Object, io.ReadSeekCloser, error) {
+		return c.service.getCrest(ctx.Request.Context(), ctx.Param("id"), thumbnail)
+	})
+}
+
+func (c Controller) DeleteCrest(ctx *gin.Context) {
+	if err := c.service.deleteCrest(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		ctx.JSON(imageErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PutPhoto replaces the photo of the player with the name in the path, as
+// PutCrest does for crests.
+func (c Controller) PutPhoto(ctx *gin.Context) {
+	c.put(ctx, func(content []byte) (Object, error) {
+		return c.service.putPhoto(ctx.Request.Context(), ctx.Param("name"), content)
+	})
+}
+
+// GetPhoto serves the photo of a player, or its thumbnail with
+// ?size=thumbnail.
+func (c Controller) GetPhoto(ctx *gin.Context) {
+	c.serve(ctx, func(thumbnail bool) (Object, io.ReadSeekCloser, error) {
+		return c.service.getPhoto(ctx.Request.Context(), ctx.Param("name"), thumbnail)
+	})
+}
+
+func (c Controller) DeletePhoto(ctx *gin.Context) {
+	if err := c.service.deletePhoto(ctx.Request.Context(), ctx.Param("name")); err != nil {
+		ctx.JSON(imageErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// put reads the image in the request body, up to MaxBytes, stores it and
+// answers where it is served.
+func (c Controller) put(ctx *gin.Context, store func(content []byte) (Object, error)) {
+	content, err := io.ReadAll(io.LimitReader(ctx.Request.Body, c.config.MaxBytes+1))
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) || int64(len(content)) > c.config.MaxBytes {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ctx, i18n.Errorf("image must be at most %d bytes", c.config.MaxBytes)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	object, err := store(content)
+	if err != nil {
+		ctx.JSON(imageErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	url := ctx.Request.URL.EscapedPath()
+	apiversion.JSON(ctx, http.StatusOK, Image{
+		ContentType:  object.ContentType,
+		Size:         object.Size,
+		Url:          url,
+		ThumbnailUrl: url + "?size=thumbnail",
+		UpdatedAt:    object.ModTime,
+	})
+}
+
+// serve writes the image open returns, or its thumbnail with
+// ?size=thumbnail, answering conditional and range requests.
+func (c Controller) serve(ctx *gin.Context, open func(thumbnail bool) (Object, io.ReadSeekCloser, error)) {
+	size := ctx.Query("size")
+	if size != "" && size != "thumbnail" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("size must be thumbnail")))
+		return
+	}
+
+	object, content, err := open(size == "thumbnail")
+	if err != nil {
+		ctx.JSON(imageErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+	defer content.Close()
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", object.ContentType)
+	header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(c.config.MaxAge.Seconds())))
+	header.Set("ETag", object.ETag)
+	header.Set("X-Content-Type-Options", "nosniff")
+	if object.ContentType == SVG {
+		header.Set("Content-Security-Policy", svgPolicy)
+	}
+
+	http.ServeContent(ctx.Writer, ctx.Request, "", object.ModTime, content)
+}
+
+func imageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errTeamNotFound), errors.Is(err, errCrestNotFound), errors.Is(err, errPhotoNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errUnsupported):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, errInvalid), errors.Is(err, errPlayerRequired):
+		return http.StatusBadRequest
+	}
+
+	return server.ErrorStatus(err)
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
@@ -0,0 +1,387 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var config = &Config{MaxBytes: 16, ThumbnailSize: 128, MaxAge: time.Hour}
+
+func TestController_PutCrest(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		body               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when the image is too large",
+			setup:              func(s *serviceMock) {},
+			body:               strings.Repeat("a", 17),
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedBody:       "{\"error\":\"image must be at most 16 bytes\"}",
+		},
+		{
+			name: "when the team does not exist",
+			setup: func(s *serviceMock) {
+				s.On("putCrest", mock.Anything, "1", []byte("image")).Return(Object{}, errTeamNotFound)
+			},
+			body:               "image",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"team not found\"}",
+		},
+		{
+			name: "when the image is not supported",
+			setup: func(s *serviceMock) {
+				s.On("putCrest", mock.Anything, "1", []byte("image")).Return(Object{}, errUnsupported)
+			},
+			body:               "image",
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedBody:       "{\"error\":\"image must be a PNG, JPEG or SVG\"}",
+		},
+		{
+			name: "when the image is corrupt",
+			setup: func(s *serviceMock) {
+				s.On("putCrest", mock.Anything, "1", []byte("image")).Return(Object{}, errInvalid)
+			},
+			body:               "image",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"image is corrupt or too large to decode\"}",
+		},
+		{
+			name: "when failed to store",
+			setup: func(s *serviceMock) {
+				s.On("putCrest", mock.Anything, "1", []byte("image")).Return(Object{}, errors.New("disk full"))
+			},
+			body:               "image",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"disk full\"}",
+		},
+		{
+			name: "when successfully store",
+			setup: func(s *serviceMock) {
+				s.On("putCrest", mock.Anything, "1", []byte("image")).Return(Object{ContentType: PNG, Size: 5, ETag: `"abc"`, ModTime: now}, nil)
+			},
+			body:               "image",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"contentType\":\"image/png\",\"size\":5,\"url\":\"/teams/1/crest\",\"thumbnailUrl\":\"/teams/1/crest?size=thumbnail\",\"updatedAt\":\"2024-05-01T12:00:00Z\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s, config)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/teams/1/crest", strings.NewReader(tt.body))
+			ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+
+			c.PutCrest(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_GetCrest(t *testing.T) {
+	png := Object{ContentType: PNG, Size: 5, ETag: `"abc"`, ModTime: now}
+	svg := Object{ContentType: SVG, Size: 5, ETag: `"def"`, ModTime: now}
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		query              string
+		ifNoneMatch        string
+		expectedStatusCode int
+		expectedBody       string
+		expectedHeaders    map[string]string
+	}{
+		{
+			name:               "when size is invalid",
+			setup:              func(s *serviceMock) {},
+			query:              "?size=large",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"size must be thumbnail\"}",
+		},
+		{
+			name: "when the team has no crest",
+			setup: func(s *serviceMock) {
+				s.On("getCrest", mock.Anything, "1", false).Return(Object{}, nil, errCrestNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"crest not found\"}",
+		},
+		{
+			name: "when successfully get the crest",
+			setup: func(s *serviceMock) {
+				s.On("getCrest", mock.Anything, "1", false).Return(png, content("image"), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "image",
+			expectedHeaders: map[string]string{
+				"Content-Type":            PNG,
+				"Cache-Control":           "public, max-age=3600",
+				"ETag":                    `"abc"`,
+				"Last-Modified":           "Wed, 01 May 2024 12:00:00 GMT",
+				"X-Content-Type-Options":  "nosniff",
+				"Content-Security-Policy": "",
+			},
+		},
+		{
+			name: "when successfully get the thumbnail of an svg",
+			setup: func(s *serviceMock) {
+				s.On("getCrest", mock.Anything, "1", true).Return(svg, content("<svg>"), nil)
+			},
+			query:              "?size=thumbnail",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "<svg>",
+			expectedHeaders: map[string]string{
+				"Content-Type":            SVG,
+				"Content-Security-Policy": svgPolicy,
+			},
+		},
+		{
+			name: "when the crest did not change",
+			setup: func(s *serviceMock) {
+				s.On("getCrest", mock.Anything, "1", false).Return(png, content("image"), nil)
+			},
+			ifNoneMatch:        `"abc"`,
+			expectedStatusCode: http.StatusNotModified,
+			expectedHeaders: map[string]string{
+				"Cache-Control": "public, max-age=3600",
+				"ETag":          `"abc"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s, config)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/teams/1/crest"+tt.query, nil)
+			if tt.ifNoneMatch != "" {
+				ctx.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+
+			c.GetCrest(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			for name, value := range tt.expectedHeaders {
+				assert.Equal(t, value, recorder.Header().Get(name), name)
+			}
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_DeleteCrest(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when the team has no crest",
+			setup: func(s *serviceMock) {
+				s.On("deleteCrest", mock.Anything, "1").Return(errCrestNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"crest not found\"}",
+		},
+		{
+			name: "when successfully delete",
+			setup: func(s *serviceMock) {
+				s.On("deleteCrest", mock.Anything, "1").Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s, config)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/teams/1/crest", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+
+			c.DeleteCrest(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_PutPhoto(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when the player name is blank",
+			setup: func(s *serviceMock) {
+				s.On("putPhoto", mock.Anything, "Alan Patrick", []byte("image")).Return(Object{}, errPlayerRequired)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"player name is required\"}",
+		},
+		{
+			name: "when successfully store",
+			setup: func(s *serviceMock) {
+				s.On("putPhoto", mock.Anything, "Alan Patrick", []byte("image")).Return(Object{ContentType: JPEG, Size: 5, ETag: `"abc"`, ModTime: now}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"contentType\":\"image/jpeg\",\"size\":5,\"url\":\"/players/Alan%20Patrick/photo\",\"thumbnailUrl\":\"/players/Alan%20Patrick/photo?size=thumbnail\",\"updatedAt\":\"2024-05-01T12:00:00Z\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s, config)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/players/Alan%20Patrick/photo", strings.NewReader("image"))
+			ctx.Params = gin.Params{{Key: "name", Value: "Alan Patrick"}}
+
+			c.PutPhoto(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_GetPhoto(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when the player has no photo",
+			setup: func(s *serviceMock) {
+				s.On("getPhoto", mock.Anything, "Alan Patrick", true).Return(Object{}, nil, errPhotoNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"photo not found\"}",
+		},
+		{
+			name: "when successfully serve the thumbnail",
+			setup: func(s *serviceMock) {
+				s.On("getPhoto", mock.Anything, "Alan Patrick", true).Return(Object{ContentType: JPEG, Size: 5, ETag: `"abc"`, ModTime: now}, content("photo"), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "photo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s, config)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/players/Alan%20Patrick/photo?size=thumbnail", nil)
+			ctx.Params = gin.Params{{Key: "name", Value: "Alan Patrick"}}
+
+			c.GetPhoto(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func content(s string) io.ReadSeekCloser {
+	return nopCloser{bytes.NewReader([]byte(s))}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) putCrest(ctx context.Context, teamId string, content []byte) (Object, error) {
+	args := m.Called(ctx, teamId, content)
+
+	return args.Get(0).(Object), args.Error(1)
+}
+
+func (m *serviceMock) getCrest(ctx context.Context, teamId string, thumbnail bool) (Object, io.ReadSeekCloser, error) {
+	args := m.Called(ctx, teamId, thumbnail)
+
+	content, _ := args.Get(1).(io.ReadSeekCloser)
+	return args.Get(0).(Object), content, args.Error(2)
+}
+
+func (m *serviceMock) deleteCrest(ctx context.Context, teamId string) error {
+	args := m.Called(ctx, teamId)
+
+	return args.Error(0)
+}
+
+func (m *serviceMock) putPhoto(ctx context.Context, player string, content []byte) (Object, error) {
+	args := m.Called(ctx, player, content)
+
+	return args.Get(0).(Object), args.Error(1)
+}
+
+func (m *serviceMock) getPhoto(ctx context.Context, player string, thumbnail bool) (Object, io.ReadSeekCloser, error) {
+	args := m.Called(ctx, player, thumbnail)
+
+	content, _ := args.Get(1).(io.ReadSeekCloser)
+	return args.Get(0).(Object), content, args.Error(2)
+}
+
+func (m *serviceMock) deletePhoto(ctx context.Context, player string) error {
+	args := m.Called(ctx, player)
+
+	return args.Error(0)
+}
@@ -0,0 +1,108 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Disk stores files under a local directory, each next to a .json file
+// holding its Object. It suits a single replica, or several sharing a
+// network volume.
+type Disk struct {
+	root string
+}
+
+func NewDisk(root string) *Disk {
+	return &Disk{root: root}
+}
+
+// path maps key to a file under the root. Cleaning the key as an absolute
+// path drops any "..", so that keys cannot escape the root.
+func (d *Disk) path(key string) string {
+	return filepath.Join(d.root, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+// Put writes the content before its metadata, each through a temporary file
+// renamed into place, so that readers never see a partial file and a key
+// only exists once both are written.
+func (d *Disk) Put(ctx context.Context, key string, object Object, content []byte) error {
+	name := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	if err = writeFile(name, content); err != nil {
+		return err
+	}
+
+	return writeFile(name+".json", metadata)
+}
+
+func (d *Disk) Get(ctx context.Context, key string) (Object, io.ReadSeekCloser, error) {
+	name := d.path(key)
+
+	metadata, err := os.ReadFile(name + ".json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return Object{}, nil, ErrNotFound
+	}
+	if err != nil {
+		return Object{}, nil, err
+	}
+
+	var object Object
+	if err = json.Unmarshal(metadata, &object); err != nil {
+		return Object{}, nil, err
+	}
+
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Object{}, nil, ErrNotFound
+	}
+	if err != nil {
+		return Object{}, nil, err
+	}
+
+	return object, file, nil
+}
+
+func (d *Disk) Delete(ctx context.Context, prefix string) error {
+	if path.Clean("/"+prefix) == "/" {
+		return errors.New("refusing to delete the whole storage")
+	}
+
+	// A prefix naming a single file leaves its metadata next to it.
+	name := d.path(prefix)
+	if err := os.Remove(name + ".json"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return os.RemoveAll(name)
+}
+
+func writeFile(name string, content []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), name)
+}
@@ -0,0 +1,49 @@
+package media
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDisk(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	disk := NewDisk(root)
+	object := Object{ContentType: PNG, Size: 3, ETag: `"abc"`, ModTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	_, _, err := disk.Get(ctx, "teams/1/crest")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, disk.Put(ctx, "teams/1/crest", object, []byte("one")))
+	assert.NoError(t, disk.Put(ctx, "teams/1/crest-thumbnail", object, []byte("two")))
+
+	got, content, err := disk.Get(ctx, "teams/1/crest")
+	assert.NoError(t, err)
+	assert.Equal(t, object, got)
+	read, _ := io.ReadAll(content)
+	content.Close()
+	assert.Equal(t, "one", string(read))
+
+	// Keys cannot escape the root.
+	assert.NoError(t, disk.Put(ctx, "../../outside", object, []byte("three")))
+	_, err = os.Stat(filepath.Join(root, "outside"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, disk.Delete(ctx, "teams/1/crest"))
+	_, _, err = disk.Get(ctx, "teams/1/crest")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = os.Stat(filepath.Join(root, "teams", "1", "crest.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, disk.Delete(ctx, "teams/1"))
+	_, _, err = disk.Get(ctx, "teams/1/crest-thumbnail")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.Error(t, disk.Delete(ctx, "/"))
+	assert.Error(t, disk.Delete(ctx, ".."))
+}
@@ -0,0 +1,12 @@
+package media
+
+import "time"
+
+// Image is the answer to an upload: what was stored and where to read it.
+type Image struct {
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	Url          string    `json:"url"`
+	ThumbnailUrl string    `json:"thumbnailUrl"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,123 @@
+package media
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+)
+
+const (
+	PNG  = "image/png"
+	JPEG = "image/jpeg"
+	SVG  = "image/svg+xml"
+)
+
+// maxPixels bounds the images decoded for thumbnails, whose files can be
+// small while their pixels take gigabytes.
+const maxPixels = 25_000_000
+
+var (
+	errUnsupported = errors.New("image must be a PNG, JPEG or SVG")
+	errInvalid     = errors.New("image is corrupt or too large to decode")
+)
+
+// detect returns the type of content from its bytes, whatever the client
+// claimed it to be.
+func detect(content []byte) (string, error) {
+	switch sniffed := http.DetectContentType(content); {
+	case sniffed == PNG, sniffed == JPEG:
+		return sniffed, nil
+	case strings.HasPrefix(sniffed, "text/xml"), strings.HasPrefix(sniffed, "text/plain"):
+		if isSVG(content) {
+			return SVG, nil
+		}
+	}
+
+	return "", errUnsupported
+}
+
+// isSVG reports whether the root element of content is an svg one.
+func isSVG(content []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local == "svg"
+		}
+	}
+}
+
+// thumbnail scales raster images down to fit a size × size square, in the
+// format they came in. SVGs scale by themselves and smaller images are
+// already small enough, so both are returned as they are.
+func thumbnail(content []byte, contentType string, size int) ([]byte, error) {
+	if contentType == SVG {
+		return content, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || config.Width*config.Height > maxPixels {
+		return nil, errInvalid
+	}
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, errInvalid
+	}
+	if config.Width <= size && config.Height <= size {
+		return content, nil
+	}
+
+	width, height := size, config.Height*size/config.Width
+	if config.Height > config.Width {
+		width, height = config.Width*size/config.Height, size
+	}
+	dst := scale(src, max(width, 1), max(height, 1))
+
+	var buf bytes.Buffer
+	if contentType == JPEG {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scale shrinks src to width × height, averaging the pixels each
+// destination pixel covers.
+func scale(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+					r, g, b, a = r+uint64(c.R), g+uint64(c.G), b+uint64(c.B), a+uint64(c.A)
+					n++
+				}
+			}
+			dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,91 @@
+package media
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+const svgFixture = `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><circle cx="5" cy="5" r="4" fill="red"/></svg>`
+
+func pngFixture(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func jpegFixture(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil)
+	return buf.Bytes()
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		expected string
+		wantErr  error
+	}{
+		{name: "when png", content: pngFixture(2, 2), expected: PNG},
+		{name: "when jpeg", content: jpegFixture(2, 2), expected: JPEG},
+		{name: "when svg", content: []byte(svgFixture), expected: SVG},
+		{name: "when svg without declaration", content: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), expected: SVG},
+		{name: "when other xml", content: []byte(`<?xml version="1.0"?><html></html>`), wantErr: errUnsupported},
+		{name: "when gif", content: []byte("GIF89a\x01\x00\x01\x00"), wantErr: errUnsupported},
+		{name: "when empty", content: nil, wantErr: errUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detect(tt.content)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      []byte
+		contentType  string
+		expectedSize image.Point
+		wantSame     bool
+		wantErr      error
+	}{
+		{name: "when svg", content: []byte(svgFixture), contentType: SVG, wantSame: true},
+		{name: "when already small", content: pngFixture(64, 32), contentType: PNG, wantSame: true},
+		{name: "when wide png", content: pngFixture(400, 200), contentType: PNG, expectedSize: image.Pt(128, 64)},
+		{name: "when tall jpeg", content: jpegFixture(100, 500), contentType: JPEG, expectedSize: image.Pt(25, 128)},
+		{name: "when corrupt", content: pngFixture(400, 200)[:100], contentType: PNG, wantErr: errInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := thumbnail(tt.content, tt.contentType, 128)
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			if tt.wantSame {
+				assert.Equal(t, tt.content, got)
+				return
+			}
+			config, format, err := image.DecodeConfig(bytes.NewReader(got))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.contentType, "image/"+format)
+			assert.Equal(t, tt.expectedSize, image.Pt(config.Width, config.Height))
+		})
+	}
+}
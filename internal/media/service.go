@@ -0,0 +1,249 @@
+// Package media stores and serves the images of the API, team crests and
+// player photos, through a Storage so that files can move to another backend.
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"io"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/teams"
+	"sc-internacional/internal/tracing"
+	"strings"
+	"time"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/media")
+
+var (
+	errTeamNotFound   = errors.New("team not found")
+	errCrestNotFound  = errors.New("crest not found")
+	errPhotoNotFound  = errors.New("photo not found")
+	errPlayerRequired = errors.New("player name is required")
+)
+
+type teamService interface {
+	GetTeams(ctx context.Context, ids []string) ([]teams.Team, error)
+}
+
+type Service struct {
+	storage Storage
+	teams   teamService
+	config  *Config
+	now     func() time.Time
+}
+
+func NewService(storage Storage, teams teamService, config *Config) *Service {
+	return &Service{storage: storage, teams: teams, config: config, now: time.Now}
+}
+
+func crestKey(teamId string, thumbnail bool) string {
+	if thumbnail {
+		return "teams/" + teamId + "/crest-thumbnail"
+	}
+
+	return "teams/" + teamId + "/crest"
+}
+
+// photoKey names the photo of a player. Players are not entities of their
+// own but names recorded in match incidents, so the name is the key; it is
+// encoded to keep slashes and dots from reaching into other keys.
+func photoKey(player string, thumbnail bool) string {
+	prefix := "players/" + base64.RawURLEncoding.EncodeToString([]byte(player))
+	if thumbnail {
+		return prefix + "/photo-thumbnail"
+	}
+
+	return prefix + "/photo"
+}
+
+// putCrest stores content as the crest of a team, along with its thumbnail.
+// Its type is told by its bytes, which must make a PNG, JPEG or SVG.
+func (s Service) putCrest(ctx context.Context, teamId string, content []byte) (Object, error) {
+	ctx, span := tracer.Start(ctx, "media.Service.putCrest")
+	defer span.End()
+
+	if err := s.checkTeam(ctx, teamId); err != nil {
+		return Object{}, tracing.Error(span, err)
+	}
+
+	object, err := s.putImage(ctx, func(thumbnail bool) string { return crestKey(teamId, thumbnail) }, content)
+	if err != nil {
+		return Object{}, tracing.Error(span, err)
+	}
+
+	return object, nil
+}
+
+// getCrest opens the crest of a team, or its thumbnail. The caller closes
+// the content.
+func (s Service) getCrest(ctx context.Context, teamId string, thumbnail bool) (Object, io.ReadSeekCloser, error) {
+	ctx, span := tracer.Start(ctx, "media.Service.getCrest")
+	defer span.End()
+
+	if err := s.checkTeam(ctx, teamId); err != nil {
+		return Object{}, nil, tracing.Error(span, err)
+	}
+
+	object, content, err := s.storage.Get(ctx, crestKey(teamId, thumbnail))
+	if errors.Is(err, ErrNotFound) {
+		return Object{}, nil, tracing.Error(span, errCrestNotFound)
+	}
+	if err != nil {
+		return Object{}, nil, tracing.Error(span, err)
+	}
+
+	return object, content, nil
+}
+
+func (s Service) deleteCrest(ctx context.Context, teamId string) error {
+	ctx, span := tracer.Start(ctx, "media.Service.deleteCrest")
+	defer span.End()
+
+	if err := s.checkTeam(ctx, teamId); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	err := s.deleteImage(ctx, func(thumbnail bool) string { return crestKey(teamId, thumbnail) })
+	if errors.Is(err, ErrNotFound) {
+		return tracing.Error(span, errCrestNotFound)
+	}
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// putPhoto stores content as the photo of a player, along with its
+// thumbnail, as putCrest does for crests.
+func (s Service) putPhoto(ctx context.Context, player string, content []byte) (Object, error) {
+	ctx, span := tracer.Start(ctx, "media.Service.putPhoto")
+	defer span.End()
+
+	if strings.TrimSpace(player) == "" {
+		return Object{}, tracing.Error(span, errPlayerRequired)
+	}
+
+	object, err := s.putImage(ctx, func(thumbnail bool) string { return photoKey(player, thumbnail) }, content)
+	if err != nil {
+		return Object{}, tracing.Error(span, err)
+	}
+
+	return object, nil
+}
+
+// getPhoto opens the photo of a player, or its thumbnail. The caller closes
+// the content.
+func (s Service) getPhoto(ctx context.Context, player string, thumbnail bool) (Object, io.ReadSeekCloser, error) {
+	ctx, span := tracer.Start(ctx, "media.Service.getPhoto")
+	defer span.End()
+
+	object, content, err := s.storage.Get(ctx, photoKey(player, thumbnail))
+	if errors.Is(err, ErrNotFound) {
+		return Object{}, nil, tracing.Error(span, errPhotoNotFound)
+	}
+	if err != nil {
+		return Object{}, nil, tracing.Error(span, err)
+	}
+
+	return object, content, nil
+}
+
+func (s Service) deletePhoto(ctx context.Context, player string) error {
+	ctx, span := tracer.Start(ctx, "media.Service.deletePhoto")
+	defer span.End()
+
+	err := s.deleteImage(ctx, func(thumbnail bool) string { return photoKey(player, thumbnail) })
+	if errors.Is(err, ErrNotFound) {
+		return tracing.Error(span, errPhotoNotFound)
+	}
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// putImage stores content and its thumbnail under the keys key returns.
+func (s Service) putImage(ctx context.Context, key func(thumbnail bool) string, content []byte) (Object, error) {
+	contentType, err := detect(content)
+	if err != nil {
+		return Object{}, err
+	}
+	small, err := thumbnail(content, contentType, s.config.ThumbnailSize)
+	if err != nil {
+		return Object{}, err
+	}
+
+	// The thumbnail goes first: an image whose thumbnail failed to store
+	// would otherwise come with the thumbnail of the previous one.
+	now := s.now().UTC().Truncate(time.Second)
+	if err = s.storage.Put(ctx, key(true), newObject(contentType, small, now), small); err != nil {
+		return Object{}, err
+	}
+	object := newObject(contentType, content, now)
+	if err = s.storage.Put(ctx, key(false), object, content); err != nil {
+		return Object{}, err
+	}
+
+	return object, nil
+}
+
+// deleteImage removes an image and its thumbnail, failing with ErrNotFound
+// when there is no image.
+func (s Service) deleteImage(ctx context.Context, key func(thumbnail bool) string) error {
+	_, content, err := s.storage.Get(ctx, key(false))
+	if err != nil {
+		return err
+	}
+	content.Close()
+
+	for _, thumbnail := range []bool{true, false} {
+		if err := s.storage.Delete(ctx, key(thumbnail)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HandleEvent removes the images of purged teams. Those of soft deleted
+// teams stay, for them to come back on restore.
+func (s Service) HandleEvent(ctx context.Context, event events.Event) error {
+	ctx, span := tracer.Start(ctx, "media.Service.HandleEvent")
+	defer span.End()
+
+	if event.Type != events.TeamPurged {
+		return nil
+	}
+
+	if err := s.storage.Delete(ctx, "teams/"+event.EntityId); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// checkTeam fails with errTeamNotFound unless the team exists and is not
+// deleted.
+func (s Service) checkTeam(ctx context.Context, teamId string) error {
+	found, err := s.teams.GetTeams(ctx, []string{teamId})
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return errTeamNotFound
+	}
+
+	return nil
+}
+
+func newObject(contentType string, content []byte, modTime time.Time) Object {
+	sum := sha256.Sum256(content)
+	return Object{ContentType: contentType, Size: int64(len(content)), ETag: `"` + hex.EncodeToString(sum[:16]) + `"`, ModTime: modTime}
+}
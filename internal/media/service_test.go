@@ -0,0 +1,202 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/teams"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T, setup func(*teamServiceMock)) (*Service, *teamServiceMock) {
+	team := &teamServiceMock{}
+	setup(team)
+	s := NewService(NewDisk(t.TempDir()), team, &Config{MaxBytes: 1 << 20, ThumbnailSize: 128})
+	s.now = func() time.Time { return now }
+	return s, team
+}
+
+func found(team *teamServiceMock) {
+	team.On("GetTeams", mock.Anything, []string{"1"}).Return([]teams.Team{{Id: "1", Name: "Internacional"}}, nil)
+}
+
+func TestService_putCrest(t *testing.T) {
+	tests := []struct {
+		name              string
+		setup             func(*teamServiceMock)
+		content           []byte
+		expectedType      string
+		expectedThumbnail bool
+		wantErr           error
+	}{
+		{
+			name: "when the team does not exist",
+			setup: func(team *teamServiceMock) {
+				team.On("GetTeams", mock.Anything, []string{"1"}).Return([]teams.Team{}, nil)
+			},
+			content: pngFixture(2, 2),
+			wantErr: errTeamNotFound,
+		},
+		{
+			name: "when failed to get the team",
+			setup: func(team *teamServiceMock) {
+				team.On("GetTeams", mock.Anything, []string{"1"}).Return([]teams.Team(nil), errors.New("failed to find"))
+			},
+			content: pngFixture(2, 2),
+			wantErr: errors.New("failed to find"),
+		},
+		{
+			name:    "when the image is not supported",
+			setup:   found,
+			content: []byte("GIF89a"),
+			wantErr: errUnsupported,
+		},
+		{
+			name:    "when the image is corrupt",
+			setup:   found,
+			content: pngFixture(400, 200)[:100],
+			wantErr: errInvalid,
+		},
+		{
+			name:              "when successfully store a png",
+			setup:             found,
+			content:           pngFixture(400, 200),
+			expectedType:      PNG,
+			expectedThumbnail: true,
+		},
+		{
+			name:         "when successfully store an svg",
+			setup:        found,
+			content:      []byte(svgFixture),
+			expectedType: SVG,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, team := newTestService(t, tt.setup)
+
+			got, err := s.putCrest(context.Background(), "1", tt.content)
+
+			assert.Equal(t, tt.wantErr, err)
+			team.AssertExpectations(t)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.expectedType, got.ContentType)
+			assert.Equal(t, int64(len(tt.content)), got.Size)
+			assert.Equal(t, now, got.ModTime)
+
+			crest, content, err := s.getCrest(context.Background(), "1", false)
+			assert.NoError(t, err)
+			assert.Equal(t, got, crest)
+			content.Close()
+
+			small, content, err := s.getCrest(context.Background(), "1", true)
+			assert.NoError(t, err)
+			read, _ := io.ReadAll(content)
+			content.Close()
+			assert.Equal(t, tt.expectedThumbnail, small.Size < crest.Size)
+			assert.Equal(t, small.Size, int64(len(read)))
+		})
+	}
+}
+
+func TestService_deleteCrest(t *testing.T) {
+	s, _ := newTestService(t, found)
+	ctx := context.Background()
+
+	assert.Equal(t, errCrestNotFound, s.deleteCrest(ctx, "1"))
+
+	_, err := s.putCrest(ctx, "1", pngFixture(400, 200))
+	assert.NoError(t, err)
+	assert.NoError(t, s.deleteCrest(ctx, "1"))
+
+	for _, thumbnail := range []bool{false, true} {
+		_, _, err = s.getCrest(ctx, "1", thumbnail)
+		assert.Equal(t, errCrestNotFound, err)
+	}
+}
+
+func TestService_photo(t *testing.T) {
+	s, _ := newTestService(t, found)
+	ctx := context.Background()
+
+	_, err := s.putPhoto(ctx, " ", pngFixture(2, 2))
+	assert.Equal(t, errPlayerRequired, err)
+	_, _, err = s.getPhoto(ctx, "Alan Patrick", false)
+	assert.Equal(t, errPhotoNotFound, err)
+	assert.Equal(t, errPhotoNotFound, s.deletePhoto(ctx, "Alan Patrick"))
+
+	// A name made of dots and slashes stays a player of its own.
+	for _, player := range []string{"Alan Patrick", "../teams/1"} {
+		photo, err := s.putPhoto(ctx, player, pngFixture(400, 200))
+		assert.NoError(t, err)
+
+		small, content, err := s.getPhoto(ctx, player, true)
+		assert.NoError(t, err)
+		content.Close()
+		assert.Less(t, small.Size, photo.Size)
+	}
+	_, _, err = s.getCrest(ctx, "1", false)
+	assert.Equal(t, errCrestNotFound, err)
+
+	assert.NoError(t, s.deletePhoto(ctx, "Alan Patrick"))
+	for _, thumbnail := range []bool{false, true} {
+		_, _, err = s.getPhoto(ctx, "Alan Patrick", thumbnail)
+		assert.Equal(t, errPhotoNotFound, err)
+	}
+	_, content, err := s.getPhoto(ctx, "../teams/1", false)
+	assert.NoError(t, err)
+	content.Close()
+}
+
+func TestService_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     events.Event
+		wantCrest bool
+	}{
+		{
+			name:      "when the team is deleted",
+			event:     events.Event{Type: events.TeamDeleted, EntityId: "1"},
+			wantCrest: true,
+		},
+		{
+			name:  "when the team is purged",
+			event: events.Event{Type: events.TeamPurged, EntityId: "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(t, found)
+			ctx := context.Background()
+			_, err := s.putCrest(ctx, "1", pngFixture(2, 2))
+			assert.NoError(t, err)
+
+			assert.NoError(t, s.HandleEvent(ctx, tt.event))
+
+			_, content, err := s.storage.Get(ctx, crestKey("1", false))
+			assert.Equal(t, tt.wantCrest, err == nil)
+			if content != nil {
+				content.Close()
+			}
+		})
+	}
+}
+
+type teamServiceMock struct {
+	teamService
+	mock.Mock
+}
+
+func (m *teamServiceMock) GetTeams(ctx context.Context, ids []string) ([]teams.Team, error) {
+	args := m.Called(ctx, ids)
+
+	return args.Get(0).([]teams.Team), args.Error(1)
+}
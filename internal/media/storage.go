@@ -0,0 +1,27 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Object describes a stored file.
+type Object struct {
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	ETag        string    `json:"etag"`
+	ModTime     time.Time `json:"modTime"`
+}
+
+// Storage keeps files by key, such as "teams/<id>/crest". Keys are
+// slash-separated whatever the backend. Get returns ErrNotFound for keys
+// holding nothing, and Delete removes every key under a prefix.
+type Storage interface {
+	Put(ctx context.Context, key string, object Object, content []byte) error
+	Get(ctx context.Context, key string) (Object, io.ReadSeekCloser, error)
+	Delete(ctx context.Context, prefix string) error
+}
@@ -0,0 +1,13 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var cacheOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "cache_operations_total",
+	Help: "Number of cache operations by operation and outcome: hit, miss or error.",
+}, []string{"operation", "outcome"})
+
+// ObserveCache records a cache operation and its outcome.
+func ObserveCache(operation, outcome string) {
+	cacheOperations.WithLabelValues(operation, outcome).Inc()
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/teams/:id", func(ctx *gin.Context) { ctx.Status(http.StatusNotFound) })
+
+	for _, path := range []string{"/teams/1", "/teams/2", "/players"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/teams/:id", "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(httpRequests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	interceptor := UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/scinternacional.v1.Teams/GetTeam"}
+	for _, err := range []error{nil, status.Error(codes.NotFound, "team not found"), status.Error(codes.NotFound, "team not found")} {
+		interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) { return nil, err })
+	}
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(grpcRequests.WithLabelValues(info.FullMethod, "OK")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(grpcRequests.WithLabelValues(info.FullMethod, "NotFound")))
+}
+
+func TestObserveMongoOperation(t *testing.T) {
+	ObserveMongoOperation("teams", "FindOne", time.Now(), nil)
+	ObserveMongoOperation("teams", "FindOne", time.Now(), mongo.ErrNoDocuments)
+	ObserveMongoOperation("teams", "FindOne", time.Now(), errors.New("connection reset"))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(mongoOperations.WithLabelValues("teams", "FindOne", "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(mongoOperations.WithLabelValues("teams", "FindOne", "error")))
+}
+
+func TestPoolMonitor(t *testing.T) {
+	monitor := PoolMonitor()
+	for _, eventType := range []string{event.ConnectionCreated, event.ConnectionCreated, event.GetSucceeded, event.GetSucceeded, event.ConnectionReturned, event.ConnectionClosed} {
+		monitor.Event(&event.PoolEvent{Type: eventType, Address: "mongo:27017"})
+	}
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(mongoPoolConnections.WithLabelValues("mongo:27017")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(mongoPoolCheckedOut.WithLabelValues("mongo:27017")))
+}
+
+func TestHandler(t *testing.T) {
+	r := gin.New()
+	r.GET("/metrics", Handler())
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.Contains(recorder.Body.String(), "# TYPE go_goroutines gauge"))
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+var (
+	mongoOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mongo_operations_total",
+		Help: "Number of Mongo operations by collection, operation and outcome.",
+	}, []string{"collection", "operation", "outcome"})
+
+	mongoOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongo_operation_duration_seconds",
+		Help:    "Latency of Mongo operations by collection and operation.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"collection", "operation"})
+
+	mongoPoolConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mongo_pool_connections",
+		Help: "Open connections in the Mongo connection pool by server address.",
+	}, []string{"address"})
+
+	mongoPoolCheckedOut = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mongo_pool_checked_out_connections",
+		Help: "Connections checked out of the Mongo connection pool by server address.",
+	}, []string{"address"})
+
+	mongoPoolCheckOutFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mongo_pool_check_out_failures_total",
+		Help: "Failed attempts to check a connection out of the Mongo connection pool.",
+	}, []string{"address", "reason"})
+)
+
+// ObserveMongoOperation records an operation that started at start and
+// finished with err. Not finding a document is not counted as an error.
+func ObserveMongoOperation(collection, operation string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		outcome = "error"
+	}
+
+	mongoOperations.WithLabelValues(collection, operation, outcome).Inc()
+	mongoOperationDuration.WithLabelValues(collection, operation).Observe(time.Since(start).Seconds())
+}
+
+// PoolMonitor keeps the connection pool gauges up to date.
+func PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoPoolConnections.WithLabelValues(e.Address).Inc()
+			case event.ConnectionClosed:
+				mongoPoolConnections.WithLabelValues(e.Address).Dec()
+			case event.GetSucceeded:
+				mongoPoolCheckedOut.WithLabelValues(e.Address).Inc()
+			case event.ConnectionReturned:
+				mongoPoolCheckedOut.WithLabelValues(e.Address).Dec()
+			case event.GetFailed:
+				mongoPoolCheckOutFailures.WithLabelValues(e.Address, e.Reason).Inc()
+			}
+		},
+	}
+}
@@ -0,0 +1,286 @@
+// Package openapi builds the OpenAPI 3 document of the REST API from the
+// types its handlers bind and return, and serves it with Swagger UI.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Document is an OpenAPI 3.0 document.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+
+	names map[reflect.Type]string
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+
+	document *Document
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Error is the body of every error response.
+type Error struct {
+	Error string `json:"error" binding:"required"`
+}
+
+const (
+	apiKeyScheme = "apiKey"
+	bearerScheme = "bearer"
+)
+
+func New(title, version string) *Document {
+	d := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]map[string]*Operation{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				apiKeyScheme: {Type: "apiKey", In: "header", Name: "X-API-Key"},
+				bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		names: map[reflect.Type]string{},
+	}
+	d.Schema(Error{})
+
+	return d
+}
+
+// Route adds the operation served by a Gin route, declaring its :params and
+// *params as path parameters.
+func (d *Document) Route(method, ginPath string) *Operation {
+	o := &Operation{Responses: map[string]*Response{}, document: d}
+	for _, segment := range strings.Split(ginPath, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			o.Parameters = append(o.Parameters, Parameter{Name: segment[1:], In: "path", Required: true, Schema: String()})
+		}
+	}
+
+	p := Path(ginPath)
+	if d.Paths[p] == nil {
+		d.Paths[p] = map[string]*Operation{}
+	}
+	d.Paths[p][strings.ToLower(method)] = o
+
+	return o
+}
+
+// Operations lists the documented operations as "METHOD /path", sorted.
+func (d *Document) Operations() []string {
+	var operations []string
+	for p, item := range d.Paths {
+		for method := range item {
+			operations = append(operations, strings.ToUpper(method)+" "+p)
+		}
+	}
+	sort.Strings(operations)
+
+	return operations
+}
+
+// Alias documents every operation under prefix again without it, as
+// deprecated operations whose responses carry the given headers.
+func (d *Document) Alias(prefix, note string, headers map[string]Header) {
+	for p, item := range d.Paths {
+		if !strings.HasPrefix(p, prefix+"/") {
+			continue
+		}
+
+		alias := map[string]*Operation{}
+		for method, o := range item {
+			deprecated := *o
+			deprecated.Deprecated = true
+			deprecated.Description = ""
+			deprecated.Note(note).Note(o.Description)
+			deprecated.Responses = map[string]*Response{}
+			for status, response := range o.Responses {
+				withHeaders := *response
+				withHeaders.Headers = map[string]Header{}
+				for name, header := range response.Headers {
+					withHeaders.Headers[name] = header
+				}
+				for name, header := range headers {
+					withHeaders.Headers[name] = header
+				}
+				deprecated.Responses[status] = &withHeaders
+			}
+			alias[method] = &deprecated
+		}
+		d.Paths[strings.TrimPrefix(p, prefix)] = alias
+	}
+}
+
+// Path turns a Gin route path into an OpenAPI one, /teams/:id into
+// /teams/{id}.
+func Path(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (o *Operation) Describe(tag, summary string) *Operation {
+	o.Tags = []string{tag}
+	o.Summary = summary
+	return o
+}
+
+// Note appends a paragraph to the description of the operation.
+func (o *Operation) Note(text string) *Operation {
+	if text == "" {
+		return o
+	}
+	if o.Description != "" {
+		o.Description += "\n\n"
+	}
+	o.Description += text
+	return o
+}
+
+func (o *Operation) Query(name, description string, schema *Schema) *Operation {
+	o.Parameters = append(o.Parameters, Parameter{Name: name, In: "query", Description: description, Schema: schema})
+	return o
+}
+
+func (o *Operation) Header(name, description string, required bool) *Operation {
+	o.Parameters = append(o.Parameters, Parameter{Name: name, In: "header", Description: description, Required: required, Schema: String()})
+	return o
+}
+
+// Body declares a required JSON request body.
+func (o *Operation) Body(schema *Schema) *Operation {
+	o.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+	return o
+}
+
+// BodyAs declares a required request body in any of the media types of
+// content.
+func (o *Operation) BodyAs(content map[string]*Schema) *Operation {
+	o.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{}}
+	for mediaType, schema := range content {
+		o.RequestBody.Content[mediaType] = MediaType{Schema: schema}
+	}
+	return o
+}
+
+// Respond declares a response with a JSON body, or without a body when
+// schema is nil.
+func (o *Operation) Respond(status int, description string, schema *Schema) *Operation {
+	if schema == nil {
+		return o.RespondAs(status, description, nil)
+	}
+
+	return o.RespondAs(status, description, map[string]*Schema{"application/json": schema})
+}
+
+// RespondAs declares a response with a body in each of the media types of
+// content.
+func (o *Operation) RespondAs(status int, description string, content map[string]*Schema) *Operation {
+	response := &Response{Description: description}
+	for mediaType, schema := range content {
+		if response.Content == nil {
+			response.Content = map[string]MediaType{}
+		}
+		response.Content[mediaType] = MediaType{Schema: schema}
+	}
+	o.Responses[strconv.Itoa(status)] = response
+	return o
+}
+
+// ResponseHeader declares a header sent with the responses of the given
+// statuses, which must already be declared.
+func (o *Operation) ResponseHeader(name, description string, statuses ...int) *Operation {
+	for _, status := range statuses {
+		response := o.Responses[strconv.Itoa(status)]
+		if response.Headers == nil {
+			response.Headers = map[string]Header{}
+		}
+		response.Headers[name] = Header{Description: description, Schema: String()}
+	}
+	return o
+}
+
+// Errors declares error responses with the standard description of their
+// status.
+func (o *Operation) Errors(statuses ...int) *Operation {
+	for _, status := range statuses {
+		o.Respond(status, http.StatusText(status), o.document.Schema(Error{}))
+	}
+	return o
+}
+
+// Secure declares that the operation takes an API key or a bearer token,
+// or may be called anonymously too when optional is set.
+func (o *Operation) Secure(optional bool) *Operation {
+	o.Security = []map[string][]string{{apiKeyScheme: {}}, {bearerScheme: {}}}
+	if optional {
+		o.Security = append(o.Security, map[string][]string{})
+	}
+	return o
+}
@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"net/http"
+	"strconv"
+)
+
+// Handler serves the document as JSON. It is encoded once, so later changes
+// to the document are not served.
+func (d *Document) Handler() gin.HandlerFunc {
+	body, err := json.Marshal(d)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
+
+// UI serves Swagger UI on a route ending in *filepath, pointed at the
+// document served on specURL.
+func UI(specURL string) gin.HandlerFunc {
+	files := http.FileServer(http.FS(swaggerFiles.FS))
+	initializer := []byte(`window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: ` + strconv.Quote(specURL) + `,
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`)
+
+	return func(ctx *gin.Context) {
+		file := ctx.Param("filepath")
+		if file == "/swagger-initializer.js" {
+			ctx.Data(http.StatusOK, "text/javascript; charset=utf-8", initializer)
+			return
+		}
+
+		request := ctx.Request.Clone(ctx.Request.Context())
+		request.URL.Path = file
+		files.ServeHTTP(ctx.Writer, request)
+	}
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type player struct {
+	Name      string     `json:"name" binding:"required,max=40"`
+	Number    int        `json:"number" binding:"min=1,max=99"`
+	Position  string     `json:"position" binding:"omitempty,oneof=goalkeeper defender midfielder forward"`
+	Nicknames []string   `json:"nicknames" binding:"required,min=1,dive,min=2"`
+	Born      time.Time  `json:"born"`
+	Retired   *time.Time `json:"retired,omitempty"`
+	Club      *club      `json:"club,omitempty"`
+	Site      string     `json:"site" binding:"omitempty,url"`
+	Internal  string     `json:"-"`
+	notes     string
+}
+
+type club struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func TestDocument_Schema(t *testing.T) {
+	d := New("test", "1")
+
+	ref := d.Schema(player{})
+
+	body, err := json.Marshal(d.Components.Schemas["player"])
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/player", ref.Ref)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "maxLength": 40},
+			"number": {"type": "integer", "format": "int32", "minimum": 1, "maximum": 99},
+			"position": {"type": "string", "enum": ["goalkeeper", "defender", "midfielder", "forward"]},
+			"nicknames": {"type": "array", "items": {"type": "string", "minLength": 2}, "minItems": 1},
+			"born": {"type": "string", "format": "date-time"},
+			"retired": {"type": "string", "format": "date-time", "nullable": true},
+			"club": {"$ref": "#/components/schemas/club"},
+			"site": {"type": "string", "format": "uri"}
+		},
+		"required": ["name", "nicknames"]
+	}`, string(body))
+	assert.Contains(t, d.Components.Schemas, "club")
+}
+
+func TestDocument_Schema_NameTaken(t *testing.T) {
+	d := New("test", "1")
+
+	type Error struct {
+		Code int `json:"code"`
+	}
+
+	assert.Equal(t, "#/components/schemas/OpenapiError", d.Schema(Error{}).Ref)
+	assert.Equal(t, "#/components/schemas/OpenapiError", d.Schema(Error{}).Ref)
+	assert.Equal(t, []string{"error"}, d.Components.Schemas["Error"].Required)
+}
@@ -0,0 +1,584 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v5.28.3
+// source: scinternacional/v1/championships.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Championship struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Season    string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"`
+	Teams     []*Team                `protobuf:"bytes,4,rep,name=teams,proto3" json:"teams,omitempty"`
+	Version   int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Names     map[string]string      `protobuf:"bytes,7,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Championship) Reset() {
+	*x = Championship{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Championship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Championship) ProtoMessage() {}
+
+func (x *Championship) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Championship.ProtoReflect.Descriptor instead.
+func (*Championship) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Championship) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Championship) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Championship) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *Championship) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *Championship) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Championship) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Championship) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type GetChampionshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeDeleted bool   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+}
+
+func (x *GetChampionshipRequest) Reset() {
+	*x = GetChampionshipRequest{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChampionshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChampionshipRequest) ProtoMessage() {}
+
+func (x *GetChampionshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChampionshipRequest.ProtoReflect.Descriptor instead.
+func (*GetChampionshipRequest) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetChampionshipRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetChampionshipRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type CreateChampionshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Championship *Championship `protobuf:"bytes,1,opt,name=championship,proto3" json:"championship,omitempty"`
+}
+
+func (x *CreateChampionshipRequest) Reset() {
+	*x = CreateChampionshipRequest{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChampionshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChampionshipRequest) ProtoMessage() {}
+
+func (x *CreateChampionshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChampionshipRequest.ProtoReflect.Descriptor instead.
+func (*CreateChampionshipRequest) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateChampionshipRequest) GetChampionship() *Championship {
+	if x != nil {
+		return x.Championship
+	}
+	return nil
+}
+
+type UpdateChampionshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Championship *Championship `protobuf:"bytes,2,opt,name=championship,proto3" json:"championship,omitempty"`
+	Version      *int64        `protobuf:"varint,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
+}
+
+func (x *UpdateChampionshipRequest) Reset() {
+	*x = UpdateChampionshipRequest{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChampionshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChampionshipRequest) ProtoMessage() {}
+
+func (x *UpdateChampionshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChampionshipRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChampionshipRequest) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateChampionshipRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateChampionshipRequest) GetChampionship() *Championship {
+	if x != nil {
+		return x.Championship
+	}
+	return nil
+}
+
+func (x *UpdateChampionshipRequest) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type DeleteChampionshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hard bool   `protobuf:"varint,2,opt,name=hard,proto3" json:"hard,omitempty"`
+}
+
+func (x *DeleteChampionshipRequest) Reset() {
+	*x = DeleteChampionshipRequest{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChampionshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChampionshipRequest) ProtoMessage() {}
+
+func (x *DeleteChampionshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChampionshipRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChampionshipRequest) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteChampionshipRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteChampionshipRequest) GetHard() bool {
+	if x != nil {
+		return x.Hard
+	}
+	return false
+}
+
+type DeleteChampionshipResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteChampionshipResponse) Reset() {
+	*x = DeleteChampionshipResponse{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChampionshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChampionshipResponse) ProtoMessage() {}
+
+func (x *DeleteChampionshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChampionshipResponse.ProtoReflect.Descriptor instead.
+func (*DeleteChampionshipResponse) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{5}
+}
+
+type RestoreChampionshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RestoreChampionshipRequest) Reset() {
+	*x = RestoreChampionshipRequest{}
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreChampionshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreChampionshipRequest) ProtoMessage() {}
+
+func (x *RestoreChampionshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scinternacional_v1_championships_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreChampionshipRequest.ProtoReflect.Descriptor instead.
+func (*RestoreChampionshipRequest) Descriptor() ([]byte, []int) {
+	return file_scinternacional_v1_championships_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RestoreChampionshipRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_scinternacional_v1_championships_proto protoreflect.FileDescriptor
+
+var file_scinternacional_v1_championships_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69,
+	0x70, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x73,
+	0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2f, 0x76,
+	0x31, 0x2f, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc, 0x02,
+	0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x63, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x65, 0x61, 0x6d, 0x52, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x41, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b,
+	0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x51, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22,
+	0x61, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f,
+	0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0c,
+	0x63, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e,
+	0x73, 0x68, 0x69, 0x70, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68,
+	0x69, 0x70, 0x22, 0x9c, 0x01, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61,
+	0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x44, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6d,
+	0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6d, 0x70, 0x69,
+	0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x1d, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x3f, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x68, 0x61,
+	0x72, 0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d,
+	0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x2c, 0x0a, 0x1a, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x32, 0x9c,
+	0x04, 0x0a, 0x0d, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73,
+	0x12, 0x5f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73,
+	0x68, 0x69, 0x70, 0x12, 0x2a, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6d,
+	0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69,
+	0x70, 0x12, 0x65, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2d, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6d,
+	0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x65, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2d,
+	0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69,
+	0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12,
+	0x73, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f,
+	0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2d, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43,
+	0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2e, 0x2e, 0x73, 0x63,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e,
+	0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x63,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x68, 0x61, 0x6d, 0x70, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x42, 0x1e, 0x5a,
+	0x1c, 0x73, 0x63, 0x2d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_scinternacional_v1_championships_proto_rawDescOnce sync.Once
+	file_scinternacional_v1_championships_proto_rawDescData = file_scinternacional_v1_championships_proto_rawDesc
+)
+
+func file_scinternacional_v1_championships_proto_rawDescGZIP() []byte {
+	file_scinternacional_v1_championships_proto_rawDescOnce.Do(func() {
+		file_scinternacional_v1_championships_proto_rawDescData = protoimpl.X.CompressGZIP(file_scinternacional_v1_championships_proto_rawDescData)
+	})
+	return file_scinternacional_v1_championships_proto_rawDescData
+}
+
+var file_scinternacional_v1_championships_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_scinternacional_v1_championships_proto_goTypes = []any{
+	(*Championship)(nil),               // 0: scinternacional.v1.Championship
+	(*GetChampionshipRequest)(nil),     // 1: scinternacional.v1.GetChampionshipRequest
+	(*CreateChampionshipRequest)(nil),  // 2: scinternacional.v1.CreateChampionshipRequest
+	(*UpdateChampionshipRequest)(nil),  // 3: scinternacional.v1.UpdateChampionshipRequest
+	(*DeleteChampionshipRequest)(nil),  // 4: scinternacional.v1.DeleteChampionshipRequest
+	(*DeleteChampionshipResponse)(nil), // 5: scinternacional.v1.DeleteChampionshipResponse
+	(*RestoreChampionshipRequest)(nil), // 6: scinternacional.v1.RestoreChampionshipRequest
+	nil,                                // 7: scinternacional.v1.Championship.NamesEntry
+	(*Team)(nil),                       // 8: scinternacional.v1.Team
+	(*timestamppb.Timestamp)(nil),      // 9: google.protobuf.Timestamp
+}
+var file_scinternacional_v1_championships_proto_depIdxs = []int32{
+	8,  // 0: scinternacional.v1.Championship.teams:type_name -> scinternacional.v1.Team
+	9,  // 1: scinternacional.v1.Championship.deleted_at:type_name -> google.protobuf.Timestamp
+	7,  // 2: scinternacional.v1.Championship.names:type_name -> scinternacional.v1.Championship.NamesEntry
+	0,  // 3: scinternacional.v1.CreateChampionshipRequest.championship:type_name -> scinternacional.v1.Championship
+	0,  // 4: scinternacional.v1.UpdateChampionshipRequest.championship:type_name -> scinternacional.v1.Championship
+	1,  // 5: scinternacional.v1.Championships.GetChampionship:input_type -> scinternacional.v1.GetChampionshipRequest
+	2,  // 6: scinternacional.v1.Championships.CreateChampionship:input_type -> scinternacional.v1.CreateChampionshipRequest
+	3,  // 7: scinternacional.v1.Championships.UpdateChampionship:input_type -> scinternacional.v1.UpdateChampionshipRequest
+	4,  // 8: scinternacional.v1.Championships.DeleteChampionship:input_type -> scinternacional.v1.DeleteChampionshipRequest
+	6,  // 9: scinternacional.v1.Championships.RestoreChampionship:input_type -> scinternacional.v1.RestoreChampionshipRequest
+	0,  // 10: scinternacional.v1.Championships.GetChampionship:output_type -> scinternacional.v1.Championship
+	0,  // 11: scinternacional.v1.Championships.CreateChampionship:output_type -> scinternacional.v1.Championship
+	0,  // 12: scinternacional.v1.Championships.UpdateChampionship:output_type -> scinternacional.v1.Championship
+	5,  // 13: scinternacional.v1.Championships.DeleteChampionship:output_type -> scinternacional.v1.DeleteChampionshipResponse
+	0,  // 14: scinternacional.v1.Championships.RestoreChampionship:output_type -> scinternacional.v1.Championship
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_scinternacional_v1_championships_proto_init() }
+func file_scinternacional_v1_championships_proto_init() {
+	if File_scinternacional_v1_championships_proto != nil {
+		return
+	}
+	file_scinternacional_v1_teams_proto_init()
+	file_scinternacional_v1_championships_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_scinternacional_v1_championships_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scinternacional_v1_championships_proto_goTypes,
+		DependencyIndexes: file_scinternacional_v1_championships_proto_depIdxs,
+		MessageInfos:      file_scinternacional_v1_championships_proto_msgTypes,
+	}.Build()
+	File_scinternacional_v1_championships_proto = out.File
+	file_scinternacional_v1_championships_proto_rawDesc = nil
+	file_scinternacional_v1_championships_proto_goTypes = nil
+	file_scinternacional_v1_championships_proto_depIdxs = nil
+}
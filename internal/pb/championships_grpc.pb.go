@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: scinternacional/v1/championships.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Championships_GetChampionship_FullMethodName     = "/scinternacional.v1.Championships/GetChampionship"
+	Championships_CreateChampionship_FullMethodName  = "/scinternacional.v1.Championships/CreateChampionship"
+	Championships_UpdateChampionship_FullMethodName  = "/scinternacional.v1.Championships/UpdateChampionship"
+	Championships_DeleteChampionship_FullMethodName  = "/scinternacional.v1.Championships/DeleteChampionship"
+	Championships_RestoreChampionship_FullMethodName = "/scinternacional.v1.Championships/RestoreChampionship"
+)
+
+// ChampionshipsClient is the client API for Championships service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChampionshipsClient interface {
+	GetChampionship(ctx context.Context, in *GetChampionshipRequest, opts ...grpc.CallOption) (*Championship, error)
+	CreateChampionship(ctx context.Context, in *CreateChampionshipRequest, opts ...grpc.CallOption) (*Championship, error)
+	UpdateChampionship(ctx context.Context, in *UpdateChampionshipRequest, opts ...grpc.CallOption) (*Championship, error)
+	DeleteChampionship(ctx context.Context, in *DeleteChampionshipRequest, opts ...grpc.CallOption) (*DeleteChampionshipResponse, error)
+	RestoreChampionship(ctx context.Context, in *RestoreChampionshipRequest, opts ...grpc.CallOption) (*Championship, error)
+}
+
+type championshipsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChampionshipsClient(cc grpc.ClientConnInterface) ChampionshipsClient {
+	return &championshipsClient{cc}
+}
+
+func (c *championshipsClient) GetChampionship(ctx context.Context, in *GetChampionshipRequest, opts ...grpc.CallOption) (*Championship, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Championship)
+	err := c.cc.Invoke(ctx, Championships_GetChampionship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *championshipsClient) CreateChampionship(ctx context.Context, in *CreateChampionshipRequest, opts ...grpc.CallOption) (*Championship, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Championship)
+	err := c.cc.Invoke(ctx, Championships_CreateChampionship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *championshipsClient) UpdateChampionship(ctx context.Context, in *UpdateChampionshipRequest, opts ...grpc.CallOption) (*Championship, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Championship)
+	err := c.cc.Invoke(ctx, Championships_UpdateChampionship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *championshipsClient) DeleteChampionship(ctx context.Context, in *DeleteChampionshipRequest, opts ...grpc.CallOption) (*DeleteChampionshipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteChampionshipResponse)
+	err := c.cc.Invoke(ctx, Championships_DeleteChampionship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *championshipsClient) RestoreChampionship(ctx context.Context, in *RestoreChampionshipRequest, opts ...grpc.CallOption) (*Championship, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Championship)
+	err := c.cc.Invoke(ctx, Championships_RestoreChampionship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChampionshipsServer is the server API for Championships service.
+// All implementations must embed UnimplementedChampionshipsServer
+// for forward compatibility.
+type ChampionshipsServer interface {
+	GetChampionship(context.Context, *GetChampionshipRequest) (*Championship, error)
+	CreateChampionship(context.Context, *CreateChampionshipRequest) (*Championship, error)
+	UpdateChampionship(context.Context, *UpdateChampionshipRequest) (*Championship, error)
+	DeleteChampionship(context.Context, *DeleteChampionshipRequest) (*DeleteChampionshipResponse, error)
+	RestoreChampionship(context.Context, *RestoreChampionshipRequest) (*Championship, error)
+	mustEmbedUnimplementedChampionshipsServer()
+}
+
+// UnimplementedChampionshipsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChampionshipsServer struct{}
+
+func (UnimplementedChampionshipsServer) GetChampionship(context.Context, *GetChampionshipRequest) (*Championship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChampionship not implemented")
+}
+func (UnimplementedChampionshipsServer) CreateChampionship(context.Context, *CreateChampionshipRequest) (*Championship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChampionship not implemented")
+}
+func (UnimplementedChampionshipsServer) UpdateChampionship(context.Context, *UpdateChampionshipRequest) (*Championship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChampionship not implemented")
+}
+func (UnimplementedChampionshipsServer) DeleteChampionship(context.Context, *DeleteChampionshipRequest) (*DeleteChampionshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChampionship not implemented")
+}
+func (UnimplementedChampionshipsServer) RestoreChampionship(context.Context, *RestoreChampionshipRequest) (*Championship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreChampionship not implemented")
+}
+func (UnimplementedChampionshipsServer) mustEmbedUnimplementedChampionshipsServer() {}
+func (UnimplementedChampionshipsServer) testEmbeddedByValue()                       {}
+
+// UnsafeChampionshipsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChampionshipsServer will
+// result in compilation errors.
+type UnsafeChampionshipsServer interface {
+	mustEmbedUnimplementedChampionshipsServer()
+}
+
+func RegisterChampionshipsServer(s grpc.ServiceRegistrar, srv ChampionshipsServer) {
+	// If the following call pancis, it indicates UnimplementedChampionshipsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Championships_ServiceDesc, srv)
+}
+
+func _Championships_GetChampionship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChampionshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChampionshipsServer).GetChampionship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Championships_GetChampionship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChampionshipsServer).GetChampionship(ctx, req.(*GetChampionshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Championships_CreateChampionship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChampionshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChampionshipsServer).CreateChampionship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Championships_CreateChampionship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChampionshipsServer).CreateChampionship(ctx, req.(*CreateChampionshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Championships_UpdateChampionship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChampionshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChampionshipsServer).UpdateChampionship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Championships_UpdateChampionship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChampionshipsServer).UpdateChampionship(ctx, req.(*UpdateChampionshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Championships_DeleteChampionship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChampionshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChampionshipsServer).DeleteChampionship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Championships_DeleteChampionship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChampionshipsServer).DeleteChampionship(ctx, req.(*DeleteChampionshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Championships_RestoreChampionship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreChampionshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChampionshipsServer).RestoreChampionship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Championships_RestoreChampionship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChampionshipsServer).RestoreChampionship(ctx, req.(*RestoreChampionshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Championships_ServiceDesc is the grpc.ServiceDesc for Championships service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Championships_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scinternacional.v1.Championships",
+	HandlerType: (*ChampionshipsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetChampionship",
+			Handler:    _Championships_GetChampionship_Handler,
+		},
+		{
+			MethodName: "CreateChampionship",
+			Handler:    _Championships_CreateChampionship_Handler,
+		},
+		{
+			MethodName: "UpdateChampionship",
+			Handler:    _Championships_UpdateChampionship_Handler,
+		},
+		{
+			MethodName: "DeleteChampionship",
+			Handler:    _Championships_DeleteChampionship_Handler,
+		},
+		{
+			MethodName: "RestoreChampionship",
+			Handler:    _Championships_RestoreChampionship_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scinternacional/v1/championships.proto",
+}
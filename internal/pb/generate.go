@@ -0,0 +1,6 @@
+// Package pb holds the code protoc generates from the definitions under
+// proto/. Regenerate it with go generate ./internal/pb, which needs protoc,
+// protoc-gen-go and protoc-gen-go-grpc on the PATH.
+package pb
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=sc-internacional --go-grpc_out=../.. --go-grpc_opt=module=sc-internacional scinternacional/v1/teams.proto scinternacional/v1/championships.proto scinternacional/v1/matches.proto
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: scinternacional/v1/matches.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Matches_GetMatch_FullMethodName     = "/scinternacional.v1.Matches/GetMatch"
+	Matches_ListMatches_FullMethodName  = "/scinternacional.v1.Matches/ListMatches"
+	Matches_CreateMatch_FullMethodName  = "/scinternacional.v1.Matches/CreateMatch"
+	Matches_UpdateMatch_FullMethodName  = "/scinternacional.v1.Matches/UpdateMatch"
+	Matches_DeleteMatch_FullMethodName  = "/scinternacional.v1.Matches/DeleteMatch"
+	Matches_RestoreMatch_FullMethodName = "/scinternacional.v1.Matches/RestoreMatch"
+)
+
+// MatchesClient is the client API for Matches service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MatchesClient interface {
+	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*Match, error)
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Match], error)
+	CreateMatch(ctx context.Context, in *CreateMatchRequest, opts ...grpc.CallOption) (*Match, error)
+	UpdateMatch(ctx context.Context, in *UpdateMatchRequest, opts ...grpc.CallOption) (*Match, error)
+	DeleteMatch(ctx context.Context, in *DeleteMatchRequest, opts ...grpc.CallOption) (*DeleteMatchResponse, error)
+	RestoreMatch(ctx context.Context, in *RestoreMatchRequest, opts ...grpc.CallOption) (*Match, error)
+}
+
+type matchesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchesClient(cc grpc.ClientConnInterface) MatchesClient {
+	return &matchesClient{cc}
+}
+
+func (c *matchesClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*Match, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Match)
+	err := c.cc.Invoke(ctx, Matches_GetMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Match], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matches_ServiceDesc.Streams[0], Matches_ListMatches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListMatchesRequest, Match]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matches_ListMatchesClient = grpc.ServerStreamingClient[Match]
+
+func (c *matchesClient) CreateMatch(ctx context.Context, in *CreateMatchRequest, opts ...grpc.CallOption) (*Match, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Match)
+	err := c.cc.Invoke(ctx, Matches_CreateMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesClient) UpdateMatch(ctx context.Context, in *UpdateMatchRequest, opts ...grpc.CallOption) (*Match, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Match)
+	err := c.cc.Invoke(ctx, Matches_UpdateMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesClient) DeleteMatch(ctx context.Context, in *DeleteMatchRequest, opts ...grpc.CallOption) (*DeleteMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMatchResponse)
+	err := c.cc.Invoke(ctx, Matches_DeleteMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesClient) RestoreMatch(ctx context.Context, in *RestoreMatchRequest, opts ...grpc.CallOption) (*Match, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Match)
+	err := c.cc.Invoke(ctx, Matches_RestoreMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatchesServer is the server API for Matches service.
+// All implementations must embed UnimplementedMatchesServer
+// for forward compatibility.
+type MatchesServer interface {
+	GetMatch(context.Context, *GetMatchRequest) (*Match, error)
+	ListMatches(*ListMatchesRequest, grpc.ServerStreamingServer[Match]) error
+	CreateMatch(context.Context, *CreateMatchRequest) (*Match, error)
+	UpdateMatch(context.Context, *UpdateMatchRequest) (*Match, error)
+	DeleteMatch(context.Context, *DeleteMatchRequest) (*DeleteMatchResponse, error)
+	RestoreMatch(context.Context, *RestoreMatchRequest) (*Match, error)
+	mustEmbedUnimplementedMatchesServer()
+}
+
+// UnimplementedMatchesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatchesServer struct{}
+
+func (UnimplementedMatchesServer) GetMatch(context.Context, *GetMatchRequest) (*Match, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (UnimplementedMatchesServer) ListMatches(*ListMatchesRequest, grpc.ServerStreamingServer[Match]) error {
+	return status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedMatchesServer) CreateMatch(context.Context, *CreateMatchRequest) (*Match, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMatch not implemented")
+}
+func (UnimplementedMatchesServer) UpdateMatch(context.Context, *UpdateMatchRequest) (*Match, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMatch not implemented")
+}
+func (UnimplementedMatchesServer) DeleteMatch(context.Context, *DeleteMatchRequest) (*DeleteMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatch not implemented")
+}
+func (UnimplementedMatchesServer) RestoreMatch(context.Context, *RestoreMatchRequest) (*Match, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMatch not implemented")
+}
+func (UnimplementedMatchesServer) mustEmbedUnimplementedMatchesServer() {}
+func (UnimplementedMatchesServer) testEmbeddedByValue()                 {}
+
+// UnsafeMatchesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchesServer will
+// result in compilation errors.
+type UnsafeMatchesServer interface {
+	mustEmbedUnimplementedMatchesServer()
+}
+
+func RegisterMatchesServer(s grpc.ServiceRegistrar, srv MatchesServer) {
+	// If the following call pancis, it indicates UnimplementedMatchesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Matches_ServiceDesc, srv)
+}
+
+func _Matches_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matches_GetMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServer).GetMatch(ctx, req.(*GetMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matches_ListMatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMatchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchesServer).ListMatches(m, &grpc.GenericServerStream[ListMatchesRequest, Match]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matches_ListMatchesServer = grpc.ServerStreamingServer[Match]
+
+func _Matches_CreateMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServer).CreateMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matches_CreateMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServer).CreateMatch(ctx, req.(*CreateMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matches_UpdateMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServer).UpdateMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matches_UpdateMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServer).UpdateMatch(ctx, req.(*UpdateMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matches_DeleteMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServer).DeleteMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matches_DeleteMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServer).DeleteMatch(ctx, req.(*DeleteMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matches_RestoreMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServer).RestoreMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matches_RestoreMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServer).RestoreMatch(ctx, req.(*RestoreMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Matches_ServiceDesc is the grpc.ServiceDesc for Matches service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Matches_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scinternacional.v1.Matches",
+	HandlerType: (*MatchesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMatch",
+			Handler:    _Matches_GetMatch_Handler,
+		},
+		{
+			MethodName: "CreateMatch",
+			Handler:    _Matches_CreateMatch_Handler,
+		},
+		{
+			MethodName: "UpdateMatch",
+			Handler:    _Matches_UpdateMatch_Handler,
+		},
+		{
+			MethodName: "DeleteMatch",
+			Handler:    _Matches_DeleteMatch_Handler,
+		},
+		{
+			MethodName: "RestoreMatch",
+			Handler:    _Matches_RestoreMatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListMatches",
+			Handler:       _Matches_ListMatches_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "scinternacional/v1/matches.proto",
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config holds the token bucket of each route group: the sustained rate in
+// requests per second and the burst a client may spend at once.
+type Config struct {
+	ReadsPerSecond  float64       `env:"RATE_LIMIT_READS_PER_SECOND" envDefault:"20"`
+	ReadsBurst      int           `env:"RATE_LIMIT_READS_BURST" envDefault:"40"`
+	WritesPerSecond float64       `env:"RATE_LIMIT_WRITES_PER_SECOND" envDefault:"2"`
+	WritesBurst     int           `env:"RATE_LIMIT_WRITES_BURST" envDefault:"5"`
+	IdleTTL         time.Duration `env:"RATE_LIMIT_IDLE_TTL" envDefault:"10m"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
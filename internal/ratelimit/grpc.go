@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+)
+
+// UnaryInterceptor is the gRPC counterpart of Middleware: it takes a token
+// from the bucket limiters lists for the method, per authenticated caller or
+// per peer address for anonymous calls. Methods missing from limiters are not
+// limited. It must run after the auth interceptor.
+func UnaryInterceptor(limiters map[string]*Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := limit(ctx, limiters[info.FullMethod]); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming methods.
+func StreamInterceptor(limiters map[string]*Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limit(stream.Context(), limiters[info.FullMethod]); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func limit(ctx context.Context, l *Limiter) error {
+	if l == nil {
+		return nil
+	}
+
+	allowed, retryAfter := l.allow(clientKey(ctx, peerIP(ctx)))
+	if !allowed {
+		// Sent as the header of the failed call, as REST sends Retry-After.
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfterSeconds(retryAfter)))
+		return status.Error(codes.ResourceExhausted, errLimited.Error())
+	}
+
+	return nil
+}
+
+// peerIP is the address of the connected peer without its port. gRPC has no
+// forwarded header to trust, so proxies in front of it share one bucket.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"sc-internacional/internal/auth"
+	"testing"
+	"time"
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(1, 1, time.Minute)
+	l.now = func() time.Time { return now }
+	interceptor := UnaryInterceptor(map[string]*Limiter{"/scinternacional.v1.Teams/GetTeam": l})
+
+	call := func(method, ip, subject string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}})
+		if subject != "" {
+			ctx = auth.WithPrincipal(ctx, auth.Principal{Subject: subject, Role: auth.Reader})
+		}
+		handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	assert.NoError(t, call("/scinternacional.v1.Teams/GetTeam", "10.0.0.1", ""))
+
+	err := call("/scinternacional.v1.Teams/GetTeam", "10.0.0.1", "")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "anonymous callers are keyed by peer address")
+	assert.Equal(t, "rate limit exceeded", status.Convert(err).Message())
+
+	assert.NoError(t, call("/scinternacional.v1.Teams/GetTeam", "10.0.0.2", ""), "other peers keep their own bucket")
+	assert.NoError(t, call("/scinternacional.v1.Teams/GetTeam", "10.0.0.1", "discord-bot"), "authenticated callers are keyed by subject")
+	assert.NoError(t, call("/scinternacional.v1.Teams/Unknown", "10.0.0.1", ""), "methods without a bucket are not limited")
+
+	now = now.Add(time.Second)
+	assert.NoError(t, call("/scinternacional.v1.Teams/GetTeam", "10.0.0.1", ""), "tokens refill over time")
+}
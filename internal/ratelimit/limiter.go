@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+	"math"
+	"net/http"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/i18n"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var errLimited = errors.New("rate limit exceeded")
+
+type client struct {
+	bucket   *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter keeps one token bucket per client. Buckets idle for longer than
+// the TTL are dropped, so memory stays bounded by the active clients.
+type Limiter struct {
+	limit     rate.Limit
+	burst     int
+	ttl       time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+	clients   map[string]*client
+	lastSweep time.Time
+}
+
+func NewLimiter(perSecond float64, burst int, ttl time.Duration) *Limiter {
+	return &Limiter{limit: rate.Limit(perSecond), burst: burst, ttl: ttl, now: time.Now, clients: map[string]*client{}}
+}
+
+// Limiters holds the buckets of reads and writes. The REST and gRPC APIs share
+// them, so that a client spends the same budget over either.
+type Limiters struct {
+	Reads  *Limiter
+	Writes *Limiter
+}
+
+func NewLimiters(cfg *Config) Limiters {
+	return Limiters{
+		Reads:  NewLimiter(cfg.ReadsPerSecond, cfg.ReadsBurst, cfg.IdleTTL),
+		Writes: NewLimiter(cfg.WritesPerSecond, cfg.WritesBurst, cfg.IdleTTL),
+	}
+}
+
+// allow takes a token from key's bucket, returning how long the client must
+// wait when the bucket is empty.
+func (l *Limiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{bucket: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+
+	reservation := c.bucket.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, l.ttl
+	}
+
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+
+	return true, 0
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.ttl {
+		return
+	}
+	l.lastSweep = now
+
+	for key, c := range l.clients {
+		if now.Sub(c.lastSeen) > l.ttl {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// Middleware limits requests per authenticated caller, or per client IP for
+// anonymous requests. It must run after auth.Authenticate. The client IP is
+// only read from X-Forwarded-For when the peer is one of the engine's trusted
+// proxies, so that clients cannot pick a fresh bucket per request.
+func Middleware(l *Limiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		allowed, retryAfter := l.allow(clientKey(ctx.Request.Context(), ctx.ClientIP()))
+		if !allowed {
+			ctx.Header("Retry-After", retryAfterSeconds(retryAfter))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": i18n.Message(ctx.Request.Context(), errLimited)})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// clientKey names the bucket of the authenticated caller, or of ip for
+// anonymous ones.
+func clientKey(ctx context.Context, ip string) string {
+	if principal, ok := auth.PrincipalFrom(ctx); ok {
+		return "principal:" + principal.Subject
+	}
+
+	return "ip:" + ip
+}
+
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+}
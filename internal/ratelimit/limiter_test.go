@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sc-internacional/internal/auth"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(1, 2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	r := gin.New()
+	r.SetTrustedProxies(nil)
+	r.Use(func(ctx *gin.Context) {
+		if subject := ctx.GetHeader("X-Subject"); subject != "" {
+			ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), auth.Principal{Subject: subject, Role: auth.Reader}))
+		}
+	}, Middleware(l))
+	r.GET("/teams", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	// Every request claims another forwarded address.
+	forwarded := 0
+	get := func(ip, subject string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/teams", nil).WithContext(context.Background())
+		request.RemoteAddr = ip + ":1234"
+		forwarded++
+		request.Header.Set("X-Forwarded-For", "192.0.2."+strconv.Itoa(forwarded))
+		if subject != "" {
+			request.Header.Set("X-Subject", subject)
+		}
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, get("10.0.0.1", "").Code)
+	assert.Equal(t, http.StatusOK, get("10.0.0.1", "").Code)
+
+	limited := get("10.0.0.1", "")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code, "forwarded addresses from untrusted peers are ignored")
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "{\"error\":\"rate limit exceeded\"}", limited.Body.String())
+
+	assert.Equal(t, http.StatusOK, get("10.0.0.2", "").Code, "other clients keep their own bucket")
+	assert.Equal(t, http.StatusOK, get("10.0.0.1", "discord-bot").Code, "authenticated callers are keyed by subject")
+
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, get("10.0.0.1", "").Code, "tokens refill over time")
+}
+
+func TestLimiter_DropsIdleClients(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(1, 1, time.Minute)
+	l.now = func() time.Time { return now }
+
+	l.allow("ip:10.0.0.1")
+	now = now.Add(2 * time.Minute)
+	l.allow("ip:10.0.0.2")
+
+	assert.Len(t, l.clients, 1)
+	assert.Contains(t, l.clients, "ip:10.0.0.2")
+}
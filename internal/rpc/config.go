@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config sets up the gRPC server. It listens on its own port, next to the
+// HTTP one, and shares the TLS files of the HTTP server when they are set.
+type Config struct {
+	Port            int           `env:"GRPC_PORT" envDefault:"9090"`
+	ShutdownTimeout time.Duration `env:"GRPC_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	MaxRecvMsgBytes int           `env:"GRPC_MAX_RECV_MSG_BYTES" envDefault:"1048576"`
+	TLSCertFile     string        `env:"TLS_CERT_FILE"`
+	TLSKeyFile      string        `env:"TLS_KEY_FILE"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Port <= 0 || cfg.Port > 65535 {
+		return nil, errors.New("GRPC_PORT must be a TCP port")
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	return &cfg, nil
+}
+
+func (c Config) tls() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
@@ -0,0 +1,53 @@
+// Package rpc holds what the gRPC servers of teams, championships and
+// matches share: mapping service errors and arguments onto gRPC statuses.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sc-internacional/internal/etag"
+)
+
+// Error maps a service error to the status code matching the HTTP status the
+// controllers answer with. Errors in conflicts, such as deleting a record
+// still referenced, fail the precondition.
+func Error(err error, conflicts ...error) error {
+	if err == nil {
+		return nil
+	}
+	for _, conflict := range conflicts {
+		if errors.Is(err, conflict) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, etag.ErrMismatch):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, primitive.ErrInvalidHex):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case status.Code(err) != codes.Unknown:
+		return err
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// ExpectedVersion is the gRPC counterpart of etag.Version: a missing version
+// fails the precondition and -1 stands for etag.Any. entity names the record
+// in the error.
+func ExpectedVersion(version *int64, entity string) (int64, error) {
+	if version == nil {
+		return 0, status.Error(codes.FailedPrecondition, "version is required")
+	}
+	if *version < etag.Any {
+		return 0, status.Error(codes.InvalidArgument, "version must be -1 or a "+entity+" version")
+	}
+
+	return *version, nil
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sc-internacional/internal/etag"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	errReferenced := errors.New("team is still referenced by matches or championships")
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{name: "when there is no error", err: nil, expectedCode: codes.OK},
+		{name: "when the record is referenced", err: fmt.Errorf("deleting: %w", errReferenced), expectedCode: codes.FailedPrecondition},
+		{name: "when the version is stale", err: etag.ErrMismatch, expectedCode: codes.Aborted},
+		{name: "when the id is malformed", err: primitive.ErrInvalidHex, expectedCode: codes.InvalidArgument},
+		{name: "when the call was canceled", err: context.Canceled, expectedCode: codes.Canceled},
+		{name: "when the error already has a status", err: status.Error(codes.NotFound, "team not found"), expectedCode: codes.NotFound},
+		{name: "when anything else failed", err: errors.New("failed to find"), expectedCode: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedCode, status.Code(Error(tt.err, errReferenced)))
+		})
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	version := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name         string
+		version      *int64
+		want         int64
+		expectedCode codes.Code
+	}{
+		{name: "when the version is missing", version: nil, want: 0, expectedCode: codes.FailedPrecondition},
+		{name: "when the version is invalid", version: version(-2), want: 0, expectedCode: codes.InvalidArgument},
+		{name: "when any version goes", version: version(-1), want: etag.Any, expectedCode: codes.OK},
+		{name: "when the version is given", version: version(3), want: 3, expectedCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpectedVersion(tt.version, "team")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package rpc serves the gRPC API defined under proto/, next to the REST one
+// and through the same services.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"log/slog"
+	"net"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/logging"
+	"sc-internacional/internal/metrics"
+	"sc-internacional/internal/pb"
+	"sc-internacional/internal/ratelimit"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+// roles lists the role each method requires, matching the route groups of
+// the REST API: reads are public when AUTH_PUBLIC_READS is set and writes
+// take an editor.
+var roles = map[string]auth.Role{
+	pb.Teams_GetTeam_FullMethodName:                     auth.Reader,
+	pb.Teams_ListTeams_FullMethodName:                   auth.Reader,
+	pb.Teams_CreateTeam_FullMethodName:                  auth.Editor,
+	pb.Teams_UpdateTeam_FullMethodName:                  auth.Editor,
+	pb.Teams_DeleteTeam_FullMethodName:                  auth.Editor,
+	pb.Teams_RestoreTeam_FullMethodName:                 auth.Editor,
+	pb.Championships_GetChampionship_FullMethodName:     auth.Reader,
+	pb.Championships_CreateChampionship_FullMethodName:  auth.Editor,
+	pb.Championships_UpdateChampionship_FullMethodName:  auth.Editor,
+	pb.Championships_DeleteChampionship_FullMethodName:  auth.Editor,
+	pb.Championships_RestoreChampionship_FullMethodName: auth.Editor,
+	pb.Matches_GetMatch_FullMethodName:                  auth.Reader,
+	pb.Matches_ListMatches_FullMethodName:               auth.Reader,
+	pb.Matches_CreateMatch_FullMethodName:               auth.Editor,
+	pb.Matches_UpdateMatch_FullMethodName:               auth.Editor,
+	pb.Matches_DeleteMatch_FullMethodName:               auth.Editor,
+	pb.Matches_RestoreMatch_FullMethodName:              auth.Editor,
+}
+
+type Server struct {
+	config Config
+	grpc   *grpc.Server
+}
+
+// limits maps each method to the bucket of its route group in the REST API.
+func limits(limiters ratelimit.Limiters) map[string]*ratelimit.Limiter {
+	byMethod := make(map[string]*ratelimit.Limiter, len(roles))
+	for method, role := range roles {
+		byMethod[method] = limiters.Writes
+		if role == auth.Reader {
+			byMethod[method] = limiters.Reads
+		}
+	}
+
+	return byMethod
+}
+
+func New(config *Config, logger *slog.Logger, authenticator *auth.Authenticator, limiters ratelimit.Limiters, teams pb.TeamsServer, championships pb.ChampionshipsServer, matches pb.MatchesServer) (*Server, error) {
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(tracing.StatsHandler()),
+		grpc.MaxRecvMsgSize(config.MaxRecvMsgBytes),
+		grpc.ChainUnaryInterceptor(logging.UnaryInterceptor(logger), metrics.UnaryInterceptor(), authenticator.UnaryInterceptor(roles), ratelimit.UnaryInterceptor(limits(limiters))),
+		grpc.ChainStreamInterceptor(logging.StreamInterceptor(logger), metrics.StreamInterceptor(), authenticator.StreamInterceptor(roles), ratelimit.StreamInterceptor(limits(limiters))),
+	}
+	if config.tls() {
+		creds, err := credentials.NewServerTLSFromFile(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterTeamsServer(server, teams)
+	pb.RegisterChampionshipsServer(server, championships)
+	pb.RegisterMatchesServer(server, matches)
+
+	return &Server{config: *config, grpc: server}, nil
+}
+
+// Run serves gRPC until ctx is done. It then stops accepting calls and waits
+// up to ShutdownTimeout for in-flight ones, streams included, before cutting
+// them off.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+	if err != nil {
+		return err
+	}
+
+	return s.serve(ctx, listener)
+}
+
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	errs := make(chan error, 1)
+	go func() { errs <- s.grpc.Serve(listener) }()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.config.ShutdownTimeout):
+		s.grpc.Stop()
+	}
+
+	return <-errs
+}
@@ -0,0 +1,204 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/pb"
+	"sc-internacional/internal/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name         string
+		metadata     map[string]string
+		call         func(ctx context.Context, conn *grpc.ClientConn) error
+		expectedCode codes.Code
+	}{
+		{
+			name: "when reading anonymously",
+			call: func(ctx context.Context, conn *grpc.ClientConn) error {
+				_, err := pb.NewTeamsClient(conn).GetTeam(ctx, &pb.GetTeamRequest{Id: "1"})
+				return err
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:     "when reading with a reader api key",
+			metadata: map[string]string{"x-api-key": "fan-key"},
+			call: func(ctx context.Context, conn *grpc.ClientConn) error {
+				_, err := pb.NewTeamsClient(conn).GetTeam(ctx, &pb.GetTeamRequest{Id: "1"})
+				return err
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:     "when streaming with a reader api key",
+			metadata: map[string]string{"x-api-key": "fan-key"},
+			call: func(ctx context.Context, conn *grpc.ClientConn) error {
+				stream, err := pb.NewTeamsClient(conn).ListTeams(ctx, &pb.ListTeamsRequest{})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:     "when writing with a reader api key",
+			metadata: map[string]string{"x-api-key": "fan-key"},
+			call: func(ctx context.Context, conn *grpc.ClientConn) error {
+				_, err := pb.NewTeamsClient(conn).CreateTeam(ctx, &pb.CreateTeamRequest{})
+				return err
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:     "when method is not implemented",
+			metadata: map[string]string{"x-api-key": "bot-key"},
+			call: func(ctx context.Context, conn *grpc.ClientConn) error {
+				_, err := pb.NewMatchesClient(conn).CreateMatch(ctx, &pb.CreateMatchRequest{})
+				return err
+			},
+			expectedCode: codes.Unimplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := start(t)
+
+			ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(tt.metadata))
+			err := tt.call(ctx, conn)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestServer_DrainsInFlightCallsOnShutdown(t *testing.T) {
+	started := make(chan struct{})
+	teams := teamsServer{getTeam: func(ctx context.Context) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+	}}
+	s, listener := newServer(t, teams)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.serve(ctx, listener) }()
+
+	conn := dial(t, listener)
+	called := make(chan error, 1)
+	go func() {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "fan-key")
+		_, err := pb.NewTeamsClient(conn).GetTeam(ctx, &pb.GetTeamRequest{Id: "1"})
+		called <- err
+	}()
+
+	<-started
+	cancel()
+
+	assert.NoError(t, <-called)
+	assert.NoError(t, <-served)
+}
+
+func TestServer_RateLimits(t *testing.T) {
+	limiters := ratelimit.NewLimiters(&ratelimit.Config{ReadsPerSecond: 0.001, ReadsBurst: 1, WritesPerSecond: 0.001, WritesBurst: 1, IdleTTL: time.Minute})
+	s, listener := newLimitedServer(t, teamsServer{}, limiters)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.serve(ctx, listener) }()
+	t.Cleanup(func() {
+		cancel()
+		<-served
+	})
+	conn := dial(t, listener)
+
+	fan := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "fan-key")
+	_, err := pb.NewTeamsClient(conn).GetTeam(fan, &pb.GetTeamRequest{Id: "1"})
+	assert.NoError(t, err)
+
+	var header metadata.MD
+	_, err = pb.NewTeamsClient(conn).GetTeam(fan, &pb.GetTeamRequest{Id: "1"}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NotEmpty(t, header.Get("retry-after"))
+
+	stream, err := pb.NewTeamsClient(conn).ListTeams(fan, &pb.ListTeamsRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "streams share the bucket of reads")
+
+	bot := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "bot-key")
+	_, err = pb.NewTeamsClient(conn).GetTeam(bot, &pb.GetTeamRequest{Id: "1"})
+	assert.NoError(t, err, "other callers keep their own bucket")
+}
+
+// start serves a Teams service that knows a single team until the test ends.
+func start(t *testing.T) *grpc.ClientConn {
+	s, listener := newServer(t, teamsServer{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.serve(ctx, listener) }()
+	t.Cleanup(func() {
+		cancel()
+		<-served
+	})
+
+	return dial(t, listener)
+}
+
+func newServer(t *testing.T, teams pb.TeamsServer) (*Server, net.Listener) {
+	return newLimitedServer(t, teams, ratelimit.NewLimiters(&ratelimit.Config{ReadsPerSecond: 20, ReadsBurst: 40, WritesPerSecond: 2, WritesBurst: 5, IdleTTL: time.Minute}))
+}
+
+func newLimitedServer(t *testing.T, teams pb.TeamsServer, limiters ratelimit.Limiters) (*Server, net.Listener) {
+	authenticator, err := auth.New(&auth.Config{APIKeys: []string{"fan-site:reader:fan-key", "discord-bot:editor:bot-key"}})
+	assert.NoError(t, err)
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	s, err := New(&Config{ShutdownTimeout: time.Second, MaxRecvMsgBytes: 1024}, logger, authenticator, limiters, teams, pb.UnimplementedChampionshipsServer{}, pb.UnimplementedMatchesServer{})
+	assert.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	return s, listener
+}
+
+func dial(t *testing.T, listener net.Listener) *grpc.ClientConn {
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+type teamsServer struct {
+	pb.UnimplementedTeamsServer
+	getTeam func(ctx context.Context)
+}
+
+func (s teamsServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	if s.getTeam != nil {
+		s.getTeam(ctx)
+	}
+
+	return &pb.Team{Id: req.Id, Name: "Internacional"}, nil
+}
+
+func (s teamsServer) ListTeams(req *pb.ListTeamsRequest, stream grpc.ServerStreamingServer[pb.Team]) error {
+	return stream.Send(&pb.Team{Id: "1", Name: "Internacional"})
+}
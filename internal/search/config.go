@@ -0,0 +1,26 @@
+package search
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config picks the indexes searches use. TextIndex asks Mongo's text indexes
+// first, which storage without them must turn off to rely on the in-process
+// index alone. That one is reloaded every RefreshInterval.
+type Config struct {
+	TextIndex       bool          `env:"SEARCH_TEXT_INDEX" envDefault:"true"`
+	RefreshInterval time.Duration `env:"SEARCH_REFRESH_INTERVAL" envDefault:"5m"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.RefreshInterval <= 0 {
+		return nil, errors.New("SEARCH_REFRESH_INTERVAL must be positive")
+	}
+	return &cfg, nil
+}
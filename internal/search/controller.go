@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+	maxQuery     = 100
+)
+
+type service interface {
+	search(ctx context.Context, q string, limit int) (Results, error)
+}
+
+type Controller struct {
+	service service
+}
+
+func NewController(service service) *Controller {
+	return &Controller{service: service}
+}
+
+// Search finds the teams, championships and players named like the q query
+// parameter, returning up to limit of each.
+func (c Controller) Search(ctx *gin.Context) {
+	q := strings.TrimSpace(ctx.Query("q"))
+	if q == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("q is required")))
+		return
+	}
+	if utf8.RuneCountInString(q) > maxQuery {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("q must be at most 100 characters")))
+		return
+	}
+
+	limit := defaultLimit
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxLimit {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("limit must be between 1 and 50")))
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := c.service.search(ctx.Request.Context(), q, limit)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, results)
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestController_Search(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when q is missing",
+			setup:              func(s *serviceMock) {},
+			query:              "q=%20",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"q is required\"}",
+		},
+		{
+			name:               "when q is too long",
+			setup:              func(s *serviceMock) {},
+			query:              "q=" + strings.Repeat("a", 101),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"q must be at most 100 characters\"}",
+		},
+		{
+			name:               "when limit is invalid",
+			setup:              func(s *serviceMock) {},
+			query:              "q=inter&limit=51",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"limit must be between 1 and 50\"}",
+		},
+		{
+			name: "when failed to search",
+			setup: func(s *serviceMock) {
+				s.On("search", mock.Anything, "inter", 10).Return(Results{}, errors.New("failed to find"))
+			},
+			query:              "q=inter",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to find\"}",
+		},
+		{
+			name: "when successfully search",
+			setup: func(s *serviceMock) {
+				results := Results{
+					Teams:         []Hit{{Id: "1", Name: "Internacional", Description: "Sport Club Internacional", Score: 0.8}},
+					Championships: []Hit{},
+					Players:       []Hit{{Name: "Fernandão", Description: "Internacional", TeamId: "1", Score: 0.6}},
+				}
+				s.On("search", mock.Anything, "inter", 5).Return(results, nil)
+			},
+			query:              "q=+inter+&limit=5",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"teams\":[{\"id\":\"1\",\"name\":\"Internacional\",\"description\":\"Sport Club Internacional\",\"score\":0.8}],\"championships\":[],\"players\":[{\"name\":\"Fernandão\",\"description\":\"Internacional\",\"teamId\":\"1\",\"score\":0.6}]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/search?"+tt.query, nil)
+
+			c.Search(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) search(ctx context.Context, q string, limit int) (Results, error) {
+	args := m.Called(ctx, q, limit)
+
+	return args.Get(0).(Results), args.Error(1)
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"math"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+	"slices"
+	"strings"
+)
+
+type Kind string
+
+const (
+	TeamKind         Kind = "team"
+	ChampionshipKind Kind = "championship"
+	PlayerKind       Kind = "player"
+)
+
+// Field weights rank a hit on a team's name above one on its nicknames, and
+// both above one on its full name.
+const (
+	nameWeight     = 1.0
+	nicknameWeight = 0.9
+	fullNameWeight = 0.7
+)
+
+// Hit is something matching a query. Players are not entities of their own
+// but the names match incidents carry, so their hits have no id and point at
+// the team they played for instead.
+type Hit struct {
+	Id          string  `json:"id,omitempty"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	TeamId      string  `json:"teamId,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// Results are the hits of a query grouped by kind, best first.
+type Results struct {
+	Teams         []Hit `json:"teams"`
+	Championships []Hit `json:"championships"`
+	Players       []Hit `json:"players"`
+}
+
+func (r Results) isEmpty() bool {
+	return len(r.Teams) == 0 && len(r.Championships) == 0 && len(r.Players) == 0
+}
+
+type field struct {
+	text   string
+	weight float64
+}
+
+// document is one searchable hit and the fields it is found by.
+type document struct {
+	kind   Kind
+	hit    Hit
+	fields []field
+}
+
+// key identifies the hit of a document, so that a player named in several
+// matches is found once.
+func (d document) key() string {
+	return string(d.kind) + ":" + d.hit.Id + ":" + d.hit.TeamId + ":" + fold(d.hit.Name)
+}
+
+// Sources name what documents derive from, so that they can be replaced
+// when it changes.
+func teamSource(id string) string         { return "team:" + id }
+func championshipSource(id string) string { return "championship:" + id }
+func matchSource(id string) string        { return "match:" + id }
+
+func teamDocuments(team teams.Team) []document {
+	if team.DeletedAt != nil {
+		return nil
+	}
+
+	fields := []field{{team.Name, nameWeight}, {team.FullName, fullNameWeight}}
+	for _, nickname := range team.Nicknames {
+		fields = append(fields, field{nickname, nicknameWeight})
+	}
+
+	return []document{{kind: TeamKind, hit: Hit{Id: team.Id, Name: team.Name, Description: team.FullName}, fields: fields}}
+}
+
+func championshipDocuments(championship championships.Championship) []document {
+	if championship.DeletedAt != nil {
+		return nil
+	}
+
+	return []document{{
+		kind:   ChampionshipKind,
+		hit:    Hit{Id: championship.Id, Name: championship.Name, Description: championship.Season},
+		fields: []field{{championship.Name, nameWeight}},
+	}}
+}
+
+// matchDocuments returns a document per player the incidents of the match
+// name.
+func matchDocuments(match matches.Match) []document {
+	if match.DeletedAt != nil {
+		return nil
+	}
+
+	teamNames := map[string]string{match.TeamHomeId: match.TeamHomeName, match.TeamAwayId: match.TeamAwayName}
+	var documents []document
+	for _, incident := range match.Incidents {
+		if strings.TrimSpace(incident.Player) == "" {
+			continue
+		}
+
+		d := document{
+			kind:   PlayerKind,
+			hit:    Hit{Name: incident.Player, Description: teamNames[incident.TeamId], TeamId: incident.TeamId},
+			fields: []field{{incident.Player, nameWeight}},
+		}
+		if !slices.ContainsFunc(documents, func(other document) bool { return other.key() == d.key() }) {
+			documents = append(documents, d)
+		}
+	}
+
+	return documents
+}
+
+// rank groups the scored documents by kind, keeping the best score of each
+// hit and the limit best hits of each kind.
+func rank(found []document, limit int) Results {
+	best := map[string]document{}
+	for _, d := range found {
+		if other, ok := best[d.key()]; !ok || d.hit.Score > other.hit.Score {
+			best[d.key()] = d
+		}
+	}
+
+	results := Results{Teams: []Hit{}, Championships: []Hit{}, Players: []Hit{}}
+	for _, d := range best {
+		d.hit.Score = math.Round(d.hit.Score*1000) / 1000
+		switch d.kind {
+		case TeamKind:
+			results.Teams = append(results.Teams, d.hit)
+		case ChampionshipKind:
+			results.Championships = append(results.Championships, d.hit)
+		case PlayerKind:
+			results.Players = append(results.Players, d.hit)
+		}
+	}
+
+	for _, hits := range []*[]Hit{&results.Teams, &results.Championships, &results.Players} {
+		slices.SortFunc(*hits, func(a, b Hit) int {
+			if a.Score != b.Score {
+				if a.Score > b.Score {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		if len(*hits) > limit {
+			*hits = (*hits)[:limit]
+		}
+	}
+
+	return results
+}
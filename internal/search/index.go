@@ -0,0 +1,104 @@
+package search
+
+import "sync"
+
+// Index is the in-process search index: an inverted index from the folded
+// words of every searchable field to the documents holding them. Unlike the
+// text indexes of Mongo it finds words by their start and despite typos, and
+// it needs no particular storage.
+type Index struct {
+	mu       sync.RWMutex
+	sources  map[string][]*document
+	postings map[string]map[*document]float64
+}
+
+func NewIndex() *Index {
+	return &Index{sources: map[string][]*document{}, postings: map[string]map[*document]float64{}}
+}
+
+// replace swaps the documents derived from source for the given ones.
+func (i *Index) replace(source string, documents []document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(source)
+	i.add(source, documents)
+}
+
+// reset swaps the whole content of the index.
+func (i *Index) reset(sources map[string][]document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.sources = map[string][]*document{}
+	i.postings = map[string]map[*document]float64{}
+	for source, documents := range sources {
+		i.add(source, documents)
+	}
+}
+
+func (i *Index) add(source string, documents []document) {
+	for _, d := range documents {
+		i.sources[source] = append(i.sources[source], &d)
+		for _, f := range d.fields {
+			for _, word := range tokenize(f.text) {
+				if i.postings[word] == nil {
+					i.postings[word] = map[*document]float64{}
+				}
+				i.postings[word][&d] = max(i.postings[word][&d], f.weight)
+			}
+		}
+	}
+}
+
+func (i *Index) remove(source string) {
+	for _, d := range i.sources[source] {
+		for _, f := range d.fields {
+			for _, word := range tokenize(f.text) {
+				delete(i.postings[word], d)
+				if len(i.postings[word]) == 0 {
+					delete(i.postings, word)
+				}
+			}
+		}
+	}
+	delete(i.sources, source)
+}
+
+// search scores every document by how well its words match each term of q,
+// averaging over the terms, and ranks those matching any.
+func (i *Index) search(q string, limit int) Results {
+	terms := tokenize(q)
+	if len(terms) == 0 {
+		return rank(nil, limit)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	scores := map[*document]float64{}
+	for _, term := range terms {
+		best := map[*document]float64{}
+		for word, documents := range i.postings {
+			s := similarity(term, word)
+			if s == 0 {
+				continue
+			}
+			for d, weight := range documents {
+				best[d] = max(best[d], s*weight)
+			}
+		}
+		for d, score := range best {
+			scores[d] += score
+		}
+	}
+
+	found := make([]document, 0, len(scores))
+	for d, score := range scores {
+		hit := *d
+		hit.hit.Score = score / float64(len(terms))
+		found = append(found, hit)
+	}
+
+	return rank(found, limit)
+}
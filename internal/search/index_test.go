@@ -0,0 +1,142 @@
+package search
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+	"testing"
+	"time"
+)
+
+func newTestIndex() *Index {
+	index := NewIndex()
+	index.reset(map[string][]document{
+		teamSource("1"):         teamDocuments(teams.Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Nicknames: []string{"Inter", "Colorado", "Clube do Povo"}}),
+		teamSource("2"):         teamDocuments(teams.Team{Id: "2", Name: "Grêmio", FullName: "Grêmio Foot-Ball Porto Alegrense", Nicknames: []string{"Tricolor"}}),
+		teamSource("4"):         teamDocuments(teams.Team{Id: "4", Name: "São Paulo", FullName: "São Paulo Futebol Clube", Nicknames: []string{"Tricolor"}}),
+		championshipSource("3"): championshipDocuments(championships.Championship{Id: "3", Name: "Copa Libertadores", Season: "2006"}),
+		matchSource("5"): matchDocuments(matches.Match{
+			Id: "5", TeamHomeId: "1", TeamHomeName: "Internacional", TeamAwayId: "4", TeamAwayName: "São Paulo",
+			Incidents: []matches.Incident{
+				{Minute: 29, Type: matches.Goal, TeamId: "1", Player: "Fernandão"},
+				{Minute: 67, Type: matches.Goal, TeamId: "1", Player: "Tinga"},
+				{Minute: 75, Type: matches.YellowCard, TeamId: "1", Player: "Fernandão"},
+			},
+		}),
+	})
+
+	return index
+}
+
+func TestIndex_search(t *testing.T) {
+	tests := []struct {
+		name                  string
+		q                     string
+		expectedTeams         []string
+		expectedChampionships []string
+		expectedPlayers       []string
+	}{
+		{
+			name:          "when the query is a name",
+			q:             "Internacional",
+			expectedTeams: []string{"Internacional"},
+		},
+		{
+			name:          "when the query starts a name",
+			q:             "inter",
+			expectedTeams: []string{"Internacional"},
+		},
+		{
+			name:          "when the query is a nickname",
+			q:             "colorado",
+			expectedTeams: []string{"Internacional"},
+		},
+		{
+			name:          "when the query leaves out the accents",
+			q:             "Gremio",
+			expectedTeams: []string{"Grêmio"},
+		},
+		{
+			name:          "when the query has a typo",
+			q:             "internacinal",
+			expectedTeams: []string{"Internacional"},
+		},
+		{
+			name:                  "when the query has two typos in a long word",
+			q:                     "libertadroes",
+			expectedChampionships: []string{"Copa Libertadores"},
+		},
+		{
+			name:            "when the query names a player twice booked and scoring",
+			q:               "fernandao",
+			expectedPlayers: []string{"Fernandão"},
+		},
+		{
+			name:          "when the query matches several teams, the closest first",
+			q:             "tricolor paulo",
+			expectedTeams: []string{"São Paulo", "Grêmio"},
+		},
+		{
+			name:          "when the query swaps two letters",
+			q:             "itner",
+			expectedTeams: []string{"Internacional"},
+		},
+		{
+			name: "when a short query has a typo",
+			q:    "pvo",
+		},
+		{
+			name: "when nothing matches",
+			q:    "barcelona",
+		},
+	}
+
+	index := newTestIndex()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := index.search(tt.q, 10)
+
+			assert.Equal(t, tt.expectedTeams, names(results.Teams))
+			assert.Equal(t, tt.expectedChampionships, names(results.Championships))
+			assert.Equal(t, tt.expectedPlayers, names(results.Players))
+		})
+	}
+}
+
+func TestIndex_replace(t *testing.T) {
+	index := newTestIndex()
+
+	deletedAt := time.Date(2024, time.May, 5, 0, 0, 0, 0, time.UTC)
+	index.replace(teamSource("2"), teamDocuments(teams.Team{Id: "2", Name: "Grêmio", DeletedAt: &deletedAt}))
+	assert.Empty(t, index.search("gremio", 10).Teams, "deleted teams are not found")
+
+	index.replace(matchSource("5"), nil)
+	assert.Empty(t, index.search("tinga", 10).Players)
+
+	index.replace(teamSource("6"), teamDocuments(teams.Team{Id: "6", Name: "Barcelona", FullName: "Futbol Club Barcelona", Nicknames: []string{"Barça"}}))
+	results := index.search("barca", 10)
+	assert.Equal(t, []Hit{{Id: "6", Name: "Barcelona", Description: "Futbol Club Barcelona", Score: 0.9}}, results.Teams)
+	assert.Equal(t, []Hit{}, results.Championships)
+	assert.Equal(t, []Hit{}, results.Players)
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, similarity("inter", "inter"))
+	assert.Equal(t, 0.8, similarity("inter", "internacional"))
+	assert.Equal(t, 0.0, similarity("in", "internacional"), "terms shorter than minPrefix only match whole words")
+	assert.InDelta(t, 0.6, similarity("colrado", "colorado"), 1e-9)
+	assert.InDelta(t, 0.6, similarity("clorado", "colorado"), 1e-9)
+	assert.InDelta(t, 0.6, similarity("internacioanl", "internacional"), 1e-9, "swapped letters are one typo")
+	assert.InDelta(t, 0.5, similarity("intrenacionl", "internacional"), 1e-9)
+	assert.Equal(t, 0.0, similarity("gremio", "grenal"))
+}
+
+func names(hits []Hit) []string {
+	var names []string
+	for _, hit := range hits {
+		names = append(names, hit.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// minPrefix is the shortest term that matches the words it starts.
+const minPrefix = 3
+
+// fold lowercases s and strips its accents, so that "Grêmio" and "gremio"
+// read the same.
+func fold(s string) string {
+	// Transformers keep state, so each call needs its own.
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+
+	return strings.ToLower(folded)
+}
+
+// tokenize splits s into its folded words.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// similarity scores how well a folded query term matches a folded word: 1
+// when they are equal, less when the term starts the word, less still when
+// it is a typo or two away from it, and 0 otherwise.
+func similarity(term, word string) float64 {
+	if term == word {
+		return 1
+	}
+
+	t, w := []rune(term), []rune(word)
+	if len(t) >= minPrefix && strings.HasPrefix(word, term) {
+		return 0.8
+	}
+
+	edits := maxEdits(len(t))
+	if edits == 0 || abs(len(t)-len(w)) > edits {
+		return 0
+	}
+	if d := distance(t, w); d <= edits {
+		return 0.7 - 0.1*float64(d)
+	}
+
+	return 0
+}
+
+// maxEdits is the number of typos a term of the given length may have: none
+// for short words, where one typo makes another word, more for long ones.
+func maxEdits(length int) int {
+	switch {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance is the number of insertions, deletions, substitutions and swaps
+// of adjacent letters turning a into b.
+func distance(a, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
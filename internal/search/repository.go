@@ -0,0 +1,164 @@
+package search
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+	"sc-internacional/internal/tracing"
+)
+
+type db interface {
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+}
+
+// Repository reads the teams, championships and matches collections, whose
+// text indexes are created by the admin indexes command.
+type Repository struct {
+	teams         db
+	championships db
+	matches       db
+}
+
+func NewRepository(teams, championships, matches db) *Repository {
+	return &Repository{teams: teams, championships: championships, matches: matches}
+}
+
+type scoredTeam struct {
+	teams.Team `bson:",inline"`
+	Score      float64 `bson:"score"`
+}
+
+type scoredChampionship struct {
+	championships.Championship `bson:",inline"`
+	Score                      float64 `bson:"score"`
+}
+
+type scoredMatch struct {
+	matches.Match `bson:",inline"`
+	Score         float64 `bson:"score"`
+}
+
+// textSearch runs q against the text indexes, which match whole words
+// whatever their accents, ranked by Mongo's text score.
+func (r Repository) textSearch(ctx context.Context, q string, limit int) (Results, error) {
+	ctx, span := tracer.Start(ctx, "search.Repository.textSearch")
+	defer span.End()
+
+	filter := bson.M{"$text": bson.M{"$search": q}, "deletedat": nil}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}}).
+		SetLimit(int64(limit))
+
+	var found []document
+
+	foundTeams, err := find[scoredTeam](ctx, r.teams, filter, opts)
+	if err != nil {
+		return Results{}, tracing.Error(span, err)
+	}
+	for _, team := range foundTeams {
+		found = append(found, scored(teamDocuments(team.Team), team.Score)...)
+	}
+
+	foundChampionships, err := find[scoredChampionship](ctx, r.championships, filter, opts)
+	if err != nil {
+		return Results{}, tracing.Error(span, err)
+	}
+	for _, championship := range foundChampionships {
+		found = append(found, scored(championshipDocuments(championship.Championship), championship.Score)...)
+	}
+
+	// A match is found by any of its players, so keep only those named by
+	// a word of the query.
+	foundMatches, err := find[scoredMatch](ctx, r.matches, filter, opts)
+	if err != nil {
+		return Results{}, tracing.Error(span, err)
+	}
+	terms := tokenize(q)
+	for _, match := range foundMatches {
+		for _, d := range scored(matchDocuments(match.Match), match.Score) {
+			if namedBy(d.hit.Name, terms) {
+				found = append(found, d)
+			}
+		}
+	}
+
+	return rank(found, limit), nil
+}
+
+// load reads every searchable document, by source.
+func (r Repository) load(ctx context.Context) (map[string][]document, error) {
+	ctx, span := tracer.Start(ctx, "search.Repository.load")
+	defer span.End()
+
+	filter := bson.M{"deletedat": nil}
+	sources := map[string][]document{}
+
+	allTeams, err := find[teams.Team](ctx, r.teams, filter)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	for _, team := range allTeams {
+		sources[teamSource(team.Id)] = teamDocuments(team)
+	}
+
+	allChampionships, err := find[championships.Championship](ctx, r.championships, filter)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	for _, championship := range allChampionships {
+		sources[championshipSource(championship.Id)] = championshipDocuments(championship)
+	}
+
+	// Only matches with incidents name players.
+	allMatches, err := find[matches.Match](ctx, r.matches, bson.M{"deletedat": nil, "incidents.player": bson.M{"$exists": true}})
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	for _, match := range allMatches {
+		sources[matchSource(match.Id)] = matchDocuments(match)
+	}
+
+	return sources, nil
+}
+
+func find[T any](ctx context.Context, db db, filter bson.M, opts ...*options.FindOptions) ([]T, error) {
+	cursor, err := db.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []T
+	if err = cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func scored(documents []document, score float64) []document {
+	for i := range documents {
+		documents[i].hit.Score = score
+	}
+
+	return documents
+}
+
+// namedBy reports whether any word of name is one of terms.
+func namedBy(name string, terms []string) bool {
+	for _, word := range tokenize(name) {
+		for _, term := range terms {
+			if word == term {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"testing"
+)
+
+func cursor(documents ...interface{}) *mongo.Cursor {
+	c, _ := mongo.NewCursorFromDocuments(documents, nil, nil)
+
+	return c
+}
+
+func TestRepository_textSearch(t *testing.T) {
+	filter := bson.M{"$text": bson.M{"$search": "fernandao inter"}, "deletedat": nil}
+	tests := []struct {
+		name    string
+		setup   func(teams, championships, matches *dbMock)
+		want    Results
+		wantErr error
+	}{
+		{
+			name: "when failed to find teams",
+			setup: func(teams, championships, matches *dbMock) {
+				teams.On("Find", mock.Anything, filter, mock.Anything).Return((*mongo.Cursor)(nil), errors.New("failed to find"))
+			},
+			want:    Results{},
+			wantErr: errors.New("failed to find"),
+		},
+		{
+			name: "when successfully search",
+			setup: func(teams, championships, matches *dbMock) {
+				teams.On("Find", mock.Anything, filter, mock.Anything).Return(cursor(
+					bson.M{"_id": "1", "name": "Internacional", "fullname": "Sport Club Internacional", "nicknames": bson.A{"Inter"}, "score": 1.5},
+				), nil)
+				championships.On("Find", mock.Anything, filter, mock.Anything).Return(cursor(), nil)
+				matches.On("Find", mock.Anything, filter, mock.Anything).Return(cursor(
+					bson.M{"_id": "5", "teamhomeid": "1", "teamhomename": "Internacional", "teamawayid": "4", "teamawayname": "São Paulo", "score": 0.75, "incidents": bson.A{
+						bson.M{"minute": 29, "type": "goal", "teamid": "1", "player": "Fernandão"},
+						bson.M{"minute": 52, "type": "goal", "teamid": "1", "player": "Fabão"},
+					}},
+				), nil)
+			},
+			want: Results{
+				Teams:         []Hit{{Id: "1", Name: "Internacional", Description: "Sport Club Internacional", Score: 1.5}},
+				Championships: []Hit{},
+				Players:       []Hit{{Name: "Fernandão", Description: "Internacional", TeamId: "1", Score: 0.75}},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams, championships, matches := &dbMock{}, &dbMock{}, &dbMock{}
+			tt.setup(teams, championships, matches)
+
+			r := NewRepository(teams, championships, matches)
+
+			got, err := r.textSearch(context.Background(), "fernandao inter", 10)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRepository_load(t *testing.T) {
+	teams, championships, matches := &dbMock{}, &dbMock{}, &dbMock{}
+	teams.On("Find", mock.Anything, bson.M{"deletedat": nil}, []*options.FindOptions(nil)).Return(cursor(
+		bson.M{"_id": "1", "name": "Internacional"},
+	), nil)
+	championships.On("Find", mock.Anything, bson.M{"deletedat": nil}, []*options.FindOptions(nil)).Return(cursor(
+		bson.M{"_id": "3", "name": "Copa Libertadores", "season": "2006"},
+	), nil)
+	matches.On("Find", mock.Anything, bson.M{"deletedat": nil, "incidents.player": bson.M{"$exists": true}}, []*options.FindOptions(nil)).Return(cursor(), nil)
+
+	r := NewRepository(teams, championships, matches)
+
+	got, err := r.load(context.Background())
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{teamSource("1"), championshipSource("3")}, keys(got))
+}
+
+func keys(sources map[string][]document) []string {
+	var keys []string
+	for source := range sources {
+		keys = append(keys, source)
+	}
+
+	return keys
+}
+
+type dbMock struct {
+	db
+	mock.Mock
+}
+
+func (m *dbMock) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error) {
+	args := m.Called(ctx, filter, opts)
+
+	return args.Get(0).(*mongo.Cursor), args.Error(1)
+}
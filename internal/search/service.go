@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"log/slog"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/teams"
+	"sc-internacional/internal/tracing"
+	"slices"
+	"time"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/search")
+
+type repository interface {
+	textSearch(ctx context.Context, q string, limit int) (Results, error)
+	load(ctx context.Context) (map[string][]document, error)
+}
+
+type Service struct {
+	repository repository
+	index      *Index
+	config     *Config
+}
+
+func NewService(repository repository, index *Index, config *Config) *Service {
+	return &Service{repository: repository, index: index, config: config}
+}
+
+// search asks the text indexes first, when they are enabled, and the
+// in-process index when they find nothing, which is what finds words by
+// their start or despite typos.
+func (s Service) search(ctx context.Context, q string, limit int) (Results, error) {
+	ctx, span := tracer.Start(ctx, "search.Service.search")
+	defer span.End()
+
+	if s.config.TextIndex {
+		results, err := s.repository.textSearch(ctx, q, limit)
+		if err != nil {
+			return Results{}, tracing.Error(span, err)
+		}
+		if !results.isEmpty() {
+			return results, nil
+		}
+	}
+
+	return s.index.search(q, limit), nil
+}
+
+// Refresh reloads the in-process index from the database.
+func (s Service) Refresh(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "search.Service.Refresh")
+	defer span.End()
+
+	sources, err := s.repository.load(ctx)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	s.index.reset(sources)
+
+	return nil
+}
+
+// Run refreshes the in-process index now and then every refresh interval,
+// which picks up the writes other instances made, until ctx is done.
+func (s Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to refresh the search index", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandleEvent keeps the in-process index up to date with the writes of this
+// instance.
+func (s Service) HandleEvent(ctx context.Context, event events.Event) error {
+	ctx, span := tracer.Start(ctx, "search.Service.HandleEvent")
+	defer span.End()
+
+	var source string
+	var documents []document
+	var err error
+	switch {
+	case slices.Contains(events.TeamTypes, event.Type):
+		var team teams.Team
+		err = event.Decode(&team, nil)
+		source, documents = teamSource(event.EntityId), teamDocuments(team)
+	case slices.Contains(events.ChampionshipTypes, event.Type):
+		var championship championships.Championship
+		err = event.Decode(&championship, nil)
+		source, documents = championshipSource(event.EntityId), championshipDocuments(championship)
+	case slices.Contains(events.MatchTypes, event.Type):
+		var match matches.Match
+		err = event.Decode(&match, nil)
+		source, documents = matchSource(event.EntityId), matchDocuments(match)
+	default:
+		return nil
+	}
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	// Purges carry no current entity, which leaves nothing to index.
+	if event.Data == nil {
+		documents = nil
+	}
+	s.index.replace(source, documents)
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/championships"
+	"sc-internacional/internal/events"
+	"testing"
+)
+
+func TestService_search(t *testing.T) {
+	found := Results{Teams: []Hit{{Id: "1", Name: "Internacional", Description: "Sport Club Internacional", Score: 1.1}}, Championships: []Hit{}, Players: []Hit{}}
+	tests := []struct {
+		name      string
+		config    *Config
+		setup     func(*repositoryMock)
+		q         string
+		wantTeams []string
+		wantErr   error
+	}{
+		{
+			name:   "when the text indexes find something",
+			config: &Config{TextIndex: true},
+			setup: func(r *repositoryMock) {
+				r.On("textSearch", mock.Anything, "internacional", 10).Return(found, nil)
+			},
+			q:         "internacional",
+			wantTeams: []string{"Internacional"},
+		},
+		{
+			name:   "when the text indexes find nothing",
+			config: &Config{TextIndex: true},
+			setup: func(r *repositoryMock) {
+				r.On("textSearch", mock.Anything, "colo", 10).Return(rank(nil, 10), nil)
+			},
+			q:         "colo",
+			wantTeams: []string{"Internacional"},
+		},
+		{
+			name:   "when the text indexes fail",
+			config: &Config{TextIndex: true},
+			setup: func(r *repositoryMock) {
+				r.On("textSearch", mock.Anything, "colo", 10).Return(Results{}, errors.New("failed to find"))
+			},
+			q:       "colo",
+			wantErr: errors.New("failed to find"),
+		},
+		{
+			name:      "when the text indexes are turned off",
+			config:    &Config{},
+			setup:     func(r *repositoryMock) {},
+			q:         "colorado",
+			wantTeams: []string{"Internacional"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+
+			s := NewService(r, newTestIndex(), tt.config)
+
+			got, err := s.search(context.Background(), tt.q, 10)
+
+			assert.Equal(t, tt.wantTeams, names(got.Teams))
+			assert.Equal(t, tt.wantErr, err)
+			r.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	r := &repositoryMock{}
+	r.On("load", mock.Anything).Return(map[string][]document{
+		championshipSource("7"): championshipDocuments(championships.Championship{Id: "7", Name: "Recopa Sudamericana", Season: "2007"}),
+	}, nil)
+
+	s := NewService(r, newTestIndex(), &Config{})
+
+	assert.NoError(t, s.Refresh(context.Background()))
+	assert.Empty(t, s.index.search("internacional", 10).Teams, "the previous content is dropped")
+	assert.Equal(t, []string{"Recopa Sudamericana"}, names(s.index.search("recopa", 10).Championships))
+}
+
+func TestService_Refresh_repositoryFailure(t *testing.T) {
+	r := &repositoryMock{}
+	r.On("load", mock.Anything).Return(map[string][]document(nil), errors.New("failed to find"))
+
+	s := NewService(r, newTestIndex(), &Config{})
+
+	assert.Equal(t, errors.New("failed to find"), s.Refresh(context.Background()))
+	assert.Equal(t, []string{"Internacional"}, names(s.index.search("internacional", 10).Teams), "the index is kept")
+}
+
+func TestService_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     events.Event
+		q         string
+		wantTeams []string
+		wantErr   bool
+	}{
+		{
+			name:      "when a team is created",
+			event:     events.Event{Type: events.TeamCreated, EntityId: "6", Data: json.RawMessage(`{"id":"6","name":"Barcelona","nicknames":["Barça"]}`)},
+			q:         "barca",
+			wantTeams: []string{"Barcelona"},
+		},
+		{
+			name:      "when a team is renamed",
+			event:     events.Event{Type: events.TeamUpdated, EntityId: "1", Data: json.RawMessage(`{"id":"1","name":"Inter"}`)},
+			q:         "colorado",
+			wantTeams: nil,
+		},
+		{
+			name:      "when a team is purged",
+			event:     events.Event{Type: events.TeamPurged, EntityId: "2"},
+			q:         "gremio",
+			wantTeams: nil,
+		},
+		{
+			name:      "when the event is about nothing searchable",
+			event:     events.Event{Type: "stadium.created", EntityId: "1", Data: json.RawMessage(`{"id":"1","name":"Beira-Rio"}`)},
+			q:         "colorado",
+			wantTeams: []string{"Internacional"},
+		},
+		{
+			name:      "when the event cannot be decoded",
+			event:     events.Event{Type: events.TeamUpdated, EntityId: "1", Data: json.RawMessage(`{"id":1}`)},
+			q:         "colorado",
+			wantTeams: []string{"Internacional"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(&repositoryMock{}, newTestIndex(), &Config{})
+
+			err := s.HandleEvent(context.Background(), tt.event)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantTeams, names(s.index.search(tt.q, 10).Teams))
+		})
+	}
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) textSearch(ctx context.Context, q string, limit int) (Results, error) {
+	args := m.Called(ctx, q, limit)
+
+	return args.Get(0).(Results), args.Error(1)
+}
+
+func (m *repositoryMock) load(ctx context.Context) (map[string][]document, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).(map[string][]document), args.Error(1)
+}
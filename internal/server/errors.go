@@ -0,0 +1,18 @@
+package server
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+)
+
+// ErrorStatus is the status answering a request that failed with err: 400
+// Bad Request for an id that is not an ObjectID, which only the client can
+// fix, and 500 Internal Server Error for anything else.
+func ErrorStatus(err error) int {
+	if errors.Is(err, primitive.ErrInvalidHex) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"testing"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "when the id is malformed", err: fmt.Errorf("finding: %w", primitive.ErrInvalidHex), want: http.StatusBadRequest},
+		{name: "when anything else failed", err: errors.New("failed to find"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorStatus(tt.err))
+		})
+	}
+}
+
+func TestBindErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "when the body is too large", err: fmt.Errorf("reading: %w", &http.MaxBytesError{Limit: 16}), want: http.StatusRequestEntityTooLarge},
+		{name: "when the body is malformed", err: errors.New("unexpected EOF"), want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BindErrorStatus(tt.err))
+		})
+	}
+}
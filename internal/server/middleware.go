@@ -0,0 +1,31 @@
+package server
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// MaxBodyBytes caps how much of a request body handlers can read. Reading
+// past the limit fails with *http.MaxBytesError.
+func MaxBodyBytes(limit int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Body != nil {
+			ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+		}
+
+		ctx.Next()
+	}
+}
+
+// BindErrorStatus is the status answering a body that failed to bind: 413
+// Request Entity Too Large past the MaxBodyBytes limit, 400 Bad Request
+// otherwise.
+func BindErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Server struct {
+	config     Config
+	http       *http.Server
+	onShutdown []func()
+}
+
+func New(config *Config, handler http.Handler) *Server {
+	return &Server{
+		config: *config,
+		http: &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.Port),
+			Handler:           handler,
+			ReadTimeout:       config.ReadTimeout,
+			ReadHeaderTimeout: config.ReadHeaderTimeout,
+			WriteTimeout:      config.WriteTimeout,
+			IdleTimeout:       config.IdleTimeout,
+			MaxHeaderBytes:    config.MaxHeaderBytes,
+		},
+	}
+}
+
+// OnShutdown registers fn to be called as soon as shutdown starts, before the
+// server stops accepting connections.
+func (s *Server) OnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// Run serves HTTP, or HTTPS when both TLS files are configured, until ctx is
+// done. It then runs the OnShutdown functions, keeps serving for ShutdownDelay
+// so load balancers notice the instance is going away, stops accepting
+// connections and waits up to ShutdownTimeout for in-flight requests to finish.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return err
+	}
+
+	return s.serve(ctx, listener)
+}
+
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	errs := make(chan error, 1)
+	go func() {
+		if s.config.tls() {
+			errs <- s.http.ServeTLS(listener, s.config.TLSCertFile, s.config.TLSKeyFile)
+		} else {
+			errs <- s.http.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	for _, fn := range s.onShutdown {
+		fn()
+	}
+	time.Sleep(s.config.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package standings
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sc-internacional/internal/export"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+)
+
+type service interface {
+	streamStandings(ctx context.Context, championshipId string, fn func(Standing) error) error
+}
+
+type Controller struct {
+	service service
+}
+
+func NewController(service service) *Controller {
+	return &Controller{service: service}
+}
+
+func (c Controller) ExportStandings(ctx *gin.Context) {
+	format, err := export.NegotiateFormat(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusNotAcceptable, errorResponse(ctx, err))
+		return
+	}
+
+	encoder := export.NewEncoder(ctx.Writer, format, "standings", csvHeader)
+	err = c.service.streamStandings(ctx.Request.Context(), ctx.Query("championshipId"), func(standing Standing) error {
+		return encoder.Encode(standing, standing.csvRecord())
+	})
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+			return
+		}
+		ctx.Error(err)
+		return
+	}
+
+	// The status is sent already, so a failure to finish the document can
+	// only be logged.
+	if err = encoder.Close(); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
@@ -0,0 +1,100 @@
+package standings
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_ExportStandings(t *testing.T) {
+	standing := Standing{ChampionshipId: "3", TeamId: "1", TeamName: "Internacional", Played: 2, Won: 2, GoalsFor: 3, GoalsAgainst: 1, GoalDifference: 2, Points: 6}
+	tests := []struct {
+		name                string
+		setup               func(*serviceMock)
+		query               string
+		accept              string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "when format is not acceptable",
+			setup:               func(s *serviceMock) {},
+			accept:              "application/xml",
+			expectedStatusCode:  http.StatusNotAcceptable,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        "{\"error\":\"export format not acceptable, use json, ndjson, csv or excel\"}",
+		},
+		{
+			name: "when failed to stream standings",
+			setup: func(s *serviceMock) {
+				s.On("streamStandings", mock.Anything, "", mock.Anything).Return(errors.New("failed to find"))
+			},
+			accept:              "text/csv",
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        "{\"error\":\"failed to find\"}",
+		},
+		{
+			name: "when successfully export the table of a championship as csv",
+			setup: func(s *serviceMock) {
+				s.On("streamStandings", mock.Anything, "3", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(2).(func(Standing) error)(standing)
+				}).Return(nil)
+			},
+			query:               "?championshipId=3",
+			accept:              "text/csv",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "championshipId,teamId,teamName,played,won,drawn,lost,goalsFor,goalsAgainst,goalDifference,points\n3,1,Internacional,2,2,0,0,3,1,2,6\n",
+		},
+		{
+			name: "when successfully export every table as json",
+			setup: func(s *serviceMock) {
+				s.On("streamStandings", mock.Anything, "", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(2).(func(Standing) error)(standing)
+				}).Return(nil)
+			},
+			accept:              "application/json",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        "[{\"championshipId\":\"3\",\"teamId\":\"1\",\"teamName\":\"Internacional\",\"played\":2,\"won\":2,\"drawn\":0,\"lost\":0,\"goalsFor\":3,\"goalsAgainst\":1,\"goalDifference\":2,\"points\":6}\n]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/export/standings"+tt.query, nil)
+			ctx.Request.Header.Set("Accept", tt.accept)
+
+			c.ExportStandings(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) streamStandings(ctx context.Context, championshipId string, fn func(Standing) error) error {
+	args := m.Called(ctx, championshipId, fn)
+
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package standings
+
+import "strconv"
+
+var csvHeader = []string{"championshipId", "teamId", "teamName", "played", "won", "drawn", "lost", "goalsFor", "goalsAgainst", "goalDifference", "points"}
+
+type Standing struct {
+	ChampionshipId string `json:"championshipId"`
+	TeamId         string `json:"teamId"`
+	TeamName       string `json:"teamName"`
+	Played         int    `json:"played"`
+	Won            int    `json:"won"`
+	Drawn          int    `json:"drawn"`
+	Lost           int    `json:"lost"`
+	GoalsFor       int    `json:"goalsFor"`
+	GoalsAgainst   int    `json:"goalsAgainst"`
+	GoalDifference int    `json:"goalDifference"`
+	Points         int    `json:"points"`
+}
+
+func (s *Standing) csvRecord() []string {
+	return []string{
+		s.ChampionshipId,
+		s.TeamId,
+		s.TeamName,
+		strconv.Itoa(s.Played),
+		strconv.Itoa(s.Won),
+		strconv.Itoa(s.Drawn),
+		strconv.Itoa(s.Lost),
+		strconv.Itoa(s.GoalsFor),
+		strconv.Itoa(s.GoalsAgainst),
+		strconv.Itoa(s.GoalDifference),
+		strconv.Itoa(s.Points),
+	}
+}
@@ -0,0 +1,196 @@
+package standings
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+type db interface {
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error)
+	Name() string
+}
+
+type matchesDB interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+type Repository struct {
+	db
+	matches matchesDB
+}
+
+func NewRepository(db db, matches matchesDB) *Repository {
+	return &Repository{db: db, matches: matches}
+}
+
+// tableOrder sorts standings by championship, then from first to last place.
+var tableOrder = bson.D{
+	{Key: "championshipid", Value: 1},
+	{Key: "points", Value: -1},
+	{Key: "goaldifference", Value: -1},
+	{Key: "goalsfor", Value: -1},
+}
+
+func (r Repository) streamStandings(ctx context.Context, championshipId string, fn func(Standing) error) error {
+	ctx, span := tracer.Start(ctx, "standings.Repository.streamStandings")
+	defer span.End()
+
+	filter := bson.M{}
+	if championshipId != "" {
+		filter["championshipid"] = championshipId
+	}
+
+	cursor, err := r.db.Find(ctx, filter, options.Find().SetSort(tableOrder))
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var standing Standing
+		if err = cursor.Decode(&standing); err != nil {
+			return tracing.Error(span, err)
+		}
+
+		if err = fn(standing); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	if err = cursor.Err(); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// getStandings returns the tables of the championships with the given ids.
+func (r Repository) getStandings(ctx context.Context, championshipIds []string) ([]Standing, error) {
+	ctx, span := tracer.Start(ctx, "standings.Repository.getStandings")
+	defer span.End()
+
+	filter := bson.M{"championshipid": bson.M{"$in": championshipIds}}
+	cursor, err := r.db.Find(ctx, filter, options.Find().SetSort(tableOrder))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	standings := []Standing{}
+	if err = cursor.All(ctx, &standings); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return standings, nil
+}
+
+// recompute rebuilds the standings of a championship, or of every
+// championship when championshipId is empty, from the matches collection.
+// Rows are replaced in place, stamped with the time of the run, and only then
+// are the rows of teams the matches no longer count removed, so that readers
+// never see the table empty. Rows stamped by a later run are left alone.
+func (r Repository) recompute(ctx context.Context, championshipId string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "standings.Repository.recompute")
+	defer span.End()
+
+	scope := bson.M{}
+	if championshipId != "" {
+		scope["championshipid"] = championshipId
+	}
+
+	// Deleted matches no longer count towards the table.
+	filter := bson.M{"deletedat": nil}
+	for key, value := range scope {
+		filter[key] = value
+	}
+
+	run := primitive.NewDateTimeFromTime(time.Now())
+	cursor, err := r.matches.Aggregate(ctx, recomputePipeline(filter, r.db.Name(), run))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	if err = cursor.Close(ctx); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	// Both the championships merged and those about to lose every row.
+	values, err := r.db.Distinct(ctx, "championshipid", scope)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	championshipIds := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			championshipIds = append(championshipIds, id)
+		}
+	}
+
+	// Rows of a later run are newer than this one's view of the matches, so
+	// they are left alone, as the merge leaves them.
+	scope["recomputedat"] = bson.M{"$not": bson.M{"$gte": run}}
+	if _, err = r.db.DeleteMany(ctx, scope); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return championshipIds, nil
+}
+
+// newerRun replaces a merged row unless the row comes from a later run.
+var newerRun = mongo.Pipeline{
+	{{Key: "$replaceWith", Value: bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$$new.recomputedat", bson.M{"$ifNull": bson.A{"$recomputedat", primitive.DateTime(0)}}}}, "$$new", "$$ROOT"}}}},
+}
+
+func recomputePipeline(filter bson.M, into string, run primitive.DateTime) mongo.Pipeline {
+	side := func(team, teamName, goalsFor, goalsAgainst string) bson.M {
+		return bson.M{"teamid": "$" + team, "teamname": "$" + teamName, "goalsfor": "$" + goalsFor, "goalsagainst": "$" + goalsAgainst}
+	}
+	count := func(op string) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{op: bson.A{"$sides.goalsfor", "$sides.goalsagainst"}}, 1, 0}}}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.M{
+			"championshipid": 1,
+			"sides": bson.A{
+				side("teamhomeid", "teamhomename", "teamhomescore", "teamawayscore"),
+				side("teamawayid", "teamawayname", "teamawayscore", "teamhomescore"),
+			},
+		}}},
+		{{Key: "$unwind", Value: "$sides"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          bson.M{"championshipid": "$championshipid", "teamid": "$sides.teamid"},
+			"teamname":     bson.M{"$last": "$sides.teamname"},
+			"played":       bson.M{"$sum": 1},
+			"won":          count("$gt"),
+			"drawn":        count("$eq"),
+			"lost":         count("$lt"),
+			"goalsfor":     bson.M{"$sum": "$sides.goalsfor"},
+			"goalsagainst": bson.M{"$sum": "$sides.goalsagainst"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":            bson.M{"$concat": bson.A{"$_id.championshipid", ":", "$_id.teamid"}},
+			"championshipid": "$_id.championshipid",
+			"teamid":         "$_id.teamid",
+			"teamname":       1,
+			"played":         1,
+			"won":            1,
+			"drawn":          1,
+			"lost":           1,
+			"goalsfor":       1,
+			"goalsagainst":   1,
+			"goaldifference": bson.M{"$subtract": bson.A{"$goalsfor", "$goalsagainst"}},
+			"points":         bson.M{"$add": bson.A{bson.M{"$multiply": bson.A{"$won", 3}}, "$drawn"}},
+			"recomputedat":   bson.M{"$literal": run},
+		}}},
+		// A run overlapping a later one keeps the rows the later one merged.
+		{{Key: "$merge", Value: bson.M{"into": into, "on": "_id", "whenMatched": newerRun, "whenNotMatched": "insert"}}},
+	}
+}
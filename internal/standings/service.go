@@ -0,0 +1,143 @@
+package standings
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"sc-internacional/internal/tracing"
+	"strings"
+)
+
+const cacheKeyPrefix = "standings:"
+
+var tracer = otel.Tracer("sc-internacional/internal/standings")
+
+type repository interface {
+	streamStandings(ctx context.Context, championshipId string, fn func(Standing) error) error
+	recompute(ctx context.Context, championshipId string) ([]string, error)
+	getStandings(ctx context.Context, championshipIds []string) ([]Standing, error)
+}
+
+type Service struct {
+	repository repository
+	cache      cache.Cache
+}
+
+func NewService(repository repository, cache cache.Cache) *Service {
+	return &Service{repository: repository, cache: cache}
+}
+
+// CacheKeys are the cached tables a change to a match of championshipId makes
+// stale.
+func CacheKeys(championshipId string) []string {
+	return []string{cacheKeyPrefix + championshipId}
+}
+
+// streamStandings hands fn the table of a championship, or of every
+// championship when championshipId is empty, straight from the cursor.
+func (s Service) streamStandings(ctx context.Context, championshipId string, fn func(Standing) error) error {
+	ctx, span := tracer.Start(ctx, "standings.Service.streamStandings")
+	defer span.End()
+
+	if err := s.repository.streamStandings(ctx, championshipId, fn); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// GetStandings returns the tables of the championships with the given ids,
+// each from first to last place. Tables are small, so they are cached whole,
+// and the ones missing from the cache are read all at once.
+func (s Service) GetStandings(ctx context.Context, championshipIds []string) ([]Standing, error) {
+	ctx, span := tracer.Start(ctx, "standings.Service.GetStandings")
+	defer span.End()
+
+	keys := make([]string, 0, len(championshipIds))
+	for _, championshipId := range championshipIds {
+		keys = append(keys, cacheKeyPrefix+championshipId)
+	}
+
+	tables, err := cache.FetchMany(ctx, s.cache, keys, func(ctx context.Context, missing []string) (map[string][]Standing, error) {
+		ids := make([]string, 0, len(missing))
+		tables := make(map[string][]Standing, len(missing))
+		for _, key := range missing {
+			ids = append(ids, strings.TrimPrefix(key, cacheKeyPrefix))
+			tables[key] = []Standing{}
+		}
+
+		found, err := s.repository.getStandings(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, standing := range found {
+			key := cacheKeyPrefix + standing.ChampionshipId
+			tables[key] = append(tables[key], standing)
+		}
+
+		return tables, nil
+	})
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	// Deleting each table once taken lists it once, should an id repeat.
+	standings := []Standing{}
+	for _, key := range keys {
+		standings = append(standings, tables[key]...)
+		delete(tables, key)
+	}
+
+	return standings, nil
+}
+
+// Recompute rebuilds the stored standings from the recorded matches, and drops
+// the cached table of every championship recomputed. An empty championshipId
+// recomputes every championship.
+func (s Service) Recompute(ctx context.Context, championshipId string) error {
+	ctx, span := tracer.Start(ctx, "standings.Service.Recompute")
+	defer span.End()
+
+	championshipIds, err := s.repository.recompute(ctx, championshipId)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	var keys []string
+	for _, id := range championshipIds {
+		keys = append(keys, CacheKeys(id)...)
+	}
+	cache.Invalidate(ctx, s.cache, keys...)
+
+	return nil
+}
+
+// HandleMatchEvent recomputes the standings of the championships a match
+// counted towards before and after it changed.
+func (s Service) HandleMatchEvent(ctx context.Context, event events.Event) error {
+	ctx, span := tracer.Start(ctx, "standings.Service.HandleMatchEvent")
+	defer span.End()
+
+	var current, previous matches.Match
+	if err := event.Decode(&current, &previous); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	championshipIds := []string{current.ChampionshipId}
+	if previous.ChampionshipId != current.ChampionshipId {
+		championshipIds = append(championshipIds, previous.ChampionshipId)
+	}
+
+	for _, championshipId := range championshipIds {
+		if championshipId == "" {
+			continue
+		}
+		if err := s.Recompute(ctx, championshipId); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package standings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/matches"
+	"testing"
+	"time"
+)
+
+func TestService_Recompute(t *testing.T) {
+	tests := []struct {
+		name           string
+		championshipId string
+		setup          func(*repositoryMock)
+		wantErr        error
+		wantDropped    []string
+		wantKept       []string
+	}{
+		{
+			name:           "when the table is recomputed",
+			championshipId: "3",
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "3").Return([]string{"3"}, nil)
+			},
+			wantDropped: []string{"3"},
+			wantKept:    []string{"4"},
+		},
+		{
+			name: "when every table is recomputed",
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "").Return([]string{"3", "4"}, nil)
+			},
+			wantDropped: []string{"3", "4"},
+		},
+		{
+			name:           "when failed to recompute",
+			championshipId: "3",
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "3").Return([]string(nil), errors.New("failed to aggregate"))
+			},
+			wantErr:  errors.New("failed to aggregate"),
+			wantKept: []string{"3", "4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &repositoryMock{}
+			tt.setup(r)
+			c := cache.NewLRU(10, time.Minute)
+			for _, id := range []string{"3", "4"} {
+				for _, key := range CacheKeys(id) {
+					assert.NoError(t, c.Set(ctx, key, []byte("[]")))
+				}
+			}
+
+			s := NewService(r, c)
+
+			assert.Equal(t, tt.wantErr, s.Recompute(ctx, tt.championshipId))
+			for _, id := range tt.wantDropped {
+				for _, key := range CacheKeys(id) {
+					_, ok, _ := c.Get(ctx, key)
+					assert.False(t, ok, "the cached table of %s is dropped once recomputed", id)
+				}
+			}
+			for _, id := range tt.wantKept {
+				for _, key := range CacheKeys(id) {
+					_, ok, _ := c.Get(ctx, key)
+					assert.True(t, ok, "the cached table of %s is kept", id)
+				}
+			}
+			r.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_HandleMatchEvent(t *testing.T) {
+	match := matches.Match{Id: "10", TeamHomeId: "1", TeamAwayId: "2", TeamHomeScore: 1, ChampionshipId: "3"}
+	moved := match
+	moved.ChampionshipId = "4"
+	scored := match
+	scored.TeamAwayScore = 1
+	tests := []struct {
+		name     string
+		previous *matches.Match
+		current  *matches.Match
+		setup    func(*repositoryMock)
+		wantErr  error
+	}{
+		{
+			name:    "when a match is recorded",
+			current: &match,
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "3").Return([]string{"3"}, nil).Once()
+			},
+		},
+		{
+			name:     "when the score of a match changes",
+			previous: &match,
+			current:  &scored,
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "3").Return([]string{"3"}, nil).Once()
+			},
+		},
+		{
+			name:     "when a match moves to another championship",
+			previous: &match,
+			current:  &moved,
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "4").Return([]string{"4"}, nil).Once()
+				r.On("recompute", mock.Anything, "3").Return([]string{"3"}, nil).Once()
+			},
+		},
+		{
+			name:     "when a match is purged",
+			previous: &match,
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "3").Return([]string{"3"}, nil).Once()
+			},
+		},
+		{
+			name:    "when failed to recompute",
+			current: &match,
+			setup: func(r *repositoryMock) {
+				r.On("recompute", mock.Anything, "3").Return([]string(nil), errors.New("failed to aggregate"))
+			},
+			wantErr: errors.New("failed to aggregate"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+
+			s := NewService(r, cache.Nop{})
+
+			err := s.HandleMatchEvent(context.Background(), matchEvent(t, tt.previous, tt.current))
+
+			assert.Equal(t, tt.wantErr, err)
+			r.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_GetStandings(t *testing.T) {
+	ctx := context.Background()
+	first := Standing{ChampionshipId: "3", TeamId: "1", TeamName: "Internacional", Points: 6}
+	second := Standing{ChampionshipId: "3", TeamId: "2", TeamName: "Barcelona", Points: 0}
+	other := Standing{ChampionshipId: "4", TeamId: "1", TeamName: "Internacional", Points: 3}
+
+	r := &repositoryMock{}
+	r.On("getStandings", mock.Anything, []string{"3", "5"}).Return([]Standing{first, second}, nil).Once()
+	r.On("getStandings", mock.Anything, []string{"4"}).Return([]Standing{other}, nil).Once()
+
+	s := NewService(r, cache.NewLRU(10, time.Minute))
+
+	got, err := s.GetStandings(ctx, []string{"3", "5"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Standing{first, second}, got)
+
+	got, err = s.GetStandings(ctx, []string{"4", "3", "5"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Standing{other, first, second}, got, "cached tables are not read again")
+	r.AssertExpectations(t)
+}
+
+func matchEvent(t *testing.T, previous, current *matches.Match) events.Event {
+	event := events.Event{Id: "e", Type: events.MatchUpdated, EntityId: "10"}
+	var err error
+	if previous != nil {
+		event.Previous, err = json.Marshal(previous)
+		assert.NoError(t, err)
+	}
+	if current != nil {
+		event.Data, err = json.Marshal(current)
+		assert.NoError(t, err)
+	}
+	return event
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) recompute(ctx context.Context, championshipId string) ([]string, error) {
+	args := m.Called(ctx, championshipId)
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *repositoryMock) getStandings(ctx context.Context, championshipIds []string) ([]Standing, error) {
+	args := m.Called(ctx, championshipIds)
+
+	return args.Get(0).([]Standing), args.Error(1)
+}
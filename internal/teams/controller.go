@@ -5,12 +5,23 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/export"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
 )
 
 type service interface {
 	createTeam(ctx context.Context, team Team) (Team, error)
-	getTeam(ctx context.Context, id string) (Team, error)
-	getAllTeams(ctx context.Context) ([]Team, error)
+	getTeam(ctx context.Context, id string, includeDeleted bool) (Team, error)
+	getAllTeams(ctx context.Context, includeDeleted bool) ([]Team, error)
+	updateTeam(ctx context.Context, id string, team Team, version int64) (Team, error)
+	deleteTeam(ctx context.Context, id string) (Team, error)
+	restoreTeam(ctx context.Context, id string) (Team, error)
+	purgeTeam(ctx context.Context, id string) (Team, error)
+	streamTeams(ctx context.Context, fn func(Team) error) error
 }
 
 type Controller struct {
@@ -23,45 +34,194 @@ func NewController(service service) *Controller {
 
 func (c Controller) PostTeam(ctx *gin.Context) {
 	var req Team
-	if err := ctx.BindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
+	// The display name is answered, never taken.
+	req.DisplayName = ""
 
 	team, err := c.service.createTeam(ctx.Request.Context(), req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, team)
+	ctx.Header("ETag", etag.Localized(team.Version, language(ctx)))
+	apiversion.JSON(ctx, http.StatusCreated, team.Localized(language(ctx)))
 }
 
 func (c Controller) GetTeam(ctx *gin.Context) {
-	team, err := c.service.getTeam(ctx.Request.Context(), ctx.Param("id"))
+	includeDeleted, err := auth.AdminFlag(ctx, "includeDeleted")
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	team, err := c.service.getTeam(ctx.Request.Context(), ctx.Param("id"), includeDeleted)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
 		return
 	}
 
 	if team.isEmpty() {
-		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("team not found")))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("team not found")))
+		return
+	}
+
+	tag := etag.Localized(team.Version, language(ctx))
+	ctx.Header("ETag", tag)
+	if etag.Match(ctx.GetHeader("If-None-Match"), tag) {
+		ctx.Status(http.StatusNotModified)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, team)
+	apiversion.JSON(ctx, http.StatusOK, team.Localized(language(ctx)))
 }
 
 func (c Controller) GetAllTeams(ctx *gin.Context) {
-	teams, err := c.service.getAllTeams(ctx.Request.Context())
+	includeDeleted, err := auth.AdminFlag(ctx, "includeDeleted")
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	teams, err := c.service.getAllTeams(ctx.Request.Context(), includeDeleted)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	lang := language(ctx)
+	for i := range teams {
+		teams[i] = teams[i].Localized(lang)
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, teams)
+}
+
+// PutTeam replaces a team. The If-Match header must carry the ETag the
+// caller last read, so that concurrent edits are refused instead of lost.
+func (c Controller) PutTeam(ctx *gin.Context) {
+	version, err := etag.Version(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(etag.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	var req Team
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+	req.DisplayName = ""
+
+	team, err := c.service.updateTeam(ctx.Request.Context(), ctx.Param("id"), req, version)
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if team.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("team not found")))
+		return
+	}
+
+	ctx.Header("ETag", etag.Localized(team.Version, language(ctx)))
+	apiversion.JSON(ctx, http.StatusOK, team.Localized(language(ctx)))
+}
+
+// DeleteTeam soft deletes the team, or removes it for good when an admin
+// passes ?hard=true and nothing references it anymore.
+func (c Controller) DeleteTeam(ctx *gin.Context) {
+	hard, err := auth.AdminFlag(ctx, "hard")
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, teams)
+	remove := c.service.deleteTeam
+	if hard {
+		remove = c.service.purgeTeam
+	}
+
+	team, err := remove(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, errReferenced) {
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		return
+	}
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if team.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("team not found")))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c Controller) RestoreTeam(ctx *gin.Context) {
+	team, err := c.service.restoreTeam(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, etag.ErrMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, errorResponse(ctx, err))
+		return
+	}
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if team.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("team not found")))
+		return
+	}
+
+	ctx.Header("ETag", etag.Localized(team.Version, language(ctx)))
+	apiversion.JSON(ctx, http.StatusOK, team.Localized(language(ctx)))
+}
+
+func (c Controller) ExportTeams(ctx *gin.Context) {
+	format, err := export.NegotiateFormat(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusNotAcceptable, errorResponse(ctx, err))
+		return
+	}
+
+	encoder := export.NewEncoder(ctx.Writer, format, "teams", csvHeader)
+	err = c.service.streamTeams(ctx.Request.Context(), func(team Team) error {
+		return encoder.Encode(team, team.csvRecord())
+	})
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+			return
+		}
+		ctx.Error(err)
+		return
+	}
+
+	// The status is sent already, so a failure to finish the document can
+	// only be logged.
+	if err = encoder.Close(); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
 }
 
-func errorResponse(err error) gin.H {
-	return gin.H{"error": err.Error()}
+func language(ctx *gin.Context) string {
+	return i18n.FromContext(ctx.Request.Context())
 }
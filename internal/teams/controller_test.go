@@ -7,9 +7,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/i18n"
+	"strings"
 	"testing"
 	"time"
 )
@@ -27,7 +33,14 @@ func TestController_PostTeam(t *testing.T) {
 			setup:                func(s *serviceMock) {},
 			requestBody:          "abcd",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: "{\"error\":\"invalid character 'a' looking for beginning of value\"}",
+			expectedResponseBody: "{\"error\":\"request body is not valid JSON\"}",
+		},
+		{
+			name:                 "when request is too large",
+			setup:                func(s *serviceMock) {},
+			requestBody:          "{\"fullName\": \"" + strings.Repeat("Sport Club Internacional", 100) + "\"}",
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedResponseBody: "{\"error\":\"request body is too large\"}",
 		},
 		{
 			name: "when failed to create a team",
@@ -48,7 +61,7 @@ func TestController_PostTeam(t *testing.T) {
 			},
 			requestBody:          "{\"fullName\": \"Sport Club Internacional\", \"name\": \"Internacional\", \"foundationDate\": \"1909-04-04T00:00:00.000Z\", \"website\": \"internacional.com.br\"}",
 			expectedStatusCode:   http.StatusCreated,
-			expectedResponseBody: "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\"}",
+			expectedResponseBody: "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\",\"displayName\":\"Internacional\"}",
 		},
 	}
 	for _, tt := range tests {
@@ -62,7 +75,7 @@ func TestController_PostTeam(t *testing.T) {
 			ctx, _ := gin.CreateTestContext(recorder)
 			ctx.Request = &http.Request{
 				Header: make(http.Header),
-				Body:   io.NopCloser(bytes.NewBuffer([]byte(tt.requestBody))),
+				Body:   http.MaxBytesReader(recorder, io.NopCloser(bytes.NewBuffer([]byte(tt.requestBody))), 1024),
 			}
 
 			c.PostTeam(ctx)
@@ -78,22 +91,35 @@ func TestController_GetTeam(t *testing.T) {
 		name               string
 		setup              func(*serviceMock)
 		id                 string
+		query              string
+		principal          auth.Principal
+		language           string
+		ifNoneMatch        string
 		expectedStatusCode int
 		expectedBody       string
 	}{
 		{
 			name: "when failed to get team",
 			setup: func(s *serviceMock) {
-				s.On("getTeam", mock.Anything, "1").Return(Team{}, errors.New("failed to get team"))
+				s.On("getTeam", mock.Anything, "1", false).Return(Team{}, errors.New("failed to get team"))
 			},
 			id:                 "1",
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedBody:       "{\"error\":\"failed to get team\"}",
 		},
+		{
+			name: "when id is malformed",
+			setup: func(s *serviceMock) {
+				s.On("getTeam", mock.Anything, "x", false).Return(Team{}, primitive.ErrInvalidHex)
+			},
+			id:                 "x",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"id must be an ObjectID of 24 hexadecimal characters\"}",
+		},
 		{
 			name: "when team is not found",
 			setup: func(s *serviceMock) {
-				s.On("getTeam", mock.Anything, "1").Return(Team{}, nil)
+				s.On("getTeam", mock.Anything, "1", false).Return(Team{}, nil)
 			},
 			id:                 "1",
 			expectedStatusCode: http.StatusNotFound,
@@ -103,11 +129,77 @@ func TestController_GetTeam(t *testing.T) {
 			name: "when successfully get team",
 			setup: func(s *serviceMock) {
 				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
-				s.On("getTeam", mock.Anything, "1").Return(team, nil)
+				s.On("getTeam", mock.Anything, "1", false).Return(team, nil)
 			},
 			id:                 "1",
 			expectedStatusCode: http.StatusOK,
-			expectedBody:       "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\"}",
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\",\"displayName\":\"Internacional\"}",
+		},
+		{
+			name: "when caller already has the current version",
+			setup: func(s *serviceMock) {
+				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3}
+				s.On("getTeam", mock.Anything, "1", false).Return(team, nil)
+			},
+			id:                 "1",
+			ifNoneMatch:        "W/\"3-en\"",
+			expectedStatusCode: http.StatusNotModified,
+			expectedBody:       "",
+		},
+		{
+			name: "when caller has the current version in another language",
+			setup: func(s *serviceMock) {
+				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3}
+				s.On("getTeam", mock.Anything, "1", false).Return(team, nil)
+			},
+			id:                 "1",
+			language:           i18n.Spanish,
+			ifNoneMatch:        "\"3-en\"",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\",\"displayName\":\"Internacional\",\"version\":3}",
+		},
+		{
+			name:               "when a non-admin asks for deleted teams",
+			setup:              func(s *serviceMock) {},
+			id:                 "1",
+			query:              "?includeDeleted=true",
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "{\"error\":\"insufficient role\"}",
+		},
+		{
+			name: "when an admin gets a deleted team",
+			setup: func(s *serviceMock) {
+				deletedAt := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), DeletedAt: &deletedAt}
+				s.On("getTeam", mock.Anything, "1", true).Return(team, nil)
+			},
+			id:                 "1",
+			query:              "?includeDeleted=true",
+			principal:          auth.Principal{Subject: "root", Role: auth.Admin},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\",\"displayName\":\"Internacional\",\"deletedAt\":\"2024-10-12T18:30:00Z\"}",
+		},
+		{
+			name: "when the team has a name in the language of the request",
+			setup: func(s *serviceMock) {
+				team := Team{Id: "5", Name: "Barcelona", FullName: "Futbol Club Barcelona", Website: "fcbarcelona.com", FoundationDate: time.Date(1899, time.November, 29, 0, 0, 0, 0, time.UTC), Names: map[string]string{"es": "FC Barcelona"}}
+				s.On("getTeam", mock.Anything, "5", false).Return(team, nil)
+			},
+			id:                 "5",
+			language:           i18n.Spanish,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"id\":\"5\",\"name\":\"Barcelona\",\"fullName\":\"Futbol Club Barcelona\",\"website\":\"fcbarcelona.com\",\"foundationDate\":\"1899-11-29T00:00:00Z\",\"names\":{\"es\":\"FC Barcelona\"},\"displayName\":\"FC Barcelona\"}",
+		},
+		{
+			name: "when team not found in the language of the request",
+			setup: func(s *serviceMock) {
+				s.On("getTeam", mock.Anything, "1", false).Return(Team{}, nil)
+			},
+			id:                 "1",
+			language:           i18n.PortugueseBR,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"time não encontrado\"}",
 		},
 	}
 	for _, tt := range tests {
@@ -120,9 +212,16 @@ func TestController_GetTeam(t *testing.T) {
 			recorder := httptest.NewRecorder()
 			ctx, _ := gin.CreateTestContext(recorder)
 			ctx.AddParam("id", tt.id)
-			ctx.Request = &http.Request{Header: make(http.Header)}
+			request := httptest.NewRequest(http.MethodGet, "/teams/"+tt.id+tt.query, nil)
+			request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			requestCtx := auth.WithPrincipal(request.Context(), tt.principal)
+			if tt.language != "" {
+				requestCtx = i18n.WithLanguage(requestCtx, tt.language)
+			}
+			ctx.Request = request.WithContext(requestCtx)
 
 			c.GetTeam(ctx)
+			ctx.Writer.WriteHeaderNow()
 
 			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
 			assert.Equal(t, tt.expectedBody, recorder.Body.String())
@@ -140,7 +239,7 @@ func TestController_GetAllTeams(t *testing.T) {
 		{
 			name: "when failed to get all teams",
 			setup: func(s *serviceMock) {
-				s.On("getAllTeams", mock.Anything).Return([]Team{}, errors.New("failed to get teams"))
+				s.On("getAllTeams", mock.Anything, false).Return([]Team{}, errors.New("failed to get teams"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedBody:       "{\"error\":\"failed to get teams\"}",
@@ -148,7 +247,7 @@ func TestController_GetAllTeams(t *testing.T) {
 		{
 			name: "when successfully got all teams",
 			setup: func(s *serviceMock) {
-				s.On("getAllTeams", mock.Anything).Return([]Team{}, nil)
+				s.On("getAllTeams", mock.Anything, false).Return([]Team{}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedBody:       "[]",
@@ -163,7 +262,7 @@ func TestController_GetAllTeams(t *testing.T) {
 
 			recorder := httptest.NewRecorder()
 			ctx, _ := gin.CreateTestContext(recorder)
-			ctx.Request = &http.Request{Header: make(http.Header)}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/teams", nil)
 
 			c.GetAllTeams(ctx)
 
@@ -173,6 +272,323 @@ func TestController_GetAllTeams(t *testing.T) {
 	}
 }
 
+func TestController_ExportTeams(t *testing.T) {
+	tests := []struct {
+		name                string
+		setup               func(*serviceMock)
+		accept              string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "when format is not acceptable",
+			setup:               func(s *serviceMock) {},
+			accept:              "application/xml",
+			expectedStatusCode:  http.StatusNotAcceptable,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        "{\"error\":\"export format not acceptable, use json, ndjson, csv or excel\"}",
+		},
+		{
+			name: "when failed to stream teams",
+			setup: func(s *serviceMock) {
+				s.On("streamTeams", mock.Anything, mock.Anything).Return(errors.New("failed to find"))
+			},
+			accept:              "text/csv",
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        "{\"error\":\"failed to find\"}",
+		},
+		{
+			name: "when successfully export teams as csv",
+			setup: func(s *serviceMock) {
+				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+				s.On("streamTeams", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(func(Team) error)(team)
+				}).Return(nil)
+			},
+			accept:              "text/csv",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "id,name,fullName,website,foundationDate\n1,Internacional,Sport Club Internacional,internacional.com.br,1909-04-04T00:00:00Z\n",
+		},
+		{
+			name: "when successfully export teams as ndjson",
+			setup: func(s *serviceMock) {
+				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+				s.On("streamTeams", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(func(Team) error)(team)
+				}).Return(nil)
+			},
+			accept:              "application/x-ndjson",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody:        "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = &http.Request{Header: make(http.Header), URL: &url.URL{}}
+			ctx.Request.Header.Set("Accept", tt.accept)
+
+			c.ExportTeams(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_PutTeam(t *testing.T) {
+	receivedTeam := Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+	requestBody := "{\"fullName\": \"Sport Club Internacional\", \"name\": \"Inter\", \"foundationDate\": \"1909-04-04T00:00:00.000Z\", \"website\": \"internacional.com.br\"}"
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		ifMatch            string
+		requestBody        string
+		expectedStatusCode int
+		expectedETag       string
+		expectedBody       string
+	}{
+		{
+			name:               "when If-Match is missing",
+			setup:              func(s *serviceMock) {},
+			requestBody:        requestBody,
+			expectedStatusCode: http.StatusPreconditionRequired,
+			expectedBody:       "{\"error\":\"If-Match header is required\"}",
+		},
+		{
+			name:               "when If-Match is malformed",
+			setup:              func(s *serviceMock) {},
+			ifMatch:            "2",
+			requestBody:        requestBody,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"If-Match header must be a single entity tag\"}",
+		},
+		{
+			name:               "when request is invalid",
+			setup:              func(s *serviceMock) {},
+			ifMatch:            "\"2\"",
+			requestBody:        "abcd",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"request body is not valid JSON\"}",
+		},
+		{
+			name: "when failed to update team",
+			setup: func(s *serviceMock) {
+				s.On("updateTeam", mock.Anything, "1", receivedTeam, int64(2)).Return(Team{}, errors.New("failed to update team"))
+			},
+			ifMatch:            "\"2\"",
+			requestBody:        requestBody,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to update team\"}",
+		},
+		{
+			name: "when team changed since it was read",
+			setup: func(s *serviceMock) {
+				s.On("updateTeam", mock.Anything, "1", receivedTeam, int64(2)).Return(Team{}, etag.ErrMismatch)
+			},
+			ifMatch:            "\"2\"",
+			requestBody:        requestBody,
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedBody:       "{\"error\":\"the resource was modified since it was read\"}",
+		},
+		{
+			name: "when team is not found",
+			setup: func(s *serviceMock) {
+				s.On("updateTeam", mock.Anything, "1", receivedTeam, etag.Any).Return(Team{}, nil)
+			},
+			ifMatch:            "*",
+			requestBody:        requestBody,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"team not found\"}",
+		},
+		{
+			name: "when successfully updates a team",
+			setup: func(s *serviceMock) {
+				returnTeam := Team{Id: "1", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3}
+				s.On("updateTeam", mock.Anything, "1", receivedTeam, int64(2)).Return(returnTeam, nil)
+			},
+			ifMatch:            "\"2\"",
+			requestBody:        requestBody,
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       "\"3-en\"",
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Inter\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\",\"displayName\":\"Inter\",\"version\":3}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "1")
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/teams/1", strings.NewReader(tt.requestBody))
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			c.PutTeam(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedETag, recorder.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_DeleteTeam(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		principal          auth.Principal
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when failed to delete team",
+			setup: func(s *serviceMock) {
+				s.On("deleteTeam", mock.Anything, "1").Return(Team{}, errors.New("failed to delete team"))
+			},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to delete team\"}",
+		},
+		{
+			name: "when team is not found",
+			setup: func(s *serviceMock) {
+				s.On("deleteTeam", mock.Anything, "1").Return(Team{}, nil)
+			},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"team not found\"}",
+		},
+		{
+			name: "when successfully deletes a team",
+			setup: func(s *serviceMock) {
+				s.On("deleteTeam", mock.Anything, "1").Return(Team{Id: "1", Name: "Internacional"}, nil)
+			},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       "",
+		},
+		{
+			name:               "when an editor asks for a hard delete",
+			setup:              func(s *serviceMock) {},
+			principal:          auth.Principal{Subject: "ci", Role: auth.Editor},
+			query:              "?hard=true",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "{\"error\":\"insufficient role\"}",
+		},
+		{
+			name: "when the team is still referenced",
+			setup: func(s *serviceMock) {
+				s.On("purgeTeam", mock.Anything, "1").Return(Team{}, errReferenced)
+			},
+			principal:          auth.Principal{Subject: "root", Role: auth.Admin},
+			query:              "?hard=true",
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       "{\"error\":\"team is still referenced by matches or championships\"}",
+		},
+		{
+			name: "when successfully purges a team",
+			setup: func(s *serviceMock) {
+				s.On("purgeTeam", mock.Anything, "1").Return(Team{Id: "1", Name: "Internacional"}, nil)
+			},
+			principal:          auth.Principal{Subject: "root", Role: auth.Admin},
+			query:              "?hard=true",
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "1")
+			request := httptest.NewRequest(http.MethodDelete, "/teams/1"+tt.query, nil)
+			ctx.Request = request.WithContext(auth.WithPrincipal(request.Context(), tt.principal))
+
+			c.DeleteTeam(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestController_RestoreTeam(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "when failed to restore team",
+			setup: func(s *serviceMock) {
+				s.On("restoreTeam", mock.Anything, "1").Return(Team{}, errors.New("failed to restore team"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to restore team\"}",
+		},
+		{
+			name: "when team is not found",
+			setup: func(s *serviceMock) {
+				s.On("restoreTeam", mock.Anything, "1").Return(Team{}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "{\"error\":\"team not found\"}",
+		},
+		{
+			name: "when successfully restores a team",
+			setup: func(s *serviceMock) {
+				team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+				s.On("restoreTeam", mock.Anything, "1").Return(team, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "{\"id\":\"1\",\"name\":\"Internacional\",\"fullName\":\"Sport Club Internacional\",\"website\":\"internacional.com.br\",\"foundationDate\":\"1909-04-04T00:00:00Z\",\"displayName\":\"Internacional\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "1")
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/teams/1/restore", nil)
+
+			c.RestoreTeam(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
 type serviceMock struct {
 	service
 	mock.Mock
@@ -184,14 +600,44 @@ func (m *serviceMock) createTeam(ctx context.Context, team Team) (Team, error) {
 	return args.Get(0).(Team), args.Error(1)
 }
 
-func (m *serviceMock) getTeam(ctx context.Context, id string) (Team, error) {
-	args := m.Called(ctx, id)
+func (m *serviceMock) getTeam(ctx context.Context, id string, includeDeleted bool) (Team, error) {
+	args := m.Called(ctx, id, includeDeleted)
 
 	return args.Get(0).(Team), args.Error(1)
 }
 
-func (m *serviceMock) getAllTeams(ctx context.Context) ([]Team, error) {
-	args := m.Called(ctx)
+func (m *serviceMock) getAllTeams(ctx context.Context, includeDeleted bool) ([]Team, error) {
+	args := m.Called(ctx, includeDeleted)
 
 	return args.Get(0).([]Team), args.Error(1)
 }
+
+func (m *serviceMock) streamTeams(ctx context.Context, fn func(Team) error) error {
+	args := m.Called(ctx, fn)
+
+	return args.Error(0)
+}
+
+func (m *serviceMock) updateTeam(ctx context.Context, id string, team Team, version int64) (Team, error) {
+	args := m.Called(ctx, id, team, version)
+
+	return args.Get(0).(Team), args.Error(1)
+}
+
+func (m *serviceMock) deleteTeam(ctx context.Context, id string) (Team, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Team), args.Error(1)
+}
+
+func (m *serviceMock) restoreTeam(ctx context.Context, id string) (Team, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Team), args.Error(1)
+}
+
+func (m *serviceMock) purgeTeam(ctx context.Context, id string) (Team, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Team), args.Error(1)
+}
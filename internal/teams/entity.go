@@ -1,15 +1,42 @@
 package teams
 
-import "time"
+import (
+	"sc-internacional/internal/i18n"
+	"time"
+)
 
+var csvHeader = []string{"id", "name", "fullName", "website", "foundationDate"}
+
+// Team is a club. Names holds its display names by language, such as
+// {"es": "Inter de Porto Alegre"}, and DisplayName the one matching the
+// language of the request, which the API answers but never stores.
 type Team struct {
-	Id             string    `json:"id,omitempty" bson:"_id,omitempty"`
-	Name           string    `json:"name" binding:"required"`
-	FullName       string    `json:"fullName" binding:"required"`
-	Website        string    `json:"website" binding:"required"`
-	FoundationDate time.Time `json:"foundationDate" binding:"required"`
+	Id             string            `json:"id,omitempty" bson:"_id,omitempty"`
+	Name           string            `json:"name" binding:"required"`
+	FullName       string            `json:"fullName" binding:"required"`
+	Website        string            `json:"website" binding:"required"`
+	FoundationDate time.Time         `json:"foundationDate" binding:"required"`
+	Nicknames      []string          `json:"nicknames,omitempty" bson:",omitempty"`
+	Names          map[string]string `json:"names,omitempty" bson:",omitempty" binding:"omitempty,dive,keys,oneof=pt-BR en es,endkeys,required"`
+	DisplayName    string            `json:"displayName,omitempty" bson:"-"`
+	Version        int64             `json:"version,omitempty"`
+	DeletedAt      *time.Time        `json:"deletedAt,omitempty" bson:",omitempty"`
 }
 
 func (t *Team) isEmpty() bool {
 	return t.Id == "" && t.Name == "" && t.FullName == "" && t.Website == "" && t.FoundationDate == time.Time{}
 }
+
+func (t *Team) isDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// Localized returns the team with the display name of lang.
+func (t Team) Localized(lang string) Team {
+	t.DisplayName = i18n.Name(lang, t.Name, t.Names)
+	return t
+}
+
+func (t *Team) csvRecord() []string {
+	return []string{t.Id, t.Name, t.FullName, t.Website, t.FoundationDate.Format(time.RFC3339)}
+}
@@ -0,0 +1,185 @@
+package teams
+
+import (
+	"context"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/pb"
+	"sc-internacional/internal/rpc"
+	"time"
+)
+
+var errNotFound = status.Error(codes.NotFound, "team not found")
+
+// GRPCServer serves the Teams gRPC service through the same service as
+// Controller, so that both APIs validate, audit and publish alike.
+type GRPCServer struct {
+	pb.UnimplementedTeamsServer
+	service service
+}
+
+func NewGRPCServer(service service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+func (s GRPCServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	if err := auth.AdminOnly(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	team, err := s.service.getTeam(ctx, req.GetId(), req.GetIncludeDeleted())
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if team.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return team.Proto(), nil
+}
+
+// ListTeams streams the teams straight from the database cursor, unless
+// deleted ones are asked for, which are read in full from the database first.
+func (s GRPCServer) ListTeams(req *pb.ListTeamsRequest, stream grpc.ServerStreamingServer[pb.Team]) error {
+	ctx := stream.Context()
+	if err := auth.AdminOnly(ctx, req.GetIncludeDeleted()); err != nil {
+		return err
+	}
+
+	if !req.GetIncludeDeleted() {
+		err := s.service.streamTeams(ctx, func(team Team) error {
+			return stream.Send(team.Proto())
+		})
+		return rpc.Error(err, errReferenced)
+	}
+
+	teams, err := s.service.getAllTeams(ctx, true)
+	if err != nil {
+		return rpc.Error(err, errReferenced)
+	}
+	for _, team := range teams {
+		if err = stream.Send(team.Proto()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s GRPCServer) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	team := TeamFromProto(req.GetTeam())
+	if err := binding.Validator.ValidateStruct(&team); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	createdTeam, err := s.service.createTeam(ctx, team)
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	return createdTeam.Proto(), nil
+}
+
+// UpdateTeam replaces a team. Like If-Match over REST, the request must carry
+// the version the caller last read.
+func (s GRPCServer) UpdateTeam(ctx context.Context, req *pb.UpdateTeamRequest) (*pb.Team, error) {
+	version, err := rpc.ExpectedVersion(req.Version, "team")
+	if err != nil {
+		return nil, err
+	}
+
+	team := TeamFromProto(req.GetTeam())
+	if err = binding.Validator.ValidateStruct(&team); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	updatedTeam, err := s.service.updateTeam(ctx, req.GetId(), team, version)
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if updatedTeam.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return updatedTeam.Proto(), nil
+}
+
+// DeleteTeam soft deletes the team, or removes it for good when an admin
+// sets hard and nothing references it anymore.
+func (s GRPCServer) DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.DeleteTeamResponse, error) {
+	if err := auth.AdminOnly(ctx, req.GetHard()); err != nil {
+		return nil, err
+	}
+
+	remove := s.service.deleteTeam
+	if req.GetHard() {
+		remove = s.service.purgeTeam
+	}
+
+	team, err := remove(ctx, req.GetId())
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if team.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return &pb.DeleteTeamResponse{}, nil
+}
+
+func (s GRPCServer) RestoreTeam(ctx context.Context, req *pb.RestoreTeamRequest) (*pb.Team, error) {
+	team, err := s.service.restoreTeam(ctx, req.GetId())
+	if err != nil {
+		return nil, rpc.Error(err, errReferenced)
+	}
+
+	if team.isEmpty() {
+		return nil, errNotFound
+	}
+
+	return team.Proto(), nil
+}
+
+// Proto converts the team to its protobuf message.
+func (t Team) Proto() *pb.Team {
+	team := &pb.Team{
+		Id:             t.Id,
+		Name:           t.Name,
+		FullName:       t.FullName,
+		Website:        t.Website,
+		FoundationDate: timestamppb.New(t.FoundationDate),
+		Version:        t.Version,
+		Nicknames:      t.Nicknames,
+		Names:          t.Names,
+	}
+	if t.DeletedAt != nil {
+		team.DeletedAt = timestamppb.New(*t.DeletedAt)
+	}
+
+	return team
+}
+
+// TeamFromProto converts the fields of a team message a client may set.
+func TeamFromProto(team *pb.Team) Team {
+	var foundationDate time.Time
+	if team.GetFoundationDate() != nil {
+		foundationDate = team.GetFoundationDate().AsTime()
+	}
+
+	return Team{
+		Id:             team.GetId(),
+		Name:           team.GetName(),
+		FullName:       team.GetFullName(),
+		Website:        team.GetWebsite(),
+		FoundationDate: foundationDate,
+		Nicknames:      team.GetNicknames(),
+		Names:          team.GetNames(),
+	}
+}
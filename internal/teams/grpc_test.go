@@ -0,0 +1,339 @@
+package teams
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"net"
+	"sc-internacional/internal/auth"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/pb"
+	"testing"
+	"time"
+)
+
+func TestGRPCServer_GetTeam(t *testing.T) {
+	team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3}
+
+	tests := []struct {
+		name         string
+		setup        func(*serviceMock)
+		role         auth.Role
+		request      *pb.GetTeamRequest
+		expectedCode codes.Code
+		expectedTeam *pb.Team
+	}{
+		{
+			name: "when team is not found",
+			setup: func(s *serviceMock) {
+				s.On("getTeam", mock.Anything, "1", false).Return(Team{}, nil)
+			},
+			request:      &pb.GetTeamRequest{Id: "1"},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "when id is malformed",
+			setup: func(s *serviceMock) {
+				s.On("getTeam", mock.Anything, "x", false).Return(Team{}, primitive.ErrInvalidHex)
+			},
+			request:      &pb.GetTeamRequest{Id: "x"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "when failed to get team",
+			setup: func(s *serviceMock) {
+				s.On("getTeam", mock.Anything, "1", false).Return(Team{}, errors.New("failed to find"))
+			},
+			request:      &pb.GetTeamRequest{Id: "1"},
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "when a reader asks for deleted teams",
+			setup:        func(s *serviceMock) {},
+			role:         auth.Reader,
+			request:      &pb.GetTeamRequest{Id: "1", IncludeDeleted: true},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "when successfully gets a team",
+			setup: func(s *serviceMock) {
+				s.On("getTeam", mock.Anything, "1", true).Return(team, nil)
+			},
+			role:         auth.Admin,
+			request:      &pb.GetTeamRequest{Id: "1", IncludeDeleted: true},
+			expectedCode: codes.OK,
+			expectedTeam: &pb.Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: timestamppb.New(team.FoundationDate), Version: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			got, err := NewGRPCServer(s).GetTeam(withRole(context.Background(), tt.role), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.True(t, proto.Equal(tt.expectedTeam, got))
+		})
+	}
+}
+
+func TestGRPCServer_ListTeams(t *testing.T) {
+	teams := []Team{
+		{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+		{Id: "2", Name: "Juventude", FullName: "Esporte Clube Juventude", Website: "juventude.com.br", FoundationDate: time.Date(1913, time.June, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name         string
+		setup        func(*serviceMock)
+		role         auth.Role
+		request      *pb.ListTeamsRequest
+		expectedCode codes.Code
+		expectedIds  []string
+	}{
+		{
+			name: "when failed to stream teams",
+			setup: func(s *serviceMock) {
+				s.On("streamTeams", mock.Anything, mock.Anything).Return(errors.New("failed to find"))
+			},
+			request:      &pb.ListTeamsRequest{},
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "when successfully streams teams",
+			setup: func(s *serviceMock) {
+				s.On("streamTeams", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					for _, team := range teams {
+						args.Get(1).(func(Team) error)(team)
+					}
+				}).Return(nil)
+			},
+			request:      &pb.ListTeamsRequest{},
+			expectedCode: codes.OK,
+			expectedIds:  []string{"1", "2"},
+		},
+		{
+			name:         "when a reader asks for deleted teams",
+			setup:        func(s *serviceMock) {},
+			role:         auth.Reader,
+			request:      &pb.ListTeamsRequest{IncludeDeleted: true},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "when an admin lists deleted teams",
+			setup: func(s *serviceMock) {
+				s.On("getAllTeams", mock.Anything, true).Return(teams, nil)
+			},
+			role:         auth.Admin,
+			request:      &pb.ListTeamsRequest{IncludeDeleted: true},
+			expectedCode: codes.OK,
+			expectedIds:  []string{"1", "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			stream, err := dial(t, s, tt.role).ListTeams(context.Background(), tt.request)
+			assert.NoError(t, err)
+
+			var ids []string
+			code := codes.OK
+			for {
+				team, err := stream.Recv()
+				if err != nil {
+					if err != io.EOF {
+						code = status.Code(err)
+					}
+					break
+				}
+				ids = append(ids, team.Id)
+			}
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedIds, ids)
+		})
+	}
+}
+
+func TestGRPCServer_UpdateTeam(t *testing.T) {
+	request := func(version *int64) *pb.UpdateTeamRequest {
+		return &pb.UpdateTeamRequest{
+			Id:      "1",
+			Team:    &pb.Team{Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: timestamppb.New(time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC))},
+			Version: version,
+		}
+	}
+	team := Team{Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name            string
+		setup           func(*serviceMock)
+		request         *pb.UpdateTeamRequest
+		expectedCode    codes.Code
+		expectedVersion int64
+	}{
+		{
+			name:         "when version is missing",
+			setup:        func(s *serviceMock) {},
+			request:      request(nil),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "when team is invalid",
+			setup:        func(s *serviceMock) {},
+			request:      &pb.UpdateTeamRequest{Id: "1", Team: &pb.Team{Name: "Internacional"}, Version: proto.Int64(3)},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "when team changed since it was read",
+			setup: func(s *serviceMock) {
+				s.On("updateTeam", mock.Anything, "1", team, int64(3)).Return(Team{}, etag.ErrMismatch)
+			},
+			request:      request(proto.Int64(3)),
+			expectedCode: codes.Aborted,
+		},
+		{
+			name: "when team is not found",
+			setup: func(s *serviceMock) {
+				s.On("updateTeam", mock.Anything, "1", team, etag.Any).Return(Team{}, nil)
+			},
+			request:      request(proto.Int64(-1)),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "when successfully updates a team",
+			setup: func(s *serviceMock) {
+				updatedTeam := team
+				updatedTeam.Id = "1"
+				updatedTeam.Version = 4
+				s.On("updateTeam", mock.Anything, "1", team, int64(3)).Return(updatedTeam, nil)
+			},
+			request:         request(proto.Int64(3)),
+			expectedCode:    codes.OK,
+			expectedVersion: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			got, err := NewGRPCServer(s).UpdateTeam(withRole(context.Background(), auth.Editor), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedVersion, got.GetVersion())
+		})
+	}
+}
+
+func TestGRPCServer_DeleteTeam(t *testing.T) {
+	team := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name         string
+		setup        func(*serviceMock)
+		role         auth.Role
+		request      *pb.DeleteTeamRequest
+		expectedCode codes.Code
+	}{
+		{
+			name: "when successfully deletes a team",
+			setup: func(s *serviceMock) {
+				s.On("deleteTeam", mock.Anything, "1").Return(team, nil)
+			},
+			role:         auth.Editor,
+			request:      &pb.DeleteTeamRequest{Id: "1"},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "when an editor purges a team",
+			setup:        func(s *serviceMock) {},
+			role:         auth.Editor,
+			request:      &pb.DeleteTeamRequest{Id: "1", Hard: true},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "when purged team is still referenced",
+			setup: func(s *serviceMock) {
+				s.On("purgeTeam", mock.Anything, "1").Return(Team{}, errReferenced)
+			},
+			role:         auth.Admin,
+			request:      &pb.DeleteTeamRequest{Id: "1", Hard: true},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "when successfully purges a team",
+			setup: func(s *serviceMock) {
+				s.On("purgeTeam", mock.Anything, "1").Return(team, nil)
+			},
+			role:         auth.Admin,
+			request:      &pb.DeleteTeamRequest{Id: "1", Hard: true},
+			expectedCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			_, err := NewGRPCServer(s).DeleteTeam(withRole(context.Background(), tt.role), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+// dial serves s over an in-memory connection, for the streaming methods, to
+// callers with role.
+func dial(t *testing.T, s service, role auth.Role) pb.TeamsClient {
+	server := grpc.NewServer(grpc.StreamInterceptor(func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, roleStream{ServerStream: stream, role: role})
+	}))
+	pb.RegisterTeamsServer(server, NewGRPCServer(s))
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewTeamsClient(conn)
+}
+
+// withRole returns ctx for a caller with role, or for an anonymous caller
+// when role is empty.
+func withRole(ctx context.Context, role auth.Role) context.Context {
+	if role == "" {
+		return ctx
+	}
+
+	return auth.WithPrincipal(ctx, auth.Principal{Subject: "test", Role: role})
+}
+
+type roleStream struct {
+	grpc.ServerStream
+	role auth.Role
+}
+
+func (s roleStream) Context() context.Context {
+	return withRole(s.ServerStream.Context(), s.role)
+}
@@ -2,30 +2,46 @@ package teams
 
 import (
 	"context"
+	"errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/clients/mongodb"
+	"sc-internacional/internal/tracing"
 )
 
 type db interface {
 	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
 	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
 	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+// referencesDB is a collection whose documents may point at a team.
+type referencesDB interface {
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
 }
 
 type Repository struct {
 	db
+	matches       referencesDB
+	championships referencesDB
 }
 
-func NewRepository(db db) *Repository {
-	return &Repository{db}
+func NewRepository(db db, matches, championships referencesDB) *Repository {
+	return &Repository{db: db, matches: matches, championships: championships}
 }
 
 func (r Repository) createTeam(ctx context.Context, team Team) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Repository.createTeam")
+	defer span.End()
+
+	team.Version = 1
 	result, err := r.db.InsertOne(ctx, team)
 	if err != nil {
-		return Team{}, err
+		return Team{}, tracing.Error(span, err)
 	}
 
 	team.Id = result.InsertedID.(primitive.ObjectID).Hex()
@@ -33,25 +49,105 @@ func (r Repository) createTeam(ctx context.Context, team Team) (Team, error) {
 	return team, nil
 }
 
-func (r Repository) getTeam(ctx context.Context, id string) (Team, error) {
+func (r Repository) getTeam(ctx context.Context, id string, includeDeleted bool) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Repository.getTeam")
+	defer span.End()
+
 	var team Team
 	docID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return Team{}, err
+		return Team{}, tracing.Error(span, err)
 	}
 
-	err = r.db.FindOne(ctx, bson.M{"_id": docID}).Decode(&team)
+	err = r.db.FindOne(ctx, mongodb.NotDeleted(bson.M{"_id": docID}, includeDeleted)).Decode(&team)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Team{}, nil
+	}
 	if err != nil {
-		return Team{}, err
+		return Team{}, tracing.Error(span, err)
 	}
 
 	return team, nil
 }
 
-func (r Repository) getAllTeams(ctx context.Context) ([]Team, error) {
-	cursor, err := r.db.Find(ctx, bson.M{})
+// updateTeam replaces the stored team with the same id and version, bumping
+// the version. It returns an empty team when there is no such team at that
+// version.
+func (r Repository) updateTeam(ctx context.Context, team Team) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Repository.updateTeam")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(team.Id)
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+
+	replacement := team
+	replacement.Id = ""
+	replacement.Version = team.Version + 1
+
+	result, err := r.db.ReplaceOne(ctx, bson.M{"_id": docID, "version": mongodb.AtVersion(team.Version)}, replacement)
 	if err != nil {
-		return []Team{}, err
+		return Team{}, tracing.Error(span, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return Team{}, nil
+	}
+
+	team.Version = replacement.Version
+
+	return team, nil
+}
+
+// deleteTeam removes the document for good. Soft deletes go through
+// updateTeam with DeletedAt set.
+func (r Repository) deleteTeam(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "teams.Repository.deleteTeam")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	if _, err = r.db.DeleteOne(ctx, bson.M{"_id": docID}); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// isReferenced reports whether any match or championship, deleted or not,
+// still points at the team.
+func (r Repository) isReferenced(ctx context.Context, id string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "teams.Repository.isReferenced")
+	defer span.End()
+
+	opts := options.Count().SetLimit(1)
+	count, err := r.matches.CountDocuments(ctx, bson.M{"$or": bson.A{bson.M{"teamhomeid": id}, bson.M{"teamawayid": id}}}, opts)
+	if err != nil {
+		return false, tracing.Error(span, err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	count, err = r.championships.CountDocuments(ctx, bson.M{"teams._id": id}, opts)
+	if err != nil {
+		return false, tracing.Error(span, err)
+	}
+
+	return count > 0, nil
+}
+
+func (r Repository) getAllTeams(ctx context.Context, includeDeleted bool) ([]Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Repository.getAllTeams")
+	defer span.End()
+
+	cursor, err := r.db.Find(ctx, mongodb.NotDeleted(bson.M{}, includeDeleted))
+	if err != nil {
+		return []Team{}, tracing.Error(span, err)
 	}
 	defer cursor.Close(ctx)
 
@@ -59,8 +155,56 @@ func (r Repository) getAllTeams(ctx context.Context) ([]Team, error) {
 
 	err = cursor.All(ctx, &teams)
 	if err != nil {
-		return []Team{}, err
+		return []Team{}, tracing.Error(span, err)
+	}
+
+	return teams, nil
+}
+
+// getTeams returns the teams with the given ids that exist and are not
+// deleted, in no particular order.
+func (r Repository) getTeams(ctx context.Context, ids []string) ([]Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Repository.getTeams")
+	defer span.End()
+
+	cursor, err := r.db.Find(ctx, mongodb.NotDeleted(bson.M{"_id": bson.M{"$in": mongodb.ObjectIDs(ids)}}, false))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	teams := []Team{}
+	if err = cursor.All(ctx, &teams); err != nil {
+		return nil, tracing.Error(span, err)
 	}
 
 	return teams, nil
 }
+
+func (r Repository) streamTeams(ctx context.Context, fn func(Team) error) error {
+	ctx, span := tracer.Start(ctx, "teams.Repository.streamTeams")
+	defer span.End()
+
+	cursor, err := r.db.Find(ctx, mongodb.NotDeleted(bson.M{}, false))
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var team Team
+		if err = cursor.Decode(&team); err != nil {
+			return tracing.Error(span, err)
+		}
+
+		if err = fn(team); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	if err = cursor.Err(); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
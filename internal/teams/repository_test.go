@@ -25,7 +25,7 @@ func TestRepository_createTeam(t *testing.T) {
 		{
 			name: "when failed to create a team",
 			setup: func(d *dbMock) {
-				receivedTeam := Team{Id: "", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+				receivedTeam := Team{Id: "", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1}
 				d.On("InsertOne", mock.Anything, receivedTeam, []*options.InsertOneOptions(nil)).Return(&mongo.InsertOneResult{}, errors.New("failed to create team"))
 			},
 			team:    Team{Id: "", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
@@ -35,11 +35,11 @@ func TestRepository_createTeam(t *testing.T) {
 		{
 			name: "when successfully create a team",
 			setup: func(d *dbMock) {
-				receivedTeam := Team{Id: "", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+				receivedTeam := Team{Id: "", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1}
 				d.On("InsertOne", mock.Anything, receivedTeam, []*options.InsertOneOptions(nil)).Return(&mongo.InsertOneResult{InsertedID: objectId}, nil)
 			},
 			team:    Team{Id: "", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
-			want:    Team{Id: objectId.Hex(), Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			want:    Team{Id: objectId.Hex(), Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1},
 			wantErr: nil,
 		},
 	}
@@ -48,7 +48,7 @@ func TestRepository_createTeam(t *testing.T) {
 			d := &dbMock{}
 			tt.setup(d)
 
-			r := NewRepository(d)
+			r := NewRepository(d, &dbMock{}, &dbMock{})
 
 			got, err := r.createTeam(context.Background(), tt.team)
 
@@ -77,7 +77,7 @@ func TestRepository_getTeam(t *testing.T) {
 			name: "when failed to find team",
 			setup: func(d *dbMock) {
 				hexId := primitive.ObjectID{0x67, 0xa, 0x95, 0xa8, 0xc1, 0x35, 0xef, 0x7c, 0x3d, 0x61, 0xf3, 0xb5}
-				d.On("FindOne", mock.Anything, primitive.M{"_id": hexId}, []*options.FindOneOptions(nil)).Return(mongo.NewSingleResultFromDocument(nil, nil, nil))
+				d.On("FindOne", mock.Anything, primitive.M{"_id": hexId, "deletedat": nil}, []*options.FindOneOptions(nil)).Return(mongo.NewSingleResultFromDocument(nil, nil, nil))
 			},
 			id:      "670a95a8c135ef7c3d61f3b5",
 			want:    Team{},
@@ -88,7 +88,7 @@ func TestRepository_getTeam(t *testing.T) {
 			setup: func(d *dbMock) {
 				hexId := primitive.ObjectID{0x67, 0xa, 0x95, 0xa8, 0xc1, 0x35, 0xef, 0x7c, 0x3d, 0x61, 0xf3, 0xb5}
 				result := map[string]interface{}{"_id": "670a95a8c135ef7c3d61f3b5", "name": "Internacional", "fullName": "Sport Club Internacional", "website": "internacional.com.br", "foundationDate": time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
-				d.On("FindOne", mock.Anything, primitive.M{"_id": hexId}, []*options.FindOneOptions(nil)).Return(mongo.NewSingleResultFromDocument(result, nil, nil))
+				d.On("FindOne", mock.Anything, primitive.M{"_id": hexId, "deletedat": nil}, []*options.FindOneOptions(nil)).Return(mongo.NewSingleResultFromDocument(result, nil, nil))
 			},
 			id:      "670a95a8c135ef7c3d61f3b5",
 			want:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
@@ -100,9 +100,9 @@ func TestRepository_getTeam(t *testing.T) {
 			d := &dbMock{}
 			tt.setup(d)
 
-			r := NewRepository(d)
+			r := NewRepository(d, &dbMock{}, &dbMock{})
 
-			got, err := r.getTeam(context.Background(), tt.id)
+			got, err := r.getTeam(context.Background(), tt.id, false)
 
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err)
@@ -120,7 +120,7 @@ func TestRepository_getAllTeams(t *testing.T) {
 		{
 			name: "when failed to find teams",
 			setup: func(d *dbMock) {
-				d.On("Find", mock.Anything, bson.M{}, []*options.FindOptions(nil)).Return(&mongo.Cursor{}, errors.New("failed to find"))
+				d.On("Find", mock.Anything, bson.M{"deletedat": nil}, []*options.FindOptions(nil)).Return(&mongo.Cursor{}, errors.New("failed to find"))
 			},
 			want:    []Team{},
 			wantErr: errors.New("failed to find"),
@@ -131,9 +131,263 @@ func TestRepository_getAllTeams(t *testing.T) {
 			d := &dbMock{}
 			tt.setup(d)
 
-			r := NewRepository(d)
+			r := NewRepository(d, &dbMock{}, &dbMock{})
 
-			got, err := r.getAllTeams(context.Background())
+			got, err := r.getAllTeams(context.Background(), false)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRepository_getTeams(t *testing.T) {
+	docID, _ := primitive.ObjectIDFromHex("670000000000000000000001")
+	tests := []struct {
+		name    string
+		ids     []string
+		setup   func(d *dbMock)
+		wantErr error
+	}{
+		{
+			name: "when failed to find teams",
+			ids:  []string{"670000000000000000000001", "not-an-id"},
+			setup: func(d *dbMock) {
+				filter := bson.M{"_id": bson.M{"$in": []primitive.ObjectID{docID}}, "deletedat": nil}
+				d.On("Find", mock.Anything, filter, []*options.FindOptions(nil)).Return(&mongo.Cursor{}, errors.New("failed to find"))
+			},
+			wantErr: errors.New("failed to find"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dbMock{}
+			tt.setup(d)
+
+			r := NewRepository(d, &dbMock{}, &dbMock{})
+
+			got, err := r.getTeams(context.Background(), tt.ids)
+
+			assert.Nil(t, got)
+			assert.Equal(t, tt.wantErr, err)
+			d.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepository_streamTeams(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(d *dbMock)
+		want    []Team
+		wantErr error
+	}{
+		{
+			name: "when failed to find teams",
+			setup: func(d *dbMock) {
+				d.On("Find", mock.Anything, bson.M{"deletedat": nil}, []*options.FindOptions(nil)).Return(&mongo.Cursor{}, errors.New("failed to find"))
+			},
+			want:    nil,
+			wantErr: errors.New("failed to find"),
+		},
+		{
+			name: "when successfully stream teams",
+			setup: func(d *dbMock) {
+				documents := []interface{}{
+					bson.M{"_id": "670a95a8c135ef7c3d61f3b5", "name": "Internacional", "fullName": "Sport Club Internacional", "website": "internacional.com.br", "foundationDate": time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+					bson.M{"_id": "670a95a8c135ef7c3d61f3b6", "name": "Grêmio", "fullName": "Grêmio Foot-Ball Porto Alegrense", "website": "gremio.net", "foundationDate": time.Date(1903, time.September, 15, 0, 0, 0, 0, time.UTC)},
+				}
+				cursor, _ := mongo.NewCursorFromDocuments(documents, nil, nil)
+				d.On("Find", mock.Anything, bson.M{"deletedat": nil}, []*options.FindOptions(nil)).Return(cursor, nil)
+			},
+			want: []Team{
+				{Id: "670a95a8c135ef7c3d61f3b5", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+				{Id: "670a95a8c135ef7c3d61f3b6", Name: "Grêmio", FullName: "Grêmio Foot-Ball Porto Alegrense", Website: "gremio.net", FoundationDate: time.Date(1903, time.September, 15, 0, 0, 0, 0, time.UTC)},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dbMock{}
+			tt.setup(d)
+
+			r := NewRepository(d, &dbMock{}, &dbMock{})
+
+			var got []Team
+			err := r.streamTeams(context.Background(), func(team Team) error {
+				got = append(got, team)
+				return nil
+			})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRepository_updateTeam(t *testing.T) {
+	hexId := primitive.ObjectID{0x67, 0xa, 0x95, 0xa8, 0xc1, 0x35, 0xef, 0x7c, 0x3d, 0x61, 0xf3, 0xb5}
+	replacement := Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3}
+	tests := []struct {
+		name    string
+		setup   func(d *dbMock)
+		team    Team
+		want    Team
+		wantErr error
+	}{
+		{
+			name:    "when invalid id is received",
+			setup:   func(d *dbMock) {},
+			team:    Team{Id: "xpto"},
+			want:    Team{},
+			wantErr: errors.New("the provided hex string is not a valid ObjectID"),
+		},
+		{
+			name: "when failed to replace team",
+			setup: func(d *dbMock) {
+				d.On("ReplaceOne", mock.Anything, bson.M{"_id": hexId, "version": int64(2)}, replacement, []*options.ReplaceOptions(nil)).Return(&mongo.UpdateResult{}, errors.New("failed to replace"))
+			},
+			team:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 2},
+			want:    Team{},
+			wantErr: errors.New("failed to replace"),
+		},
+		{
+			name: "when team does not exist",
+			setup: func(d *dbMock) {
+				d.On("ReplaceOne", mock.Anything, bson.M{"_id": hexId, "version": int64(2)}, replacement, []*options.ReplaceOptions(nil)).Return(&mongo.UpdateResult{MatchedCount: 0}, nil)
+			},
+			team:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 2},
+			want:    Team{},
+			wantErr: nil,
+		},
+		{
+			name: "when successfully replace team",
+			setup: func(d *dbMock) {
+				d.On("ReplaceOne", mock.Anything, bson.M{"_id": hexId, "version": int64(2)}, replacement, []*options.ReplaceOptions(nil)).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+			},
+			team:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 2},
+			want:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3},
+			wantErr: nil,
+		},
+		{
+			name: "when team was written before versions existed",
+			setup: func(d *dbMock) {
+				legacy := Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1}
+				d.On("ReplaceOne", mock.Anything, bson.M{"_id": hexId, "version": bson.M{"$in": bson.A{0, nil}}}, legacy, []*options.ReplaceOptions(nil)).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+			},
+			team:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			want:    Team{Id: "670a95a8c135ef7c3d61f3b5", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dbMock{}
+			tt.setup(d)
+
+			r := NewRepository(d, &dbMock{}, &dbMock{})
+
+			got, err := r.updateTeam(context.Background(), tt.team)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRepository_deleteTeam(t *testing.T) {
+	hexId := primitive.ObjectID{0x67, 0xa, 0x95, 0xa8, 0xc1, 0x35, 0xef, 0x7c, 0x3d, 0x61, 0xf3, 0xb5}
+	tests := []struct {
+		name    string
+		setup   func(d *dbMock)
+		id      string
+		wantErr error
+	}{
+		{
+			name: "when failed to delete team",
+			setup: func(d *dbMock) {
+				d.On("DeleteOne", mock.Anything, bson.M{"_id": hexId}, []*options.DeleteOptions(nil)).Return(&mongo.DeleteResult{}, errors.New("failed to delete"))
+			},
+			id:      "670a95a8c135ef7c3d61f3b5",
+			wantErr: errors.New("failed to delete"),
+		},
+		{
+			name: "when successfully delete team",
+			setup: func(d *dbMock) {
+				d.On("DeleteOne", mock.Anything, bson.M{"_id": hexId}, []*options.DeleteOptions(nil)).Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+			},
+			id:      "670a95a8c135ef7c3d61f3b5",
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dbMock{}
+			tt.setup(d)
+
+			r := NewRepository(d, &dbMock{}, &dbMock{})
+
+			err := r.deleteTeam(context.Background(), tt.id)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRepository_isReferenced(t *testing.T) {
+	matchesFilter := bson.M{"$or": bson.A{bson.M{"teamhomeid": "1"}, bson.M{"teamawayid": "1"}}}
+	championshipsFilter := bson.M{"teams._id": "1"}
+	opts := []*options.CountOptions{options.Count().SetLimit(1)}
+	tests := []struct {
+		name    string
+		setup   func(m, c *dbMock)
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "when failed to count matches",
+			setup: func(m, c *dbMock) {
+				m.On("CountDocuments", mock.Anything, matchesFilter, opts).Return(int64(0), errors.New("failed to count"))
+			},
+			want:    false,
+			wantErr: errors.New("failed to count"),
+		},
+		{
+			name: "when a match references the team",
+			setup: func(m, c *dbMock) {
+				m.On("CountDocuments", mock.Anything, matchesFilter, opts).Return(int64(1), nil)
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name: "when a championship references the team",
+			setup: func(m, c *dbMock) {
+				m.On("CountDocuments", mock.Anything, matchesFilter, opts).Return(int64(0), nil)
+				c.On("CountDocuments", mock.Anything, championshipsFilter, opts).Return(int64(1), nil)
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name: "when nothing references the team",
+			setup: func(m, c *dbMock) {
+				m.On("CountDocuments", mock.Anything, matchesFilter, opts).Return(int64(0), nil)
+				c.On("CountDocuments", mock.Anything, championshipsFilter, opts).Return(int64(0), nil)
+			},
+			want:    false,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, c := &dbMock{}, &dbMock{}
+			tt.setup(m, c)
+
+			r := NewRepository(&dbMock{}, m, c)
+
+			got, err := r.isReferenced(context.Background(), "1")
 
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err)
@@ -163,3 +417,21 @@ func (m *dbMock) Find(ctx context.Context, filter interface{}, opts ...*options.
 
 	return args.Get(0).(*mongo.Cursor), args.Error(1)
 }
+
+func (m *dbMock) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, replacement, opts)
+
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *dbMock) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	args := m.Called(ctx, filter, opts)
+
+	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
+}
+
+func (m *dbMock) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	args := m.Called(ctx, filter, opts)
+
+	return args.Get(0).(int64), args.Error(1)
+}
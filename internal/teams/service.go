@@ -1,44 +1,321 @@
 package teams
 
-import "context"
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+const (
+	auditEntity   = "team"
+	allTeamsKey   = "teams"
+	teamKeyPrefix = "teams:"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/teams")
+
+var errReferenced = errors.New("team is still referenced by matches or championships")
 
 type repository interface {
 	createTeam(ctx context.Context, team Team) (Team, error)
-	getTeam(ctx context.Context, id string) (Team, error)
-	getAllTeams(ctx context.Context) ([]Team, error)
+	getTeam(ctx context.Context, id string, includeDeleted bool) (Team, error)
+	getAllTeams(ctx context.Context, includeDeleted bool) ([]Team, error)
+	getTeams(ctx context.Context, ids []string) ([]Team, error)
+	updateTeam(ctx context.Context, team Team) (Team, error)
+	deleteTeam(ctx context.Context, id string) error
+	isReferenced(ctx context.Context, id string) (bool, error)
+	streamTeams(ctx context.Context, fn func(Team) error) error
+}
+
+type auditor interface {
+	Record(ctx context.Context, action audit.Action, entity, entityId string, before, after interface{})
+}
+
+type publisher interface {
+	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
+	Publish(ctx context.Context, eventType events.Type, entityId string, previous, current interface{}) error
 }
 
 type Service struct {
 	repository repository
+	auditor    auditor
+	cache      cache.Cache
+	publisher  publisher
+	now        func() time.Time
 }
 
-func NewService(repository repository) *Service {
-	return &Service{repository: repository}
+func NewService(repository repository, auditor auditor, cache cache.Cache, publisher publisher) *Service {
+	return &Service{repository: repository, auditor: auditor, cache: cache, publisher: publisher, now: time.Now}
 }
 
 func (s Service) createTeam(ctx context.Context, team Team) (Team, error) {
-	createdTeam, err := s.repository.createTeam(ctx, team)
+	ctx, span := tracer.Start(ctx, "teams.Service.createTeam")
+	defer span.End()
+
+	team.DeletedAt = nil
+	var createdTeam Team
+	err := s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		createdTeam, err = s.repository.createTeam(ctx, team)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.TeamCreated, createdTeam.Id, nil, createdTeam)
+	})
 	if err != nil {
-		return Team{}, err
+		return Team{}, tracing.Error(span, err)
 	}
 
+	s.invalidate(ctx, createdTeam.Id)
+	s.auditor.Record(ctx, audit.Create, auditEntity, createdTeam.Id, nil, createdTeam)
+
 	return createdTeam, nil
 }
 
-func (s Service) getTeam(ctx context.Context, id string) (Team, error) {
-	team, err := s.repository.getTeam(ctx, id)
+func (s Service) getTeam(ctx context.Context, id string, includeDeleted bool) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.getTeam")
+	defer span.End()
+
+	load := func(ctx context.Context) (Team, error) {
+		return s.repository.getTeam(ctx, id, includeDeleted)
+	}
+
+	var team Team
+	var err error
+	if includeDeleted {
+		// Deleted teams are only shown to admins, so that view is not cached.
+		team, err = load(ctx)
+	} else {
+		team, err = cache.Fetch(ctx, s.cache, teamKeyPrefix+id, load)
+	}
 	if err != nil {
-		return Team{}, err
+		return Team{}, tracing.Error(span, err)
 	}
 
 	return team, nil
 }
 
-func (s Service) getAllTeams(ctx context.Context) ([]Team, error) {
-	teams, err := s.repository.getAllTeams(ctx)
+func (s Service) getAllTeams(ctx context.Context, includeDeleted bool) ([]Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.getAllTeams")
+	defer span.End()
+
+	load := func(ctx context.Context) ([]Team, error) {
+		return s.repository.getAllTeams(ctx, includeDeleted)
+	}
+
+	var teams []Team
+	var err error
+	if includeDeleted {
+		teams, err = load(ctx)
+	} else {
+		teams, err = cache.Fetch(ctx, s.cache, allTeamsKey, load)
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return teams, nil
+}
+
+// ListTeams returns every team that is not deleted.
+func (s Service) ListTeams(ctx context.Context) ([]Team, error) {
+	return s.getAllTeams(ctx, false)
+}
+
+// GetTeams returns the teams with the given ids that exist and are not
+// deleted, in no particular order, reading them all at once so that callers
+// resolving many references do not query once per team.
+func (s Service) GetTeams(ctx context.Context, ids []string) ([]Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.GetTeams")
+	defer span.End()
+
+	teams, err := s.repository.getTeams(ctx, ids)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return teams, nil
 }
+
+// updateTeam replaces the team with the given id if it is still at version,
+// or at any version when it is etag.Any. It returns an empty team when the
+// team does not exist or is deleted, and etag.ErrMismatch when it changed.
+func (s Service) updateTeam(ctx context.Context, id string, team Team, version int64) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.updateTeam")
+	defer span.End()
+
+	before, err := s.repository.getTeam(ctx, id, false)
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Team{}, nil
+	}
+	if version != etag.Any && version != before.Version {
+		return Team{}, etag.ErrMismatch
+	}
+
+	team.Id = id
+	team.Version = before.Version
+	team.DeletedAt = nil
+	var updatedTeam Team
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTeam, err = s.repository.updateTeam(ctx, team)
+		if err != nil || updatedTeam.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.TeamUpdated, id, before, updatedTeam)
+	})
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if updatedTeam.isEmpty() {
+		return Team{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Update, auditEntity, id, before, updatedTeam)
+
+	return updatedTeam, nil
+}
+
+// deleteTeam marks the team with the given id as deleted and returns it, or
+// an empty team when it does not exist or is already deleted.
+func (s Service) deleteTeam(ctx context.Context, id string) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.deleteTeam")
+	defer span.End()
+
+	before, err := s.repository.getTeam(ctx, id, false)
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Team{}, nil
+	}
+
+	deletedAt := s.now().UTC().Truncate(time.Millisecond)
+	team := before
+	team.DeletedAt = &deletedAt
+
+	var deletedTeam Team
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		deletedTeam, err = s.repository.updateTeam(ctx, team)
+		if err != nil || deletedTeam.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.TeamDeleted, id, before, deletedTeam)
+	})
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if deletedTeam.isEmpty() {
+		return Team{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Delete, auditEntity, id, before, deletedTeam)
+
+	return deletedTeam, nil
+}
+
+// restoreTeam clears the deletion marker of the team with the given id. A
+// team that is not deleted is returned unchanged.
+func (s Service) restoreTeam(ctx context.Context, id string) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.restoreTeam")
+	defer span.End()
+
+	before, err := s.repository.getTeam(ctx, id, true)
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() || !before.isDeleted() {
+		return before, nil
+	}
+
+	team := before
+	team.DeletedAt = nil
+
+	var restoredTeam Team
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		restoredTeam, err = s.repository.updateTeam(ctx, team)
+		if err != nil || restoredTeam.isEmpty() {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.TeamRestored, id, before, restoredTeam)
+	})
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if restoredTeam.isEmpty() {
+		return Team{}, etag.ErrMismatch
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Restore, auditEntity, id, before, restoredTeam)
+
+	return restoredTeam, nil
+}
+
+// purgeTeam removes the team with the given id for good, deleted or not. It
+// fails with errReferenced while a match or championship points at it.
+func (s Service) purgeTeam(ctx context.Context, id string) (Team, error) {
+	ctx, span := tracer.Start(ctx, "teams.Service.purgeTeam")
+	defer span.End()
+
+	before, err := s.repository.getTeam(ctx, id, true)
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if before.isEmpty() {
+		return Team{}, nil
+	}
+
+	referenced, err := s.repository.isReferenced(ctx, id)
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+	if referenced {
+		return Team{}, errReferenced
+	}
+
+	err = s.publisher.Atomic(ctx, func(ctx context.Context) error {
+		if err := s.repository.deleteTeam(ctx, id); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, events.TeamPurged, id, before, nil)
+	})
+	if err != nil {
+		return Team{}, tracing.Error(span, err)
+	}
+
+	s.invalidate(ctx, id)
+	s.auditor.Record(ctx, audit.Purge, auditEntity, id, before, nil)
+
+	return before, nil
+}
+
+// invalidate drops the cached reads a write to the team with the given id
+// makes stale.
+func (s Service) invalidate(ctx context.Context, id string) {
+	cache.Invalidate(ctx, s.cache, teamKeyPrefix+id, allTeamsKey)
+}
+
+func (s Service) streamTeams(ctx context.Context, fn func(Team) error) error {
+	ctx, span := tracer.Start(ctx, "teams.Service.streamTeams")
+	defer span.End()
+
+	if err := s.repository.streamTeams(ctx, fn); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
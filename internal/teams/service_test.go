@@ -5,6 +5,10 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"sc-internacional/internal/audit"
+	"sc-internacional/internal/cache"
+	"sc-internacional/internal/etag"
+	"sc-internacional/internal/events"
 	"testing"
 	"time"
 )
@@ -44,7 +48,10 @@ func TestService_createTeam(t *testing.T) {
 			r := &repositoryMock{}
 			tt.setup(r)
 
-			s := NewService(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
 
 			got, err := s.createTeam(context.Background(), tt.team)
 
@@ -65,7 +72,7 @@ func TestService_getTeamById(t *testing.T) {
 		{
 			name: "when repository fail to get team",
 			setup: func(r *repositoryMock) {
-				r.On("getTeam", mock.Anything, "1").Return(Team{}, errors.New("failed to get team"))
+				r.On("getTeam", mock.Anything, "1", false).Return(Team{}, errors.New("failed to get team"))
 			},
 			id:      "1",
 			want:    Team{},
@@ -75,7 +82,7 @@ func TestService_getTeamById(t *testing.T) {
 			name: "when repository successfully get team",
 			setup: func(r *repositoryMock) {
 				returnTeam := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
-				r.On("getTeam", mock.Anything, "1").Return(returnTeam, nil)
+				r.On("getTeam", mock.Anything, "1", false).Return(returnTeam, nil)
 			},
 			id:      "1",
 			want:    Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
@@ -87,9 +94,12 @@ func TestService_getTeamById(t *testing.T) {
 			r := &repositoryMock{}
 			tt.setup(r)
 
-			s := NewService(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
 
-			got, err := s.getTeam(context.Background(), tt.id)
+			got, err := s.getTeam(context.Background(), tt.id, false)
 
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err)
@@ -97,6 +107,340 @@ func TestService_getTeamById(t *testing.T) {
 	}
 }
 
+func TestService_cachesReadsUntilTeamChanges(t *testing.T) {
+	before := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 1}
+	after := before
+	after.Name = "Inter"
+	after.Version = 2
+
+	r := &repositoryMock{}
+	r.On("getTeam", mock.Anything, "1", false).Return(before, nil).Once()
+	r.On("getAllTeams", mock.Anything, false).Return([]Team{before}, nil).Once()
+	a := &auditorMock{}
+	a.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	s := NewService(r, a, cache.NewLRU(10, time.Minute), events.NewBus())
+
+	for i := 0; i < 2; i++ {
+		got, err := s.getTeam(context.Background(), "1", false)
+		assert.NoError(t, err)
+		assert.Equal(t, before, got)
+
+		all, err := s.getAllTeams(context.Background(), false)
+		assert.NoError(t, err)
+		assert.Equal(t, []Team{before}, all)
+	}
+
+	r.On("getTeam", mock.Anything, "1", false).Return(before, nil).Once()
+	r.On("updateTeam", mock.Anything, mock.Anything).Return(after, nil).Once()
+	_, err := s.updateTeam(context.Background(), "1", Team{Name: "Inter"}, etag.Any)
+	assert.NoError(t, err)
+
+	r.On("getTeam", mock.Anything, "1", false).Return(after, nil).Once()
+	r.On("getAllTeams", mock.Anything, false).Return([]Team{after}, nil).Once()
+
+	got, err := s.getTeam(context.Background(), "1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, after, got)
+
+	all, err := s.getAllTeams(context.Background(), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []Team{after}, all)
+	r.AssertExpectations(t)
+}
+
+func TestService_updateTeam(t *testing.T) {
+	before := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 2}
+	replacement := Team{Id: "1", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 2}
+	after := Team{Id: "1", Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC), Version: 3}
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		team      Team
+		version   int64
+		want      Team
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when repository fail to get team",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(Team{}, errors.New("failed to get team"))
+			},
+			team:    Team{Name: "Inter"},
+			want:    Team{},
+			wantErr: errors.New("failed to get team"),
+		},
+		{
+			name: "when team does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(Team{}, nil)
+			},
+			team:    Team{Name: "Inter"},
+			want:    Team{},
+			wantErr: nil,
+		},
+		{
+			name: "when repository fail to update team",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateTeam", mock.Anything, replacement).Return(Team{}, errors.New("failed to update team"))
+			},
+			team:    Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			version: 2,
+			want:    Team{},
+			wantErr: errors.New("failed to update team"),
+		},
+		{
+			name: "when team is at another version",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(before, nil)
+			},
+			team:    Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			version: 1,
+			want:    Team{},
+			wantErr: etag.ErrMismatch,
+		},
+		{
+			name: "when team changes concurrently",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateTeam", mock.Anything, replacement).Return(Team{}, nil)
+			},
+			team:    Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			version: etag.Any,
+			want:    Team{},
+			wantErr: etag.ErrMismatch,
+		},
+		{
+			name: "when repository successfully update team",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateTeam", mock.Anything, replacement).Return(after, nil)
+			},
+			team:      Team{Name: "Inter", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)},
+			version:   2,
+			want:      after,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Update, "team", "1", before, after).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.updateTeam(context.Background(), "1", tt.team, tt.version)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_deleteTeam(t *testing.T) {
+	now := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+	before := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+	deleted := before
+	deleted.DeletedAt = &now
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Team
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when team does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(Team{}, nil)
+			},
+			want:    Team{},
+			wantErr: nil,
+		},
+		{
+			name: "when repository fail to mark team as deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateTeam", mock.Anything, deleted).Return(Team{}, errors.New("failed to update team"))
+			},
+			want:    Team{},
+			wantErr: errors.New("failed to update team"),
+		},
+		{
+			name: "when repository successfully mark team as deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", false).Return(before, nil)
+				r.On("updateTeam", mock.Anything, deleted).Return(deleted, nil)
+			},
+			want:      deleted,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Delete, "team", "1", before, deleted).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+			s.now = func() time.Time { return now }
+
+			got, err := s.deleteTeam(context.Background(), "1")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_restoreTeam(t *testing.T) {
+	deletedAt := time.Date(2024, time.October, 12, 18, 30, 0, 0, time.UTC)
+	restored := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+	deleted := restored
+	deleted.DeletedAt = &deletedAt
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Team
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when team does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(Team{}, nil)
+			},
+			want:    Team{},
+			wantErr: nil,
+		},
+		{
+			name: "when team is not deleted",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(restored, nil)
+			},
+			want:    restored,
+			wantErr: nil,
+		},
+		{
+			name: "when repository successfully restore team",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(deleted, nil)
+				r.On("updateTeam", mock.Anything, restored).Return(restored, nil)
+			},
+			want:      restored,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Restore, "team", "1", deleted, restored).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.restoreTeam(context.Background(), "1")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_purgeTeam(t *testing.T) {
+	before := Team{Id: "1", Name: "Internacional", FullName: "Sport Club Internacional", Website: "internacional.com.br", FoundationDate: time.Date(1909, time.April, 4, 0, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name      string
+		setup     func(r *repositoryMock)
+		want      Team
+		wantErr   error
+		wantAudit bool
+	}{
+		{
+			name: "when team does not exist",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(Team{}, nil)
+			},
+			want:    Team{},
+			wantErr: nil,
+		},
+		{
+			name: "when team is still referenced",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(before, nil)
+				r.On("isReferenced", mock.Anything, "1").Return(true, nil)
+			},
+			want:    Team{},
+			wantErr: errReferenced,
+		},
+		{
+			name: "when repository fail to delete team",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(before, nil)
+				r.On("isReferenced", mock.Anything, "1").Return(false, nil)
+				r.On("deleteTeam", mock.Anything, "1").Return(errors.New("failed to delete team"))
+			},
+			want:    Team{},
+			wantErr: errors.New("failed to delete team"),
+		},
+		{
+			name: "when repository successfully delete team",
+			setup: func(r *repositoryMock) {
+				r.On("getTeam", mock.Anything, "1", true).Return(before, nil)
+				r.On("isReferenced", mock.Anything, "1").Return(false, nil)
+				r.On("deleteTeam", mock.Anything, "1").Return(nil)
+			},
+			want:      before,
+			wantErr:   nil,
+			wantAudit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			tt.setup(r)
+			a := &auditorMock{}
+			a.On("Record", mock.Anything, audit.Purge, "team", "1", before, nil).Return()
+
+			s := NewService(r, a, cache.Nop{}, events.NewBus())
+
+			got, err := s.purgeTeam(context.Background(), "1")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantAudit {
+				a.AssertExpectations(t)
+			} else {
+				a.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
 type repositoryMock struct {
 	repository
 	mock.Mock
@@ -108,8 +452,40 @@ func (m *repositoryMock) createTeam(ctx context.Context, team Team) (Team, error
 	return args.Get(0).(Team), args.Error(1)
 }
 
-func (m *repositoryMock) getTeam(ctx context.Context, id string) (Team, error) {
-	args := m.Called(ctx, id)
+func (m *repositoryMock) getTeam(ctx context.Context, id string, includeDeleted bool) (Team, error) {
+	args := m.Called(ctx, id, includeDeleted)
 
 	return args.Get(0).(Team), args.Error(1)
 }
+
+func (m *repositoryMock) getAllTeams(ctx context.Context, includeDeleted bool) ([]Team, error) {
+	args := m.Called(ctx, includeDeleted)
+
+	return args.Get(0).([]Team), args.Error(1)
+}
+
+func (m *repositoryMock) updateTeam(ctx context.Context, team Team) (Team, error) {
+	args := m.Called(ctx, team)
+
+	return args.Get(0).(Team), args.Error(1)
+}
+
+func (m *repositoryMock) deleteTeam(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *repositoryMock) isReferenced(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+
+	return args.Bool(0), args.Error(1)
+}
+
+type auditorMock struct {
+	mock.Mock
+}
+
+func (m *auditorMock) Record(ctx context.Context, action audit.Action, entity, entityId string, before, after interface{}) {
+	m.Called(ctx, action, entity, entityId, before, after)
+}
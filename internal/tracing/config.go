@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"fmt"
+	"github.com/caarlos0/env/v11"
+)
+
+// Config selects where spans are exported. The OTLP exporter itself is
+// configured with the standard OTEL_EXPORTER_OTLP_* variables, and sampling
+// with OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG.
+type Config struct {
+	Exporter    string `env:"OTEL_TRACES_EXPORTER" envDefault:"none"`
+	ServiceName string `env:"OTEL_SERVICE_NAME" envDefault:"sc-internacional"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	switch cfg.Exporter {
+	case "none", "otlp", "stdout":
+	default:
+		return nil, fmt.Errorf("invalid OTEL_TRACES_EXPORTER %q, use otlp, stdout or none", cfg.Exporter)
+	}
+	return &cfg, nil
+}
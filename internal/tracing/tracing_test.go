@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := gin.New()
+	r.Use(Middleware(&Config{ServiceName: "sc-internacional"}))
+	r.GET("/teams/:id", func(ctx *gin.Context) {
+		_, span := otel.Tracer("test").Start(ctx.Request.Context(), "teams.Service.getTeam")
+		Error(span, errors.New("team not found"))
+		span.End()
+		ctx.Status(http.StatusOK)
+	})
+	r.GET("/healthz", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	request := httptest.NewRequest(http.MethodGet, "/teams/1", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), request)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "teams.Service.getTeam", child.Name)
+	assert.Equal(t, codes.Error, child.Status.Code)
+	assert.Equal(t, "team not found", child.Status.Description)
+	assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID())
+
+	assert.Equal(t, "/teams/:id", server.Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+}
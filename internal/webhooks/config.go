@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"errors"
+	"github.com/caarlos0/env/v11"
+	"time"
+)
+
+// Config bounds deliveries: how long a target may take to answer, how many
+// attempts it gets and how the wait between them grows, doubling from
+// BackoffBase up to BackoffMax.
+type Config struct {
+	Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	BackoffBase  time.Duration `env:"WEBHOOK_BACKOFF_BASE" envDefault:"10s"`
+	BackoffMax   time.Duration `env:"WEBHOOK_BACKOFF_MAX" envDefault:"1h"`
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"5s"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Timeout <= 0 || cfg.BackoffBase <= 0 || cfg.PollInterval <= 0 || cfg.MaxAttempts < 1 {
+		return nil, errors.New("WEBHOOK_TIMEOUT, WEBHOOK_BACKOFF_BASE, WEBHOOK_POLL_INTERVAL and WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+	if cfg.BackoffMax < cfg.BackoffBase {
+		return nil, errors.New("WEBHOOK_BACKOFF_MAX must not be shorter than WEBHOOK_BACKOFF_BASE")
+	}
+	return &cfg, nil
+}
+
+// backoff is the wait after the given failed attempt, counting from 1.
+func (c Config) backoff(attempt int) time.Duration {
+	wait := c.BackoffBase
+	for i := 1; i < attempt && wait < c.BackoffMax; i++ {
+		wait *= 2
+	}
+
+	return min(wait, c.BackoffMax)
+}
@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"sc-internacional/internal/apiversion"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/i18n"
+	"sc-internacional/internal/server"
+	"slices"
+	"strconv"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type service interface {
+	createSubscription(ctx context.Context, subscription Subscription) (Subscription, error)
+	getSubscriptions(ctx context.Context) ([]Subscription, error)
+	deleteSubscription(ctx context.Context, id string) (bool, error)
+	getDeliveries(ctx context.Context, subscriptionId string, status Status, limit int64) ([]Delivery, error)
+	retryDelivery(ctx context.Context, id string) (Delivery, error)
+}
+
+type Controller struct {
+	service service
+}
+
+func NewController(service service) *Controller {
+	return &Controller{service: service}
+}
+
+// PostWebhook subscribes a URL to events. The response carries the secret
+// the deliveries are signed with; it cannot be read again afterwards.
+func (c Controller) PostWebhook(ctx *gin.Context) {
+	var req Subscription
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(server.BindErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	if target, err := url.Parse(req.URL); err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("url must be an http or https URL")))
+		return
+	}
+	for _, eventType := range req.Events {
+		if !slices.Contains(events.Types, eventType) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, i18n.Errorf("unknown event type %q", eventType)))
+			return
+		}
+	}
+
+	subscription, err := c.service.createSubscription(ctx.Request.Context(), req)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusCreated, subscription)
+}
+
+func (c Controller) GetWebhooks(ctx *gin.Context) {
+	subscriptions, err := c.service.getSubscriptions(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, subscriptions)
+}
+
+func (c Controller) DeleteWebhook(ctx *gin.Context) {
+	deleted, err := c.service.deleteSubscription(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+	if !deleted {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("webhook not found")))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries lists the delivery history of a subscription, newest
+// first, optionally filtered by ?status=.
+func (c Controller) GetWebhookDeliveries(ctx *gin.Context) {
+	c.getDeliveries(ctx, ctx.Param("id"), Status(ctx.Query("status")))
+}
+
+// GetDeadLetters lists the deliveries of every subscription that ran out of
+// attempts, newest first.
+func (c Controller) GetDeadLetters(ctx *gin.Context) {
+	c.getDeliveries(ctx, "", Dead)
+}
+
+func (c Controller) getDeliveries(ctx *gin.Context, subscriptionId string, status Status) {
+	limit := int64(defaultLimit)
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 || parsed > maxLimit {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("limit must be between 1 and 1000")))
+			return
+		}
+		limit = parsed
+	}
+
+	switch status {
+	case "", Pending, Delivered, Dead:
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("status must be pending, delivered or dead")))
+		return
+	}
+
+	deliveries, err := c.service.getDeliveries(ctx.Request.Context(), subscriptionId, status, limit)
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusOK, deliveries)
+}
+
+// RetryWebhookDelivery queues a delivery again, dead or not.
+func (c Controller) RetryWebhookDelivery(ctx *gin.Context) {
+	delivery, err := c.service.retryDelivery(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(server.ErrorStatus(err), errorResponse(ctx, err))
+		return
+	}
+	if delivery.isEmpty() {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("delivery not found")))
+		return
+	}
+
+	apiversion.JSON(ctx, http.StatusAccepted, delivery)
+}
+
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	return gin.H{"error": i18n.Message(ctx.Request.Context(), err)}
+}
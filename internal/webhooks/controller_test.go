@@ -0,0 +1,155 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"sc-internacional/internal/events"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestController_PostWebhook(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		requestBody        string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when url is missing",
+			setup:              func(s *serviceMock) {},
+			requestBody:        "{\"events\": [\"match.finished\"]}",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "when url is not http",
+			setup:              func(s *serviceMock) {},
+			requestBody:        "{\"url\": \"ftp://example.com/hook\"}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"url must be an http or https URL\"}",
+		},
+		{
+			name:               "when an event type is unknown",
+			setup:              func(s *serviceMock) {},
+			requestBody:        "{\"url\": \"https://example.com/hook\", \"events\": [\"match.goal\"]}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"unknown event type \\\"match.goal\\\"\"}",
+		},
+		{
+			name:               "when the body is too large",
+			setup:              func(s *serviceMock) {},
+			requestBody:        "{\"url\": \"https://example.com/" + strings.Repeat("hook", 300) + "\"}",
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedBody:       "{\"error\":\"request body is too large\"}",
+		},
+		{
+			name: "when failed to create the subscription",
+			setup: func(s *serviceMock) {
+				s.On("createSubscription", mock.Anything, mock.Anything).Return(Subscription{}, errors.New("failed to create subscription"))
+			},
+			requestBody:        "{\"url\": \"https://example.com/hook\"}",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "{\"error\":\"failed to create subscription\"}",
+		},
+		{
+			name: "when successfully creates a subscription",
+			setup: func(s *serviceMock) {
+				received := Subscription{URL: "https://example.com/hook", Events: []events.Type{events.MatchFinished}}
+				created := Subscription{Id: "1", URL: "https://example.com/hook", Events: []events.Type{events.MatchFinished}, Secret: "s3cr3t", CreatedAt: time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)}
+				s.On("createSubscription", mock.Anything, received).Return(created, nil)
+			},
+			requestBody:        "{\"url\": \"https://example.com/hook\", \"events\": [\"match.finished\"]}",
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       "{\"id\":\"1\",\"url\":\"https://example.com/hook\",\"events\":[\"match.finished\"],\"secret\":\"s3cr3t\",\"createdAt\":\"2006-12-17T12:00:00Z\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.requestBody))
+			ctx.Request.Body = http.MaxBytesReader(recorder, ctx.Request.Body, 1024)
+
+			c.PostWebhook(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestController_GetWebhookDeliveries(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func(*serviceMock)
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "when status is unknown",
+			setup:              func(s *serviceMock) {},
+			query:              "status=lost",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "{\"error\":\"status must be pending, delivered or dead\"}",
+		},
+		{
+			name: "when successfully gets the history",
+			setup: func(s *serviceMock) {
+				s.On("getDeliveries", mock.Anything, "1", Dead, int64(10)).Return([]Delivery{}, nil)
+			},
+			query:              "status=dead&limit=10",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceMock{}
+			tt.setup(s)
+
+			c := NewController(s)
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.AddParam("id", "1")
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries?"+tt.query, nil)
+
+			c.GetWebhookDeliveries(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+type serviceMock struct {
+	service
+	mock.Mock
+}
+
+func (m *serviceMock) createSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	args := m.Called(ctx, subscription)
+
+	return args.Get(0).(Subscription), args.Error(1)
+}
+
+func (m *serviceMock) getDeliveries(ctx context.Context, subscriptionId string, status Status, limit int64) ([]Delivery, error) {
+	args := m.Called(ctx, subscriptionId, status, limit)
+
+	return args.Get(0).([]Delivery), args.Error(1)
+}
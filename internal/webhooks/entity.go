@@ -0,0 +1,49 @@
+package webhooks
+
+import (
+	"sc-internacional/internal/events"
+	"time"
+)
+
+type Status string
+
+const (
+	Pending   Status = "pending"
+	Delivered Status = "delivered"
+	Dead      Status = "dead"
+)
+
+// Subscription sends the events of the listed types, or of every type when
+// Events is empty, to URL. Secret signs every delivery and is only shown
+// when the subscription is created.
+type Subscription struct {
+	Id        string        `json:"id,omitempty" bson:"_id,omitempty"`
+	URL       string        `json:"url" binding:"required,url"`
+	Events    []events.Type `json:"events"`
+	Secret    string        `json:"secret,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+func (s *Subscription) isEmpty() bool {
+	return s.Id == "" && s.URL == ""
+}
+
+// Delivery is one event on its way to one subscription. It stays Pending,
+// retried at NextAttemptAt, until the target accepts it or it runs out of
+// attempts and goes Dead.
+type Delivery struct {
+	Id             string       `json:"id,omitempty" bson:"_id,omitempty"`
+	SubscriptionId string       `json:"subscriptionId"`
+	Event          events.Event `json:"event"`
+	Status         Status       `json:"status"`
+	Attempts       int          `json:"attempts"`
+	NextAttemptAt  time.Time    `json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time   `json:"lastAttemptAt,omitempty" bson:",omitempty"`
+	LastStatusCode int          `json:"lastStatusCode,omitempty"`
+	LastError      string       `json:"lastError,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt"`
+}
+
+func (d *Delivery) isEmpty() bool {
+	return d.Id == "" && d.SubscriptionId == ""
+}
@@ -0,0 +1,233 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/tracing"
+	"time"
+)
+
+type db interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+type deliveriesDB interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (cur *mongo.Cursor, err error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+}
+
+type Repository struct {
+	db
+	deliveries deliveriesDB
+}
+
+func NewRepository(db db, deliveries deliveriesDB) *Repository {
+	return &Repository{db: db, deliveries: deliveries}
+}
+
+func (r Repository) createSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.createSubscription")
+	defer span.End()
+
+	result, err := r.db.InsertOne(ctx, subscription)
+	if err != nil {
+		return Subscription{}, tracing.Error(span, err)
+	}
+
+	subscription.Id = result.InsertedID.(primitive.ObjectID).Hex()
+
+	return subscription, nil
+}
+
+func (r Repository) getSubscription(ctx context.Context, id string) (Subscription, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.getSubscription")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Subscription{}, tracing.Error(span, err)
+	}
+
+	var subscription Subscription
+	err = r.db.FindOne(ctx, bson.M{"_id": docID}).Decode(&subscription)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Subscription{}, nil
+	}
+	if err != nil {
+		return Subscription{}, tracing.Error(span, err)
+	}
+
+	return subscription, nil
+}
+
+// getSubscriptions returns the subscriptions interested in eventType, or
+// every subscription when eventType is empty.
+func (r Repository) getSubscriptions(ctx context.Context, eventType events.Type) ([]Subscription, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.getSubscriptions")
+	defer span.End()
+
+	filter := bson.M{}
+	if eventType != "" {
+		filter = bson.M{"$or": bson.A{
+			bson.M{"events": eventType},
+			bson.M{"events": bson.M{"$in": bson.A{nil, bson.A{}}}},
+		}}
+	}
+
+	cursor, err := r.db.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}}))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []Subscription{}
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return subscriptions, nil
+}
+
+// deleteSubscription reports whether there was a subscription to delete.
+// Its pending deliveries go dead the next time they are attempted.
+func (r Repository) deleteSubscription(ctx context.Context, id string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.deleteSubscription")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, tracing.Error(span, err)
+	}
+
+	result, err := r.db.DeleteOne(ctx, bson.M{"_id": docID})
+	if err != nil {
+		return false, tracing.Error(span, err)
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// createDelivery queues a delivery. A subscription already holding one for
+// the same event, as happens when the outbox relays an event again, keeps
+// it: the unique index on subscriptionid and event._id refuses the copy,
+// and an empty delivery is returned.
+func (r Repository) createDelivery(ctx context.Context, delivery Delivery) (Delivery, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.createDelivery")
+	defer span.End()
+
+	result, err := r.deliveries.InsertOne(ctx, delivery)
+	if mongo.IsDuplicateKeyError(err) {
+		return Delivery{}, nil
+	}
+	if err != nil {
+		return Delivery{}, tracing.Error(span, err)
+	}
+
+	delivery.Id = result.InsertedID.(primitive.ObjectID).Hex()
+
+	return delivery, nil
+}
+
+func (r Repository) getDelivery(ctx context.Context, id string) (Delivery, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.getDelivery")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Delivery{}, tracing.Error(span, err)
+	}
+
+	var delivery Delivery
+	err = r.deliveries.FindOne(ctx, bson.M{"_id": docID}).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Delivery{}, nil
+	}
+	if err != nil {
+		return Delivery{}, tracing.Error(span, err)
+	}
+
+	return delivery, nil
+}
+
+// getDeliveries returns the newest deliveries first. Empty subscriptionId or
+// status match every value.
+func (r Repository) getDeliveries(ctx context.Context, subscriptionId string, status Status, limit int64) ([]Delivery, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.getDeliveries")
+	defer span.End()
+
+	filter := bson.M{}
+	if subscriptionId != "" {
+		filter["subscriptionid"] = subscriptionId
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}}).SetLimit(limit)
+	cursor, err := r.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []Delivery{}
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return deliveries, nil
+}
+
+// claimDelivery takes the pending delivery that has waited the longest, if
+// one is due at now, and pushes its next attempt to until. Another replica
+// polling meanwhile skips it, and picks it up again should this one die
+// before recording the attempt.
+func (r Repository) claimDelivery(ctx context.Context, now, until time.Time) (Delivery, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.claimDelivery")
+	defer span.End()
+
+	filter := bson.M{"status": Pending, "nextattemptat": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"nextattemptat": until}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "nextattemptat", Value: 1}})
+
+	var delivery Delivery
+	err := r.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Delivery{}, nil
+	}
+	if err != nil {
+		return Delivery{}, tracing.Error(span, err)
+	}
+
+	return delivery, nil
+}
+
+func (r Repository) updateDelivery(ctx context.Context, delivery Delivery) error {
+	ctx, span := tracer.Start(ctx, "webhooks.Repository.updateDelivery")
+	defer span.End()
+
+	docID, err := primitive.ObjectIDFromHex(delivery.Id)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	replacement := delivery
+	replacement.Id = ""
+
+	if _, err = r.deliveries.ReplaceOne(ctx, bson.M{"_id": docID}, replacement); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"io"
+	"log/slog"
+	"net/http"
+	"sc-internacional/internal/events"
+	"sc-internacional/internal/tracing"
+	"strconv"
+	"time"
+)
+
+var tracer = otel.Tracer("sc-internacional/internal/webhooks")
+
+type repository interface {
+	createSubscription(ctx context.Context, subscription Subscription) (Subscription, error)
+	getSubscription(ctx context.Context, id string) (Subscription, error)
+	getSubscriptions(ctx context.Context, eventType events.Type) ([]Subscription, error)
+	deleteSubscription(ctx context.Context, id string) (bool, error)
+	createDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+	getDelivery(ctx context.Context, id string) (Delivery, error)
+	getDeliveries(ctx context.Context, subscriptionId string, status Status, limit int64) ([]Delivery, error)
+	claimDelivery(ctx context.Context, now, until time.Time) (Delivery, error)
+	updateDelivery(ctx context.Context, delivery Delivery) error
+}
+
+type Service struct {
+	repository repository
+	config     Config
+	client     *http.Client
+	now        func() time.Time
+	wake       chan struct{}
+}
+
+func NewService(repository repository, config *Config) *Service {
+	return &Service{
+		repository: repository,
+		config:     *config,
+		client:     &http.Client{Timeout: config.Timeout},
+		now:        time.Now,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+func (s Service) createSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.createSubscription")
+	defer span.End()
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return Subscription{}, tracing.Error(span, err)
+	}
+
+	subscription.Id = ""
+	subscription.Secret = hex.EncodeToString(secret)
+	subscription.CreatedAt = s.now().UTC().Truncate(time.Millisecond)
+	if subscription.Events == nil {
+		subscription.Events = []events.Type{}
+	}
+
+	created, err := s.repository.createSubscription(ctx, subscription)
+	if err != nil {
+		return Subscription{}, tracing.Error(span, err)
+	}
+
+	return created, nil
+}
+
+// getSubscriptions lists every subscription without its secret.
+func (s Service) getSubscriptions(ctx context.Context) ([]Subscription, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.getSubscriptions")
+	defer span.End()
+
+	subscriptions, err := s.repository.getSubscriptions(ctx, "")
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+func (s Service) deleteSubscription(ctx context.Context, id string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.deleteSubscription")
+	defer span.End()
+
+	deleted, err := s.repository.deleteSubscription(ctx, id)
+	if err != nil {
+		return false, tracing.Error(span, err)
+	}
+
+	return deleted, nil
+}
+
+// getDeliveries returns the delivery history of a subscription, or of every
+// subscription when subscriptionId is empty, newest first.
+func (s Service) getDeliveries(ctx context.Context, subscriptionId string, status Status, limit int64) ([]Delivery, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.getDeliveries")
+	defer span.End()
+
+	deliveries, err := s.repository.getDeliveries(ctx, subscriptionId, status, limit)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return deliveries, nil
+}
+
+// retryDelivery sends a delivery again right away with a fresh set of
+// attempts, typically to take it off the dead-letter list once its target is
+// fixed. It returns an empty delivery when there is none with the given id.
+func (s Service) retryDelivery(ctx context.Context, id string) (Delivery, error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.retryDelivery")
+	defer span.End()
+
+	delivery, err := s.repository.getDelivery(ctx, id)
+	if err != nil {
+		return Delivery{}, tracing.Error(span, err)
+	}
+	if delivery.isEmpty() {
+		return Delivery{}, nil
+	}
+
+	delivery.Status = Pending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = s.now().UTC()
+	if err = s.repository.updateDelivery(ctx, delivery); err != nil {
+		return Delivery{}, tracing.Error(span, err)
+	}
+
+	s.notify()
+
+	return delivery, nil
+}
+
+// HandleEvent queues a delivery of the event for every subscription
+// interested in its type. Run sends them. Subscriptions already holding a
+// delivery of the event are skipped, so that an event handled again is not
+// sent twice, and a failure for one subscription does not skip the others.
+func (s Service) HandleEvent(ctx context.Context, event events.Event) error {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.HandleEvent")
+	defer span.End()
+
+	subscriptions, err := s.repository.getSubscriptions(ctx, event.Type)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	now := s.now().UTC().Truncate(time.Millisecond)
+	var errs []error
+	queued := false
+	for _, subscription := range subscriptions {
+		delivery, err := s.repository.createDelivery(ctx, Delivery{
+			SubscriptionId: subscription.Id,
+			Event:          event,
+			Status:         Pending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		queued = queued || !delivery.isEmpty()
+	}
+
+	if queued {
+		s.notify()
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// Run sends due deliveries until ctx is done, polling every PollInterval and
+// right away whenever a delivery is queued by this process.
+func (s Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s Service) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := s.now().UTC()
+		// The claim outlives the request timeout, so that a slow target is
+		// not sent the same delivery twice.
+		delivery, err := s.repository.claimDelivery(ctx, now, now.Add(2*s.config.Timeout))
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to claim webhook delivery", "error", err)
+			return
+		}
+		if delivery.isEmpty() {
+			return
+		}
+
+		s.deliver(ctx, delivery)
+	}
+}
+
+func (s Service) deliver(ctx context.Context, delivery Delivery) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.deliver")
+	defer span.End()
+	span.SetAttributes(attribute.String("webhook.delivery_id", delivery.Id), attribute.String("event.type", string(delivery.Event.Type)))
+
+	subscription, err := s.repository.getSubscription(ctx, delivery.SubscriptionId)
+	if err != nil {
+		tracing.Error(span, err)
+		slog.ErrorContext(ctx, "failed to load webhook subscription", "delivery_id", delivery.Id, "error", err)
+		return
+	}
+
+	if subscription.isEmpty() {
+		delivery.Status = Dead
+		delivery.LastError = "subscription was deleted"
+	} else {
+		statusCode, err := s.send(ctx, subscription, delivery)
+		delivery = s.record(delivery, statusCode, err)
+	}
+
+	if err = s.repository.updateDelivery(ctx, delivery); err != nil {
+		tracing.Error(span, err)
+		slog.ErrorContext(ctx, "failed to record webhook delivery", "delivery_id", delivery.Id, "error", err)
+	}
+}
+
+// send posts the event to the subscription URL, signed with its secret, and
+// returns the status the target answered with.
+func (s Service) send(ctx context.Context, subscription Subscription, delivery Delivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := s.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "sc-internacional-webhooks")
+	request.Header.Set("X-Webhook-Id", delivery.Id)
+	request.Header.Set("X-Webhook-Event", string(delivery.Event.Type))
+	request.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Webhook-Signature", Sign(subscription.Secret, timestamp, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	// Draining a bounded part of the body lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	return response.StatusCode, nil
+}
+
+// record updates a delivery with the outcome of an attempt: delivered on a
+// 2xx answer, retried after a growing wait otherwise, and dead once it ran
+// out of attempts.
+func (s Service) record(delivery Delivery, statusCode int, err error) Delivery {
+	now := s.now().UTC().Truncate(time.Millisecond)
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+
+	switch {
+	case err != nil:
+		delivery.LastError = err.Error()
+	case statusCode >= 200 && statusCode < 300:
+		delivery.Status = Delivered
+		return delivery
+	default:
+		delivery.LastError = fmt.Sprintf("target answered %d", statusCode)
+	}
+
+	if delivery.Attempts >= s.config.MaxAttempts {
+		delivery.Status = Dead
+		return delivery
+	}
+
+	delivery.NextAttemptAt = now.Add(s.config.backoff(delivery.Attempts))
+
+	return delivery
+}
@@ -0,0 +1,240 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sc-internacional/internal/events"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestService_HandleEvent(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)
+	event := events.Event{Id: "e1", Type: events.MatchFinished, EntityId: "10"}
+	delivery := func(subscriptionId string) Delivery {
+		return Delivery{SubscriptionId: subscriptionId, Event: event, Status: Pending, NextAttemptAt: now, CreatedAt: now}
+	}
+	tests := []struct {
+		name     string
+		setup    func(r *repositoryMock)
+		wantErr  error
+		wantWake bool
+	}{
+		{
+			name: "when deliveries are queued",
+			setup: func(r *repositoryMock) {
+				r.On("createDelivery", mock.Anything, delivery("s1")).Return(Delivery{Id: "d1"}, nil)
+				r.On("createDelivery", mock.Anything, delivery("s2")).Return(Delivery{Id: "d2"}, nil)
+			},
+			wantWake: true,
+		},
+		{
+			name: "when the event was already handled",
+			setup: func(r *repositoryMock) {
+				r.On("createDelivery", mock.Anything, delivery("s1")).Return(Delivery{}, nil)
+				r.On("createDelivery", mock.Anything, delivery("s2")).Return(Delivery{}, nil)
+			},
+		},
+		{
+			name: "when a delivery cannot be queued",
+			setup: func(r *repositoryMock) {
+				r.On("createDelivery", mock.Anything, delivery("s1")).Return(Delivery{}, errors.New("failed to insert"))
+				r.On("createDelivery", mock.Anything, delivery("s2")).Return(Delivery{Id: "d2"}, nil)
+			},
+			wantErr:  errors.New("failed to insert"),
+			wantWake: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repositoryMock{}
+			r.On("getSubscriptions", mock.Anything, events.MatchFinished).Return([]Subscription{{Id: "s1"}, {Id: "s2"}}, nil)
+			tt.setup(r)
+
+			s := NewService(r, &Config{Timeout: time.Second, MaxAttempts: 3, BackoffBase: time.Second, BackoffMax: time.Minute, PollInterval: time.Second})
+			s.now = func() time.Time { return now }
+
+			err := s.HandleEvent(context.Background(), event)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			r.AssertExpectations(t)
+			assert.Equal(t, tt.wantWake, len(s.wake) == 1, "the worker is woken up for new deliveries only")
+		})
+	}
+}
+
+func TestService_deliver(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)
+	config := &Config{Timeout: time.Second, MaxAttempts: 3, BackoffBase: 10 * time.Second, BackoffMax: time.Hour, PollInterval: time.Second}
+	event := events.Event{Id: "e1", Type: events.MatchFinished, EntityId: "10", OccurredAt: now}
+
+	var status int
+	var received *http.Request
+	var body []byte
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer target.Close()
+
+	subscription := Subscription{Id: "s1", URL: target.URL, Secret: "secret"}
+	tests := []struct {
+		name         string
+		subscription Subscription
+		attempts     int
+		status       int
+		want         Delivery
+	}{
+		{
+			name:         "when target accepts the delivery",
+			subscription: subscription,
+			status:       http.StatusNoContent,
+			want:         Delivery{Id: "d1", SubscriptionId: "s1", Event: event, Status: Delivered, Attempts: 1, NextAttemptAt: now, LastAttemptAt: &now, LastStatusCode: http.StatusNoContent},
+		},
+		{
+			name:         "when target fails",
+			subscription: subscription,
+			attempts:     1,
+			status:       http.StatusInternalServerError,
+			want:         Delivery{Id: "d1", SubscriptionId: "s1", Event: event, Status: Pending, Attempts: 2, NextAttemptAt: now.Add(20 * time.Second), LastAttemptAt: &now, LastStatusCode: http.StatusInternalServerError, LastError: "target answered 500"},
+		},
+		{
+			name:         "when target fails for the last time",
+			subscription: subscription,
+			attempts:     2,
+			status:       http.StatusBadGateway,
+			want:         Delivery{Id: "d1", SubscriptionId: "s1", Event: event, Status: Dead, Attempts: 3, NextAttemptAt: now, LastAttemptAt: &now, LastStatusCode: http.StatusBadGateway, LastError: "target answered 502"},
+		},
+		{
+			name:         "when subscription was deleted",
+			subscription: Subscription{},
+			want:         Delivery{Id: "d1", SubscriptionId: "s1", Event: event, Status: Dead, NextAttemptAt: now, LastError: "subscription was deleted"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			received = nil
+
+			r := &repositoryMock{}
+			r.On("getSubscription", mock.Anything, "s1").Return(tt.subscription, nil)
+			r.On("updateDelivery", mock.Anything, tt.want).Return(nil)
+
+			s := NewService(r, config)
+			s.now = func() time.Time { return now }
+
+			s.deliver(context.Background(), Delivery{Id: "d1", SubscriptionId: "s1", Event: event, Status: Pending, Attempts: tt.attempts, NextAttemptAt: now})
+
+			r.AssertExpectations(t)
+			if tt.subscription.isEmpty() {
+				assert.Nil(t, received)
+				return
+			}
+			assert.Equal(t, "d1", received.Header.Get("X-Webhook-Id"))
+			assert.Equal(t, "match.finished", received.Header.Get("X-Webhook-Event"))
+			assert.Equal(t, strconv.FormatInt(now.Unix(), 10), received.Header.Get("X-Webhook-Timestamp"))
+			assert.Equal(t, Sign("secret", now.Unix(), body), received.Header.Get("X-Webhook-Signature"))
+			assert.JSONEq(t, "{\"id\":\"e1\",\"type\":\"match.finished\",\"entityId\":\"10\",\"occurredAt\":\"2006-12-17T12:00:00Z\"}", string(body))
+		})
+	}
+}
+
+func TestService_deliver_whenTargetIsUnreachable(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)
+	target := httptest.NewServer(http.NotFoundHandler())
+	target.Close()
+
+	r := &repositoryMock{}
+	r.On("getSubscription", mock.Anything, "s1").Return(Subscription{Id: "s1", URL: target.URL, Secret: "secret"}, nil)
+	r.On("updateDelivery", mock.Anything, mock.MatchedBy(func(d Delivery) bool {
+		return d.Status == Pending && d.Attempts == 1 && d.NextAttemptAt.Equal(now.Add(10*time.Second)) && d.LastError != ""
+	})).Return(nil)
+
+	s := NewService(r, &Config{Timeout: time.Second, MaxAttempts: 3, BackoffBase: 10 * time.Second, BackoffMax: time.Hour, PollInterval: time.Second})
+	s.now = func() time.Time { return now }
+
+	s.deliver(context.Background(), Delivery{Id: "d1", SubscriptionId: "s1", Status: Pending, NextAttemptAt: now})
+
+	r.AssertExpectations(t)
+}
+
+func TestService_retryDelivery(t *testing.T) {
+	now := time.Date(2006, time.December, 17, 12, 0, 0, 0, time.UTC)
+	dead := Delivery{Id: "d1", SubscriptionId: "s1", Status: Dead, Attempts: 8, LastError: "target answered 500"}
+	retried := Delivery{Id: "d1", SubscriptionId: "s1", Status: Pending, NextAttemptAt: now, LastError: "target answered 500"}
+
+	r := &repositoryMock{}
+	r.On("getDelivery", mock.Anything, "d1").Return(dead, nil)
+	r.On("updateDelivery", mock.Anything, retried).Return(nil)
+
+	s := NewService(r, &Config{Timeout: time.Second, MaxAttempts: 8, BackoffBase: time.Second, BackoffMax: time.Minute, PollInterval: time.Second})
+	s.now = func() time.Time { return now }
+
+	got, err := s.retryDelivery(context.Background(), "d1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, retried, got)
+	r.AssertExpectations(t)
+}
+
+func TestConfig_backoff(t *testing.T) {
+	c := Config{BackoffBase: 10 * time.Second, BackoffMax: time.Minute}
+
+	assert.Equal(t, 10*time.Second, c.backoff(1))
+	assert.Equal(t, 20*time.Second, c.backoff(2))
+	assert.Equal(t, 40*time.Second, c.backoff(3))
+	assert.Equal(t, time.Minute, c.backoff(4))
+	assert.Equal(t, time.Minute, c.backoff(60), "long outages do not overflow")
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "sha256=cae1bdc702bf9d1118bbd6ad19b11fe284b22e5c4f29c255c6bcc1e50a923412", Sign("secret", 1166356800, []byte("{}")))
+	assert.NotEqual(t, Sign("secret", 1166356800, []byte("{}")), Sign("other", 1166356800, []byte("{}")))
+	assert.NotEqual(t, Sign("secret", 1166356800, []byte("{}")), Sign("secret", 1166356801, []byte("{}")))
+}
+
+type repositoryMock struct {
+	repository
+	mock.Mock
+}
+
+func (m *repositoryMock) getSubscription(ctx context.Context, id string) (Subscription, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Subscription), args.Error(1)
+}
+
+func (m *repositoryMock) getSubscriptions(ctx context.Context, eventType events.Type) ([]Subscription, error) {
+	args := m.Called(ctx, eventType)
+
+	return args.Get(0).([]Subscription), args.Error(1)
+}
+
+func (m *repositoryMock) createDelivery(ctx context.Context, delivery Delivery) (Delivery, error) {
+	args := m.Called(ctx, delivery)
+
+	return args.Get(0).(Delivery), args.Error(1)
+}
+
+func (m *repositoryMock) getDelivery(ctx context.Context, id string) (Delivery, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(Delivery), args.Error(1)
+}
+
+func (m *repositoryMock) updateDelivery(ctx context.Context, delivery Delivery) error {
+	args := m.Called(ctx, delivery)
+
+	return args.Error(0)
+}
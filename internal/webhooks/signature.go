@@ -0,0 +1,21 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Sign computes the X-Webhook-Signature of a delivery: the hex HMAC-SHA256,
+// keyed with the subscription secret, of the X-Webhook-Timestamp header, a
+// dot and the raw body. Receivers recompute it to check that a delivery came
+// from us and was not replayed long after timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}